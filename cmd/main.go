@@ -16,7 +16,7 @@ import (
 func main() {
 	cfg := config.MustLoad()
 
-	appLogger, closeLogFile, err := logger.SetupLogger(cfg.Logger.LogPath, cfg.Logger.LevelInfo)
+	appLogger, closeLogFile, err := logger.SetupLogger(cfg.Logger.LogPath, cfg.Logger.LevelInfo, cfg.Logger.Format)
 	if err != nil {
 		slog.Error("error while setting up logger", slog.Any("error", err))
 		return
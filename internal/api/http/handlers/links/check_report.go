@@ -0,0 +1,167 @@
+package links
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/polonkoevv/linkchecker/internal/api/http/response"
+	"github.com/polonkoevv/linkchecker/internal/models"
+	"github.com/polonkoevv/linkchecker/internal/pdfgenerator"
+)
+
+// linksNumHeader carries the number of the group stored by POST /links/report,
+// as PDF and CSV responses have no body field for it.
+const linksNumHeader = "X-Links-Num"
+
+// CheckReportRequest represents a request payload for checking links and reporting on them at once.
+type CheckReportRequest struct {
+	CheckLinksRequest
+	Report models.ReportOptions `json:"report,omitempty"`
+}
+
+// CheckReport handles POST /links/report: it checks the links like POST /links, stores the group
+// and responds with its report in one round trip. The report is a PDF by default, HTML for
+// Accept: text/html or ?format=html, CSV rows of the results for Accept: text/csv and the results
+// with statistics for Accept: application/json.
+func (h *Handler) CheckReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.RequestTimeout)
+	defer cancel()
+
+	format, err := reportFormat(r)
+	if err != nil {
+		slog.WarnContext(ctx, "validation failed: invalid report format", slog.String("handler", "CheckReport"))
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
+		return
+	}
+
+	var req CheckReportRequest
+	if err := decodeRequest(r.Body, &req, "links"); err != nil {
+		slog.WarnContext(ctx, "validation failed: invalid request body",
+			slog.String("handler", "CheckReport"),
+			slog.Any("error", err),
+		)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
+		return
+	}
+
+	opts, err := req.validate()
+	if err != nil {
+		slog.WarnContext(ctx, "validation failed: invalid check request",
+			slog.String("handler", "CheckReport"),
+			slog.Any("error", err),
+		)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
+		return
+	}
+
+	result, err := h.Service.CheckMany(ctx, req.urls(), opts)
+	if err != nil {
+		writeCheckError(ctx, w, "CheckReport", err)
+		return
+	}
+	w.Header().Set(linksNumHeader, strconv.Itoa(result.LinksNum))
+
+	// an explicit format always returns the document
+	accept := r.Header.Get("Accept")
+	if r.URL.Query().Has("format") {
+		accept = ""
+	}
+	if strings.Contains(accept, "text/csv") {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", "attachment; filename=link_report.csv")
+		if err := writeResultsCSV(w, result.Results); err != nil {
+			slog.ErrorContext(ctx, "failed to send CSV to client",
+				slog.String("handler", "CheckReport"),
+				slog.Any("error", err),
+			)
+		}
+		return
+	}
+
+	wantJSON := strings.Contains(accept, "application/json")
+	req.Report.Format = format
+	if wantJSON {
+		req.Report.Format = models.ReportFormatPDF
+	}
+	doc := &reportWriter{w: w, format: req.Report.Format}
+	var out io.Writer = doc
+	if wantJSON {
+		out = io.Discard
+	}
+
+	report, err := h.Service.GenerateReport(ctx, []int{result.LinksNum}, req.Report, out)
+	if err != nil {
+		if doc.started {
+			slog.ErrorContext(ctx, "failed to send report to client",
+				slog.String("handler", "CheckReport"),
+				slog.Any("error", err),
+			)
+			return
+		}
+		writeReportError(ctx, w, "CheckReport", err)
+		return
+	}
+
+	slog.DebugContext(ctx, "links checked and reported",
+		slog.String("handler", "CheckReport"),
+		slog.Int("links_num", result.LinksNum),
+		slog.Int("links_count", len(req.Links)),
+		slog.Int("size_bytes", report.Size),
+	)
+
+	if wantJSON {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(models.CheckReportResponse{
+			LinksResponse: result,
+			Statistics:    report.Statistics,
+			Hosts:         report.Hosts,
+		}); err != nil {
+			slog.ErrorContext(ctx, "failed to encode response",
+				slog.String("handler", "CheckReport"),
+				slog.Any("error", err),
+			)
+		}
+	}
+}
+
+// validate checks the request like POST /links does and builds check options from it.
+// Async checks are rejected, as the report needs the results, and so are report options
+// that could only fail after the links were checked.
+func (req CheckReportRequest) validate() (models.CheckOptions, error) {
+	if len(req.Links) == 0 {
+		return models.CheckOptions{}, errors.New("Links array cannot be empty")
+	}
+	if req.Workers < 0 {
+		return models.CheckOptions{}, errors.New("workers must not be negative")
+	}
+	if req.Async {
+		return models.CheckOptions{}, errors.New("async: not supported for reports")
+	}
+	if err := pdfgenerator.ValidateOptions(req.Report); err != nil {
+		return models.CheckOptions{}, err
+	}
+	return req.checkOptions()
+}
+
+// writeResultsCSV writes results as CSV with a header row, in the order the links were submitted.
+func writeResultsCSV(w io.Writer, results []models.LinkResult) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"url", "status", "method", "label"}); err != nil {
+		return err
+	}
+	for _, result := range results {
+		if err := cw.Write([]string{result.URL, string(result.Status), result.Method, result.Label}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,100 @@
+package links
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// decodeRequest decodes the JSON object in body into dst, rejecting fields dst does not
+// declare, values of the wrong type and missing required fields.
+// Errors name the offending field so they can be returned to the client as is.
+func decodeRequest(body io.Reader, dst any, required ...string) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("read request body: %w", err)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return errors.New("request body must be a JSON object")
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return errors.New("request body must be a JSON object")
+		}
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	for _, name := range required {
+		if _, ok := fields[name]; !ok {
+			return fmt.Errorf("%s: field is required", name)
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		return decodeError(err)
+	}
+
+	return nil
+}
+
+// decodeError rewrites a json decoding error into a message naming the field at fault.
+func decodeError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		field := typeErr.Field
+		if field == "" {
+			field = "request body"
+		}
+		return fmt.Errorf("%s: must be %s, got %s", field, jsonType(typeErr.Type), typeErr.Value)
+	}
+
+	if field, ok := unknownField(err); ok {
+		return fmt.Errorf("%s: unknown field", field)
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	return err
+}
+
+// unknownField returns the field named by a DisallowUnknownFields error.
+// encoding/json has no typed error for it, the message reads: json: unknown field "name".
+func unknownField(err error) (string, bool) {
+	name, ok := strings.CutPrefix(err.Error(), "json: unknown field ")
+	if !ok {
+		return "", false
+	}
+	return strings.Trim(name, `"`), true
+}
+
+// jsonType names the JSON type a Go value of type t is decoded from.
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Map, reflect.Struct:
+		return "an object"
+	default:
+		return "a " + t.Kind().String()
+	}
+}
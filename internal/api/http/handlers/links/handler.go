@@ -6,215 +6,1385 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/polonkoevv/linkchecker/internal/api/http/response"
 	"github.com/polonkoevv/linkchecker/internal/models"
 )
 
 // CheckLinksRequest represents a request payload for checking multiple links.
 type CheckLinksRequest struct {
-	Links []string `json:"links"`
+	Links   []LinkItem `json:"links"`
+	Workers int        `json:"workers,omitempty"`
+	Async   bool       `json:"async,omitempty"`
+	// ExpectedStatus is a status code, class or range ("200", "2xx", "200-299") required of every link.
+	ExpectedStatus string `json:"expected_status,omitempty"`
+	// ExpectedStatuses overrides ExpectedStatus for individual links.
+	ExpectedStatuses map[string]string `json:"expected_statuses,omitempty"`
+	// Method is the HTTP method every link is checked with, HEAD by default.
+	Method string `json:"method,omitempty"`
+	// ExpectContent is a substring the body of every available link must contain.
+	ExpectContent string `json:"expect_content,omitempty"`
+	// ExpectContentRegex is a regular expression the body of every available link must match.
+	ExpectContentRegex string `json:"expect_content_regex,omitempty"`
+	// Name is an optional label stored with the group.
+	Name string `json:"name,omitempty"`
+	// BudgetSeconds bounds the whole check, links not checked in time are reported as skipped.
+	BudgetSeconds int `json:"budget_seconds,omitempty"`
+	// Samples is how many times every link is checked to aggregate min/avg/max durations.
+	Samples int `json:"samples,omitempty"`
+	// Accept overrides the Accept header sent with the checks of this batch.
+	Accept string `json:"accept,omitempty"`
+	// CORS checks every link with a CORS preflight OPTIONS request instead of Method.
+	CORS *CORSRequest `json:"cors,omitempty"`
+}
+
+// CORSRequest describes the cross-origin request whose preflight is sent to every link.
+type CORSRequest struct {
+	Origin string `json:"origin"`
+	// Method is the method the preflight asks permission for, GET by default.
+	Method  string   `json:"method,omitempty"`
+	Headers []string `json:"headers,omitempty"`
+}
+
+// maxCORSHeaders limits the request headers a preflight asks permission for.
+const maxCORSHeaders = 20
+
+// preflight validates the origin, method and headers and builds the preflight from them.
+func (r CORSRequest) preflight() (models.CORSPreflight, error) {
+	origin := strings.TrimSpace(r.Origin)
+	u, err := url.Parse(origin)
+	if origin == "" || err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+		u.User != nil || u.Path != "" || u.RawQuery != "" || u.Fragment != "" {
+		return models.CORSPreflight{}, errors.New("cors.origin: must be a scheme and host, e.g. https://example.com")
+	}
+
+	method := strings.ToUpper(strings.TrimSpace(r.Method))
+	if method == "" {
+		method = http.MethodGet
+	}
+	if !isToken(method) {
+		return models.CORSPreflight{}, fmt.Errorf("cors.method: invalid HTTP method %q", r.Method)
+	}
+
+	if len(r.Headers) > maxCORSHeaders {
+		return models.CORSPreflight{}, fmt.Errorf("cors.headers: must have at most %d headers", maxCORSHeaders)
+	}
+	headers := make([]string, 0, len(r.Headers))
+	for _, h := range r.Headers {
+		h = strings.TrimSpace(h)
+		if !isToken(h) {
+			return models.CORSPreflight{}, fmt.Errorf("cors.headers: invalid header name %q", h)
+		}
+		headers = append(headers, h)
+	}
+
+	return models.CORSPreflight{Origin: origin, Method: method, Headers: headers}, nil
+}
+
+// isToken reports whether s is a non-empty HTTP token, as methods and header names are.
+func isToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	return !strings.ContainsFunc(s, func(r rune) bool {
+		isAlnum := r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+		return !isAlnum && !strings.ContainsRune("!#$%&'*+-.^_`|~", r)
+	})
+}
+
+// LinkItem is a link to check, given in JSON either as a URL string
+// or as an object with a URL, a label and the HTTP method to check it with.
+type LinkItem struct {
+	URL    string `json:"url"`
+	Label  string `json:"label,omitempty"`
+	Method string `json:"method,omitempty"`
+}
+
+// UnmarshalJSON accepts both "https://example.com" and {"url": "https://example.com", "label": "...", "method": "GET"}.
+func (l *LinkItem) UnmarshalJSON(data []byte) error {
+	var url string
+	if err := json.Unmarshal(data, &url); err == nil {
+		*l = LinkItem{URL: url}
+		return nil
+	}
+
+	// linkItem drops the UnmarshalJSON method to decode the object form.
+	type linkItem LinkItem
+	var item linkItem
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&item); err != nil {
+		if field, ok := unknownField(err); ok {
+			return fmt.Errorf("links.%s: unknown field", field)
+		}
+		return errors.New("links: each link must be a URL string or an object with url, label and method")
+	}
+	if item.URL == "" {
+		return errors.New("links: link object must have a url")
+	}
+	*l = LinkItem(item)
+	return nil
+}
+
+// urls returns the URLs of the requested links in order.
+func (req CheckLinksRequest) urls() []string {
+	urls := make([]string, len(req.Links))
+	for i, link := range req.Links {
+		urls[i] = link.URL
+	}
+	return urls
+}
+
+// maxGroupNameLength limits the label stored with a group.
+const maxGroupNameLength = 200
+
+// maxLinkLabelLength limits the label stored with a single link.
+const maxLinkLabelLength = 200
+
+// maxSamples limits how many times a single link is checked per request.
+const maxSamples = 20
+
+// maxAcceptLength limits the Accept header a batch may override.
+const maxAcceptLength = 255
+
+// fail_on_broken values of POST /links: respond with Handler.BrokenStatus when any or all links are broken.
+const (
+	failOnBrokenAny = "any"
+	failOnBrokenAll = "all"
+)
+
+// idempotencyKeyHeader carries a client key that makes retried POST /links return the first stored group.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// maxIdempotencyKeyLength limits the remembered Idempotency-Key values.
+const maxIdempotencyKeyLength = 255
+
+// checkOptions validates the group name, budget, samples, expected statuses, methods, Accept header, CORS preflight
+// and content of the request and builds check options from it.
+func (req CheckLinksRequest) checkOptions() (models.CheckOptions, error) {
+	opts := models.CheckOptions{Workers: req.Workers, Name: strings.TrimSpace(req.Name)}
+	if len(opts.Name) > maxGroupNameLength {
+		return models.CheckOptions{}, fmt.Errorf("name: must be at most %d characters", maxGroupNameLength)
+	}
+
+	if req.BudgetSeconds < 0 {
+		return models.CheckOptions{}, errors.New("budget_seconds: must not be negative")
+	}
+	opts.Budget = time.Duration(req.BudgetSeconds) * time.Second
+
+	if req.Samples < 0 || req.Samples > maxSamples {
+		return models.CheckOptions{}, fmt.Errorf("samples: must be between 0 and %d", maxSamples)
+	}
+	opts.Samples = req.Samples
+
+	if req.ExpectedStatus != "" {
+		r, err := models.ParseStatusRange(req.ExpectedStatus)
+		if err != nil {
+			return models.CheckOptions{}, fmt.Errorf("expected_status: %w", err)
+		}
+		opts.ExpectedStatus = r
+	}
+
+	if len(req.ExpectedStatuses) > 0 {
+		opts.ExpectedStatusByURL = make(map[string]models.StatusRange, len(req.ExpectedStatuses))
+		for link, value := range req.ExpectedStatuses {
+			r, err := models.ParseStatusRange(value)
+			if err != nil {
+				return models.CheckOptions{}, fmt.Errorf("expected_statuses: %s: %w", link, err)
+			}
+			opts.ExpectedStatusByURL[link] = r
+		}
+	}
+
+	if req.Method != "" {
+		method, err := models.ParseCheckMethod(req.Method)
+		if err != nil {
+			return models.CheckOptions{}, fmt.Errorf("method: %w", err)
+		}
+		opts.Method = method
+	}
+
+	for _, link := range req.Links {
+		if link.Method == "" {
+			continue
+		}
+		method, err := models.ParseCheckMethod(link.Method)
+		if err != nil {
+			return models.CheckOptions{}, fmt.Errorf("links: %s: method: %w", link.URL, err)
+		}
+		if opts.MethodByURL == nil {
+			opts.MethodByURL = make(map[string]string)
+		}
+		// a URL listed twice keeps its first method, as the check keeps its first occurrence
+		if _, ok := opts.MethodByURL[link.URL]; !ok {
+			opts.MethodByURL[link.URL] = method
+		}
+	}
+
+	for _, link := range req.Links {
+		if link.Label == "" {
+			continue
+		}
+		if len(link.Label) > maxLinkLabelLength {
+			return models.CheckOptions{}, fmt.Errorf("links: %s: label must be at most %d characters", link.URL, maxLinkLabelLength)
+		}
+		if opts.Labels == nil {
+			opts.Labels = make(map[string]string)
+		}
+		// a URL listed twice keeps its first label, as the check keeps its first occurrence
+		if _, ok := opts.Labels[link.URL]; !ok {
+			opts.Labels[link.URL] = link.Label
+		}
+	}
+
+	if len(req.Accept) > maxAcceptLength {
+		return models.CheckOptions{}, fmt.Errorf("accept: must be at most %d characters", maxAcceptLength)
+	}
+	if strings.ContainsFunc(req.Accept, func(r rune) bool { return r < ' ' || r == 0x7f }) {
+		return models.CheckOptions{}, errors.New("accept: must not contain control characters")
+	}
+	opts.Accept = strings.TrimSpace(req.Accept)
+
+	if req.CORS != nil {
+		if len(opts.MethodByURL) > 0 || opts.Method != "" {
+			return models.CheckOptions{}, errors.New("cors: preflight checks are sent with OPTIONS, method must not be set")
+		}
+		preflight, err := req.CORS.preflight()
+		if err != nil {
+			return models.CheckOptions{}, err
+		}
+		opts.CORS = preflight
+	}
+
+	opts.ContentMatch.Contains = req.ExpectContent
+	if req.ExpectContentRegex != "" {
+		pattern, err := regexp.Compile(req.ExpectContentRegex)
+		if err != nil {
+			return models.CheckOptions{}, fmt.Errorf("expect_content_regex: %w", err)
+		}
+		opts.ContentMatch.Pattern = pattern
+	}
+
+	return opts, nil
+}
+
+// CheckSitemapRequest represents a request payload for checking all pages of a sitemap.
+type CheckSitemapRequest struct {
+	URL     string `json:"url"`
+	Workers int    `json:"workers,omitempty"`
+}
+
+// CrawlRequest represents a request payload for checking all links found on a page.
+type CrawlRequest struct {
+	URL     string `json:"url"`
+	Workers int    `json:"workers,omitempty"`
+}
+
+// SetIgnoredRequest represents a request payload for marking a link of a stored group as ignored.
+type SetIgnoredRequest struct {
+	URL     string `json:"url"`
+	Ignored bool   `json:"ignored"`
 }
 
 type service interface {
-	CheckMany(ctx context.Context, links []string) (models.LinksResponse, error)
-	GenerateReport(ctx context.Context, linksNum []int) (*bytes.Buffer, error)
+	CheckMany(ctx context.Context, links []string, opts models.CheckOptions) (models.LinksResponse, error)
+	CheckSitemap(ctx context.Context, sitemapURL string, opts models.CheckOptions) (models.LinksResponse, error)
+	CheckPage(ctx context.Context, pageURL string, opts models.CheckOptions) (models.CrawlResponse, error)
+	GenerateReport(ctx context.Context, linksNum []int, opts models.ReportOptions, w io.Writer) (*models.Report, error)
+	GenerateFullReport(ctx context.Context, opts models.ReportOptions, w io.Writer) (*models.Report, error)
 	GetAll(ctx context.Context) ([]models.Links, error)
+	GetByNum(ctx context.Context, num int) (models.Links, error)
+	Recheck(ctx context.Context, num int) (models.LinksResponse, error)
+	SetIgnored(ctx context.Context, num int, url string, ignored bool) (models.Links, error)
+	Summaries(ctx context.Context) ([]models.GroupSummary, error)
+	GetBetween(ctx context.Context, from, to time.Time) ([]models.Links, error)
+	StartCheckJob(ctx context.Context, links []string, opts models.CheckOptions) (models.Job, error)
+	GetJob(ctx context.Context, id string) (models.Job, error)
+	CancelJob(ctx context.Context, id string) (models.Job, error)
+	Stats(ctx context.Context) (models.Statistics, error)
+	Search(ctx context.Context, q models.SearchQuery) (models.SearchResponse, error)
+	Export(ctx context.Context) ([]models.Links, error)
+	Import(ctx context.Context, groups []models.Links, mode models.ImportMode) (models.ImportResponse, error)
+	Monitor(ctx context.Context) (models.MonitorResponse, error)
+	Status() models.ServiceStatus
 }
 
 // Handler provides HTTP handlers for link checking and reporting.
 type Handler struct {
 	Service        service
 	RequestTimeout time.Duration
+	// MaxLinks limits the number of links read from an uploaded file, zero disables it.
+	MaxLinks int
+	// BrokenStatus is the response status of POST /links?fail_on_broken when the batch has broken links.
+	BrokenStatus int
 }
 
-// New constructs a new Handler with the given service and per-request timeout.
-func New(service service, requestTimeout time.Duration) *Handler {
+// New constructs a new Handler with the given service, per-request timeout, uploaded links limit
+// and the status returned for batches with broken links.
+func New(service service, requestTimeout time.Duration, maxLinks, brokenStatus int) *Handler {
 	return &Handler{
 		Service:        service,
 		RequestTimeout: requestTimeout,
+		MaxLinks:       maxLinks,
+		BrokenStatus:   brokenStatus,
 	}
 }
 
 // Check handles POST /links and triggers asynchronous link status checks.
-// JSON validation is handled by middleware.
+// With verbose=true, a synchronous check responds with the full checked links instead of their statuses.
+// JSON syntax is validated by middleware, the request shape by decodeRequest.
 func (h *Handler) Check(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	ctx, cancel := context.WithTimeout(ctx, h.RequestTimeout)
 	defer cancel()
 
 	var req CheckLinksRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		// This should rarely happen as middleware validates JSON structure
-		slog.Warn("failed to decode request body",
+	if err := decodeRequest(r.Body, &req, "links"); err != nil {
+		slog.WarnContext(ctx, "validation failed: invalid request body",
 			slog.String("handler", "Check"),
 			slog.Any("error", err),
 		)
-		http.Error(w, "Invalid JSON: "+err.Error(), http.StatusBadRequest)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
 		return
 	}
 
 	// Business validation: links array cannot be empty
 	if len(req.Links) == 0 {
-		slog.Warn("validation failed: links array is empty", slog.String("handler", "Check"))
-		http.Error(w, "Links array cannot be empty", http.StatusBadRequest)
+		slog.WarnContext(ctx, "validation failed: links array is empty", slog.String("handler", "Check"))
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Links array cannot be empty")
+		return
+	}
+
+	if req.Workers < 0 {
+		slog.WarnContext(ctx, "validation failed: workers is negative", slog.String("handler", "Check"))
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "workers must not be negative")
 		return
 	}
 
-	result, err := h.Service.CheckMany(ctx, req.Links)
+	opts, err := req.checkOptions()
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			slog.Warn("check links timeout", slog.String("handler", "Check"))
-			http.Error(w, "Link check timeout", http.StatusRequestTimeout)
+		slog.WarnContext(ctx, "validation failed: invalid check options",
+			slog.String("handler", "Check"),
+			slog.Any("error", err),
+		)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
+		return
+	}
+
+	failOnBroken := r.URL.Query().Get("fail_on_broken")
+	if failOnBroken != "" && failOnBroken != failOnBrokenAny && failOnBroken != failOnBrokenAll {
+		slog.WarnContext(ctx, "validation failed: invalid fail_on_broken", slog.String("handler", "Check"))
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "fail_on_broken: must be any or all")
+		return
+	}
+
+	verbose := false
+	if value := r.URL.Query().Get("verbose"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			slog.WarnContext(ctx, "validation failed: invalid verbose flag", slog.String("handler", "Check"))
+			response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "verbose: must be true or false")
 			return
 		}
-		if errors.Is(err, context.Canceled) {
-			slog.Warn("request canceled by client", slog.String("handler", "Check"))
-			http.Error(w, "Request canceled", http.StatusRequestTimeout)
+		verbose = parsed
+	}
+
+	if req.Async {
+		if failOnBroken != "" {
+			slog.WarnContext(ctx, "validation failed: fail_on_broken with async", slog.String("handler", "Check"))
+			response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "fail_on_broken: not supported for async checks")
+			return
+		}
+		if verbose {
+			slog.WarnContext(ctx, "validation failed: verbose with async", slog.String("handler", "Check"))
+			response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "verbose: not supported for async checks")
 			return
 		}
+		h.startCheckJob(w, r, req.urls(), opts)
+		return
+	}
+
+	opts.IdempotencyKey = strings.TrimSpace(r.Header.Get(idempotencyKeyHeader))
+	if len(opts.IdempotencyKey) > maxIdempotencyKeyLength {
+		slog.WarnContext(ctx, "validation failed: idempotency key too long", slog.String("handler", "Check"))
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, fmt.Sprintf("%s must be at most %d characters", idempotencyKeyHeader, maxIdempotencyKeyLength))
+		return
+	}
+
+	result, err := h.Service.CheckMany(ctx, req.urls(), opts)
+	if err != nil {
+		writeCheckError(ctx, w, "Check", err)
+		return
+	}
+
+	slog.DebugContext(ctx, "links checked successfully",
+		slog.String("handler", "Check"),
+		slog.Int("links_count", len(req.Links)),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	if hasBrokenLinks(result.Results, failOnBroken) {
+		slog.InfoContext(ctx, "batch has broken links",
+			slog.String("handler", "Check"),
+			slog.String("fail_on_broken", failOnBroken),
+			slog.Int("links_num", result.LinksNum),
+		)
+		w.WriteHeader(h.BrokenStatus)
+	}
+	var body any = result
+	if verbose {
+		body = result.Verbose()
+	}
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		slog.ErrorContext(ctx, "failed to encode response",
+			slog.String("handler", "Check"),
+			slog.Any("error", err),
+		)
+	}
+}
 
-		slog.Error("check many failed",
+// startCheckJob starts an asynchronous check and responds with 202 and the job state.
+func (h *Handler) startCheckJob(w http.ResponseWriter, r *http.Request, links []string, opts models.CheckOptions) {
+	ctx := r.Context()
+
+	job, err := h.Service.StartCheckJob(ctx, links, opts)
+	if err != nil {
+		if errors.Is(err, models.ErrShuttingDown) {
+			writeCheckError(ctx, w, "Check", err)
+			return
+		}
+		slog.ErrorContext(ctx, "failed to start check job",
 			slog.String("handler", "Check"),
 			slog.Any("error", err),
 		)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		response.Error(w, http.StatusInternalServerError, response.CodeInternal, err.Error())
 		return
 	}
 
-	slog.Debug("links checked successfully",
+	slog.DebugContext(ctx, "async check job started",
 		slog.String("handler", "Check"),
-		slog.Int("links_count", len(req.Links)),
+		slog.String("job_id", job.ID),
+		slog.Int("links_count", job.Total),
 	)
 
 	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(result); err != nil {
-		slog.Error("failed to encode response",
-			slog.String("handler", "GetAll"),
+	w.Header().Set("Location", "/jobs/"+job.ID)
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(job); err != nil {
+		slog.ErrorContext(ctx, "failed to encode response",
+			slog.String("handler", "Check"),
 			slog.Any("error", err),
 		)
 	}
 }
 
-// GenerateReport handles POST /report and returns a PDF or JSON report.
-// JSON validation is handled by middleware.
-func (h *Handler) GenerateReport(w http.ResponseWriter, r *http.Request) {
+// GetJob handles GET /jobs/{id} and returns progress and result of an async check job.
+func (h *Handler) GetJob(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	ctx, cancel := context.WithTimeout(ctx, h.RequestTimeout)
 	defer cancel()
 
-	var req models.GenerateReportRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		// This should rarely happen as middleware validates JSON structure
-		slog.Warn("failed to decode request body",
-			slog.String("handler", "GenerateReport"),
+	id := r.PathValue("id")
+
+	job, err := h.Service.GetJob(ctx, id)
+	if err != nil {
+		if errors.Is(err, models.ErrJobNotFound) {
+			slog.WarnContext(ctx, "job not found",
+				slog.String("handler", "GetJob"),
+				slog.String("job_id", id),
+			)
+			response.Error(w, http.StatusNotFound, response.CodeNotFound, "Job not found")
+			return
+		}
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+			slog.WarnContext(ctx, "get job timeout or canceled", slog.String("handler", "GetJob"))
+			response.Error(w, http.StatusRequestTimeout, response.CodeCanceled, "Request canceled")
+			return
+		}
+
+		slog.ErrorContext(ctx, "get job failed",
+			slog.String("handler", "GetJob"),
 			slog.Any("error", err),
 		)
-		http.Error(w, "Invalid JSON: "+err.Error(), http.StatusBadRequest)
+		response.Error(w, http.StatusInternalServerError, response.CodeInternal, err.Error())
 		return
 	}
 
-	// Business validation: links_num array cannot be empty
-	if len(req.LinksNum) == 0 {
-		slog.Warn("validation failed: links_num array is empty", slog.String("handler", "GenerateReport"))
-		http.Error(w, "Links_num array cannot be empty", http.StatusBadRequest)
-		return
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(job); err != nil {
+		slog.ErrorContext(ctx, "failed to encode response",
+			slog.String("handler", "GetJob"),
+			slog.Any("error", err),
+		)
 	}
+}
+
+// CancelJob handles DELETE /jobs/{id}: it stops a pending or running async job and returns it
+// once the links checked until then are stored, unchecked links are stored as skipped.
+func (h *Handler) CancelJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.RequestTimeout)
+	defer cancel()
 
-	pdfBuffer, err := h.Service.GenerateReport(ctx, req.LinksNum)
+	id := r.PathValue("id")
+
+	job, err := h.Service.CancelJob(ctx, id)
 	if err != nil {
-		slog.Error("failed to generate report",
-			slog.String("handler", "GenerateReport"),
+		if errors.Is(err, models.ErrJobNotFound) {
+			slog.WarnContext(ctx, "job not found",
+				slog.String("handler", "CancelJob"),
+				slog.String("job_id", id),
+			)
+			response.Error(w, http.StatusNotFound, response.CodeNotFound, "Job not found")
+			return
+		}
+		if errors.Is(err, models.ErrJobFinished) {
+			slog.WarnContext(ctx, "job already finished",
+				slog.String("handler", "CancelJob"),
+				slog.String("job_id", id),
+			)
+			response.Error(w, http.StatusConflict, response.CodeJobFinished, "Job already finished")
+			return
+		}
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+			slog.WarnContext(ctx, "cancel job timeout or canceled", slog.String("handler", "CancelJob"))
+			response.Error(w, http.StatusRequestTimeout, response.CodeTimeout, "Timeout waiting for the job to stop")
+			return
+		}
+
+		slog.ErrorContext(ctx, "cancel job failed",
+			slog.String("handler", "CancelJob"),
 			slog.Any("error", err),
 		)
-		http.Error(w, "Failed to generate report: "+err.Error(), http.StatusInternalServerError)
+		response.Error(w, http.StatusInternalServerError, response.CodeInternal, err.Error())
 		return
 	}
 
-	// Checking if client wants JSON or PDF response
-	acceptHeader := r.Header.Get("Accept")
-	if strings.Contains(acceptHeader, "application/json") {
-		slog.Debug("returning JSON report meta",
-			slog.String("handler", "GenerateReport"),
-			slog.Int("links_num_count", len(req.LinksNum)),
-			slog.Int("size_bytes", pdfBuffer.Len()),
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(job); err != nil {
+		slog.ErrorContext(ctx, "failed to encode response",
+			slog.String("handler", "CancelJob"),
+			slog.Any("error", err),
 		)
+	}
+}
 
-		// Returning JSON with report information
-		w.Header().Set("Content-Type", "application/json")
-		if err := json.NewEncoder(w).Encode(models.GenerateReportResponse{
-			Message: "PDF report generated successfully",
-			Size:    pdfBuffer.Len(),
-		}); err != nil {
-			slog.Error("failed to encode response",
-				slog.String("handler", "GenerateReport"),
-				slog.Any("error", err),
-			)
-		}
+// CheckSitemap handles POST /links/sitemap and checks every page listed in the sitemap.
+// JSON syntax is validated by middleware, the request shape by decodeRequest.
+func (h *Handler) CheckSitemap(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.RequestTimeout)
+	defer cancel()
+
+	var req CheckSitemapRequest
+	if err := decodeRequest(r.Body, &req, "url"); err != nil {
+		slog.WarnContext(ctx, "validation failed: invalid request body",
+			slog.String("handler", "CheckSitemap"),
+			slog.Any("error", err),
+		)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
 		return
 	}
 
-	// Returning PDF report by default
-	slog.Debug("returning PDF report",
-		slog.String("handler", "GenerateReport"),
-		slog.Int("links_num_count", len(req.LinksNum)),
-		slog.Int("size_bytes", pdfBuffer.Len()),
-	)
+	// Business validation: sitemap URL must be an absolute http(s) URL
+	if !isHTTPURL(req.URL) {
+		slog.WarnContext(ctx, "validation failed: invalid sitemap url", slog.String("handler", "CheckSitemap"))
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Url must be an absolute http(s) URL")
+		return
+	}
+
+	if req.Workers < 0 {
+		slog.WarnContext(ctx, "validation failed: workers is negative", slog.String("handler", "CheckSitemap"))
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "workers must not be negative")
+		return
+	}
+
+	result, err := h.Service.CheckSitemap(ctx, req.URL, models.CheckOptions{Workers: req.Workers})
+	if err != nil {
+		writeCheckError(ctx, w, "CheckSitemap", err)
+		return
+	}
 
-	w.Header().Set("Content-Type", "application/pdf")
-	w.Header().Set("Content-Disposition", "attachment; filename=link_report.pdf")
-	w.Header().Set("Content-Length", fmt.Sprintf("%d", pdfBuffer.Len()))
+	slog.DebugContext(ctx, "sitemap links checked successfully",
+		slog.String("handler", "CheckSitemap"),
+		slog.Int("links_count", len(result.Links)),
+	)
 
-	if _, err = pdfBuffer.WriteTo(w); err != nil {
-		slog.Error("failed to send PDF to client",
-			slog.String("handler", "GenerateReport"),
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		slog.ErrorContext(ctx, "failed to encode response",
+			slog.String("handler", "CheckSitemap"),
 			slog.Any("error", err),
 		)
-		http.Error(w, "Failed to send PDF", http.StatusInternalServerError)
-		return
 	}
 }
 
-// GetAll handles GET /links and returns all stored link groups.
-func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+// Crawl handles POST /links/crawl and checks every link found on the given page.
+// JSON syntax is validated by middleware, the request shape by decodeRequest.
+func (h *Handler) Crawl(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	ctx, cancel := context.WithTimeout(ctx, h.RequestTimeout)
 	defer cancel()
 
-	result, err := h.Service.GetAll(ctx)
-	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			slog.Warn("get all timeout", slog.String("handler", "GetAll"))
-			http.Error(w, "Get all timeout", http.StatusRequestTimeout)
-			return
-		}
-		if errors.Is(err, context.Canceled) {
-			slog.Warn("request canceled by client", slog.String("handler", "GetAll"))
-			http.Error(w, "Request canceled", http.StatusRequestTimeout)
-			return
-		}
-
-		slog.Error("get all links failed",
-			slog.String("handler", "GetAll"),
+	var req CrawlRequest
+	if err := decodeRequest(r.Body, &req, "url"); err != nil {
+		slog.WarnContext(ctx, "validation failed: invalid request body",
+			slog.String("handler", "Crawl"),
 			slog.Any("error", err),
 		)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
 		return
 	}
 
-	slog.Debug("get all links succeeded",
-		slog.String("handler", "GetAll"),
-		slog.Int("groups_count", len(result)),
+	// Business validation: page URL must be an absolute http(s) URL
+	if !isHTTPURL(req.URL) {
+		slog.WarnContext(ctx, "validation failed: invalid page url", slog.String("handler", "Crawl"))
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Url must be an absolute http(s) URL")
+		return
+	}
+
+	if req.Workers < 0 {
+		slog.WarnContext(ctx, "validation failed: workers is negative", slog.String("handler", "Crawl"))
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "workers must not be negative")
+		return
+	}
+
+	result, err := h.Service.CheckPage(ctx, req.URL, models.CheckOptions{Workers: req.Workers})
+	if err != nil {
+		writeCheckError(ctx, w, "Crawl", err)
+		return
+	}
+
+	slog.DebugContext(ctx, "page links checked successfully",
+		slog.String("handler", "Crawl"),
+		slog.String("source", result.Source),
+		slog.Int("links_count", len(result.Links)),
 	)
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(result); err != nil {
-		slog.Error("failed to encode response",
-			slog.String("handler", "GetAll"),
+		slog.ErrorContext(ctx, "failed to encode response",
+			slog.String("handler", "Crawl"),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// GenerateReport handles POST /report and returns a PDF, HTML or JSON report.
+// With ?all=true the report covers every stored group and links_num is ignored.
+// The document is streamed to the client without Content-Length, so errors after its first
+// bytes are sent can only be logged.
+// JSON syntax is validated by middleware, the request shape by decodeRequest.
+func (h *Handler) GenerateReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.RequestTimeout)
+	defer cancel()
+
+	all := false
+	if value := r.URL.Query().Get("all"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			slog.WarnContext(ctx, "validation failed: invalid all flag", slog.String("handler", "GenerateReport"))
+			response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "all: must be true or false")
+			return
+		}
+		all = parsed
+	}
+
+	format, err := reportFormat(r)
+	if err != nil {
+		slog.WarnContext(ctx, "validation failed: invalid report format", slog.String("handler", "GenerateReport"))
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
+		return
+	}
+
+	// links_num may be omitted only when the report covers every stored group
+	var required []string
+	if !all {
+		required = append(required, "links_num")
+	}
+
+	var req models.GenerateReportRequest
+	if err := decodeRequest(r.Body, &req, required...); err != nil {
+		slog.WarnContext(ctx, "validation failed: invalid request body",
+			slog.String("handler", "GenerateReport"),
 			slog.Any("error", err),
 		)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
+		return
+	}
+
+	// Business validation: links_num array cannot be empty
+	if !all && len(req.LinksNum) == 0 {
+		slog.WarnContext(ctx, "validation failed: links_num array is empty", slog.String("handler", "GenerateReport"))
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Links_num array cannot be empty")
+		return
+	}
+
+	// Checking if client wants JSON or a document, JSON only reports the PDF size;
+	// an explicit format always returns the document
+	wantJSON := !r.URL.Query().Has("format") && strings.Contains(r.Header.Get("Accept"), "application/json")
+	req.Format = format
+	if wantJSON {
+		req.Format = models.ReportFormatPDF
+	}
+	doc := &reportWriter{w: w, format: req.Format}
+	var out io.Writer = doc
+	if wantJSON {
+		out = io.Discard
+	}
+
+	var report *models.Report
+	if all {
+		report, err = h.Service.GenerateFullReport(ctx, req.ReportOptions, out)
+	} else {
+		report, err = h.Service.GenerateReport(ctx, req.LinksNum, req.ReportOptions, out)
+	}
+	if err != nil {
+		if doc.started {
+			slog.ErrorContext(ctx, "failed to send report to client",
+				slog.String("handler", "GenerateReport"),
+				slog.Any("error", err),
+			)
+			return
+		}
+
+		writeReportError(ctx, w, "GenerateReport", err)
+		return
+	}
+
+	if wantJSON {
+		slog.DebugContext(ctx, "returning JSON report meta",
+			slog.String("handler", "GenerateReport"),
+			slog.Int("links_num_count", len(req.LinksNum)),
+			slog.Int("size_bytes", report.Size),
+		)
+
+		// Returning JSON with report information
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(models.GenerateReportResponse{
+			Message:    "PDF report generated successfully",
+			Size:       report.Size,
+			Statistics: report.Statistics,
+			Hosts:      report.Hosts,
+			Groups:     report.Groups,
+		}); err != nil {
+			slog.ErrorContext(ctx, "failed to encode response",
+				slog.String("handler", "GenerateReport"),
+				slog.Any("error", err),
+			)
+		}
+		return
+	}
+
+	// PDF or HTML report has already been written
+	slog.DebugContext(ctx, "returned report",
+		slog.String("handler", "GenerateReport"),
+		slog.String("format", string(req.Format)),
+		slog.Int("links_num_count", len(req.LinksNum)),
+		slog.Int("size_bytes", report.Size),
+	)
+}
+
+// writeReportError maps an error returned by report generation to an HTTP error response.
+func writeReportError(ctx context.Context, w http.ResponseWriter, handler string, err error) {
+	var notFound *models.GroupNotFoundError
+	if errors.As(err, &notFound) {
+		slog.WarnContext(ctx, "validation failed: link groups not found",
+			slog.String("handler", handler),
+			slog.Any("missing_nums", notFound.Nums),
+		)
+		response.Error(w, http.StatusNotFound, response.CodeNotFound, fmt.Sprintf("links_num: groups not found: %v", notFound.Nums))
+		return
+	}
+	if errors.Is(err, models.ErrGroupNotFound) {
+		slog.WarnContext(ctx, "no link groups stored for report", slog.String("handler", handler))
+		response.Error(w, http.StatusNotFound, response.CodeNotFound, "No link groups stored")
+		return
+	}
+	if errors.Is(err, models.ErrReportTooLarge) {
+		slog.WarnContext(ctx, "validation failed: report too large",
+			slog.String("handler", handler),
+			slog.Any("error", err),
+		)
+		response.Error(w, http.StatusUnprocessableEntity, response.CodeReportTooLarge, err.Error())
+		return
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		slog.WarnContext(ctx, "generate report timeout or canceled", slog.String("handler", handler))
+		response.Error(w, http.StatusRequestTimeout, response.CodeTimeout, "Report generation timeout")
+		return
+	}
+	if errors.Is(err, models.ErrInvalidReportOptions) {
+		slog.WarnContext(ctx, "validation failed: invalid report options",
+			slog.String("handler", handler),
+			slog.Any("error", err),
+		)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
+		return
+	}
+
+	slog.ErrorContext(ctx, "failed to generate report",
+		slog.String("handler", handler),
+		slog.Any("error", err),
+	)
+	response.Error(w, http.StatusInternalServerError, response.CodeInternal, "Failed to generate report: "+err.Error())
+}
+
+// reportWriter streams a PDF or HTML report to the client, setting the headers of its format
+// on the first write so that errors before it can still be answered with a JSON error.
+type reportWriter struct {
+	w       http.ResponseWriter
+	format  models.ReportFormat
+	started bool
+}
+
+func (p *reportWriter) Write(b []byte) (int, error) {
+	if !p.started {
+		p.started = true
+		if p.format == models.ReportFormatHTML {
+			// HTML reports are shown inline, e.g. when embedded in a dashboard
+			p.w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			p.w.Header().Set("Content-Disposition", "inline; filename=link_report.html")
+		} else {
+			p.w.Header().Set("Content-Type", "application/pdf")
+			p.w.Header().Set("Content-Disposition", "attachment; filename=link_report.pdf")
+		}
+	}
+	return p.w.Write(b)
+}
+
+// reportFormat returns the document format chosen with the format query parameter ("pdf" or
+// "html"), or with Accept: text/html, PDF otherwise.
+func reportFormat(r *http.Request) (models.ReportFormat, error) {
+	switch format := r.URL.Query().Get("format"); format {
+	case "":
+	case string(models.ReportFormatPDF), string(models.ReportFormatHTML):
+		return models.ReportFormat(format), nil
+	default:
+		return "", errors.New("format: must be pdf or html")
+	}
+	if strings.Contains(r.Header.Get("Accept"), "text/html") {
+		return models.ReportFormatHTML, nil
+	}
+	return models.ReportFormatPDF, nil
+}
+
+// GetAll handles GET /links and returns all stored link groups.
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.RequestTimeout)
+	defer cancel()
+
+	from, to, err := parseTimeRange(r.URL.Query())
+	if err != nil {
+		slog.WarnContext(ctx, "validation failed: invalid time range",
+			slog.String("handler", "GetAll"),
+			slog.Any("error", err),
+		)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
+		return
+	}
+
+	var result []models.Links
+	if from.IsZero() && to.IsZero() {
+		result, err = h.Service.GetAll(ctx)
+	} else {
+		result, err = h.Service.GetBetween(ctx, from, to)
+	}
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			slog.WarnContext(ctx, "get all timeout", slog.String("handler", "GetAll"))
+			response.Error(w, http.StatusRequestTimeout, response.CodeTimeout, "Get all timeout")
+			return
+		}
+		if errors.Is(err, context.Canceled) {
+			slog.WarnContext(ctx, "request canceled by client", slog.String("handler", "GetAll"))
+			response.Error(w, http.StatusRequestTimeout, response.CodeCanceled, "Request canceled")
+			return
+		}
+
+		slog.ErrorContext(ctx, "get all links failed",
+			slog.String("handler", "GetAll"),
+			slog.Any("error", err),
+		)
+		response.Error(w, http.StatusInternalServerError, response.CodeInternal, err.Error())
+		return
+	}
+
+	slog.DebugContext(ctx, "get all links succeeded",
+		slog.String("handler", "GetAll"),
+		slog.Int("groups_count", len(result)),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		slog.ErrorContext(ctx, "failed to encode response",
+			slog.String("handler", "GetAll"),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// ListGroups handles GET /groups and returns summaries of all stored groups without their links.
+func (h *Handler) ListGroups(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.RequestTimeout)
+	defer cancel()
+
+	result, err := h.Service.Summaries(ctx)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			slog.WarnContext(ctx, "list groups timeout", slog.String("handler", "ListGroups"))
+			response.Error(w, http.StatusRequestTimeout, response.CodeTimeout, "List groups timeout")
+			return
+		}
+		if errors.Is(err, context.Canceled) {
+			slog.WarnContext(ctx, "request canceled by client", slog.String("handler", "ListGroups"))
+			response.Error(w, http.StatusRequestTimeout, response.CodeCanceled, "Request canceled")
+			return
+		}
+
+		slog.ErrorContext(ctx, "list groups failed",
+			slog.String("handler", "ListGroups"),
+			slog.Any("error", err),
+		)
+		response.Error(w, http.StatusInternalServerError, response.CodeInternal, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		slog.ErrorContext(ctx, "failed to encode response",
+			slog.String("handler", "ListGroups"),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// GetGroup handles GET /links/{num} and returns a single stored link group.
+func (h *Handler) GetGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.RequestTimeout)
+	defer cancel()
+
+	num, err := strconv.Atoi(r.PathValue("num"))
+	if err != nil || num <= 0 {
+		slog.WarnContext(ctx, "validation failed: invalid group number",
+			slog.String("handler", "GetGroup"),
+			slog.String("num", r.PathValue("num")),
+		)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "num: must be a positive integer")
+		return
+	}
+
+	group, err := h.Service.GetByNum(ctx, num)
+	if err != nil {
+		if errors.Is(err, models.ErrGroupNotFound) {
+			slog.WarnContext(ctx, "link group not found",
+				slog.String("handler", "GetGroup"),
+				slog.Int("links_num", num),
+			)
+			response.Error(w, http.StatusNotFound, response.CodeNotFound, fmt.Sprintf("Group %d not found", num))
+			return
+		}
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+			slog.WarnContext(ctx, "get group timeout or canceled", slog.String("handler", "GetGroup"))
+			response.Error(w, http.StatusRequestTimeout, response.CodeCanceled, "Request canceled")
+			return
+		}
+
+		slog.ErrorContext(ctx, "get group failed",
+			slog.String("handler", "GetGroup"),
+			slog.Any("error", err),
+		)
+		response.Error(w, http.StatusInternalServerError, response.CodeInternal, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(group); err != nil {
+		slog.ErrorContext(ctx, "failed to encode response",
+			slog.String("handler", "GetGroup"),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// Monitor handles GET /monitor and returns the latest results of the URLs checked on an interval.
+func (h *Handler) Monitor(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.RequestTimeout)
+	defer cancel()
+
+	res, err := h.Service.Monitor(ctx)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrMonitorDisabled):
+			slog.WarnContext(ctx, "monitor is disabled", slog.String("handler", "Monitor"))
+			response.Error(w, http.StatusNotFound, response.CodeNotFound, "Monitor is disabled, set MONITOR_URLS to enable it")
+		case errors.Is(err, models.ErrMonitorPending):
+			slog.WarnContext(ctx, "monitor has no results yet", slog.String("handler", "Monitor"))
+			response.Error(w, http.StatusServiceUnavailable, response.CodeMonitorPending, "Monitor has no results yet")
+		case errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled):
+			slog.WarnContext(ctx, "get monitor timeout or canceled", slog.String("handler", "Monitor"))
+			response.Error(w, http.StatusRequestTimeout, response.CodeCanceled, "Request canceled")
+		default:
+			slog.ErrorContext(ctx, "get monitor failed",
+				slog.String("handler", "Monitor"),
+				slog.Any("error", err),
+			)
+			response.Error(w, http.StatusInternalServerError, response.CodeInternal, err.Error())
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		slog.ErrorContext(ctx, "failed to encode response",
+			slog.String("handler", "Monitor"),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// Recheck handles POST /links/{num}/recheck: it checks the links of a stored group again with
+// their stored settings, stores them as a new group and reports which links broke or got fixed since.
+func (h *Handler) Recheck(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.RequestTimeout)
+	defer cancel()
+
+	num, err := strconv.Atoi(r.PathValue("num"))
+	if err != nil || num <= 0 {
+		slog.WarnContext(ctx, "validation failed: invalid group number",
+			slog.String("handler", "Recheck"),
+			slog.String("num", r.PathValue("num")),
+		)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "num: must be a positive integer")
+		return
+	}
+
+	result, err := h.Service.Recheck(ctx, num)
+	if err != nil {
+		if errors.Is(err, models.ErrGroupNotFound) {
+			slog.WarnContext(ctx, "link group not found",
+				slog.String("handler", "Recheck"),
+				slog.Int("links_num", num),
+			)
+			response.Error(w, http.StatusNotFound, response.CodeNotFound, fmt.Sprintf("Group %d not found", num))
+			return
+		}
+		writeCheckError(ctx, w, "Recheck", err)
+		return
+	}
+
+	slog.DebugContext(ctx, "link group re-checked successfully",
+		slog.String("handler", "Recheck"),
+		slog.Int("previous_links_num", num),
+		slog.Int("links_num", result.LinksNum),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		slog.ErrorContext(ctx, "failed to encode response",
+			slog.String("handler", "Recheck"),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// SetIgnored handles PUT /links/{num}/ignored: it marks the links of the group with the given
+// URL as ignored or not and returns the updated group. Ignored links are still checked and
+// listed, but left out of availability statistics.
+func (h *Handler) SetIgnored(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.RequestTimeout)
+	defer cancel()
+
+	num, err := strconv.Atoi(r.PathValue("num"))
+	if err != nil || num <= 0 {
+		slog.WarnContext(ctx, "validation failed: invalid group number",
+			slog.String("handler", "SetIgnored"),
+			slog.String("num", r.PathValue("num")),
+		)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "num: must be a positive integer")
+		return
+	}
+
+	var req SetIgnoredRequest
+	if err := decodeRequest(r.Body, &req, "url", "ignored"); err != nil {
+		slog.WarnContext(ctx, "validation failed: invalid request body",
+			slog.String("handler", "SetIgnored"),
+			slog.Any("error", err),
+		)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
+		return
+	}
+	if strings.TrimSpace(req.URL) == "" {
+		slog.WarnContext(ctx, "validation failed: empty url", slog.String("handler", "SetIgnored"))
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "url: must not be empty")
+		return
+	}
+
+	group, err := h.Service.SetIgnored(ctx, num, req.URL, req.Ignored)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrGroupNotFound):
+			slog.WarnContext(ctx, "link group not found",
+				slog.String("handler", "SetIgnored"),
+				slog.Int("links_num", num),
+			)
+			response.Error(w, http.StatusNotFound, response.CodeNotFound, fmt.Sprintf("Group %d not found", num))
+		case errors.Is(err, models.ErrLinkNotFound):
+			slog.WarnContext(ctx, "link not found in group",
+				slog.String("handler", "SetIgnored"),
+				slog.Int("links_num", num),
+			)
+			response.Error(w, http.StatusNotFound, response.CodeNotFound, fmt.Sprintf("Link %s not found in group %d", req.URL, num))
+		case errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled):
+			slog.WarnContext(ctx, "set ignored timeout or canceled", slog.String("handler", "SetIgnored"))
+			response.Error(w, http.StatusRequestTimeout, response.CodeCanceled, "Request canceled")
+		default:
+			slog.ErrorContext(ctx, "set ignored failed",
+				slog.String("handler", "SetIgnored"),
+				slog.Any("error", err),
+			)
+			response.Error(w, http.StatusInternalServerError, response.CodeInternal, err.Error())
+		}
+		return
+	}
+
+	slog.DebugContext(ctx, "link ignored flag updated",
+		slog.String("handler", "SetIgnored"),
+		slog.Int("links_num", num),
+		slog.Bool("ignored", req.Ignored),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(group); err != nil {
+		slog.ErrorContext(ctx, "failed to encode response",
+			slog.String("handler", "SetIgnored"),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// Search handles GET /links/search and returns stored links matching all of the given
+// q (substring), pattern (glob), regex, host and status parameters.
+func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.RequestTimeout)
+	defer cancel()
+
+	query, err := parseSearchQuery(r.URL.Query())
+	if err != nil {
+		slog.WarnContext(ctx, "validation failed: invalid search query",
+			slog.String("handler", "Search"),
+			slog.Any("error", err),
+		)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
+		return
+	}
+
+	result, err := h.Service.Search(ctx, query)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidSearch) {
+			slog.WarnContext(ctx, "validation failed: invalid search pattern",
+				slog.String("handler", "Search"),
+				slog.Any("error", err),
+			)
+			response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
+			return
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			slog.WarnContext(ctx, "search timeout", slog.String("handler", "Search"))
+			response.Error(w, http.StatusRequestTimeout, response.CodeTimeout, "Search timeout")
+			return
+		}
+		if errors.Is(err, context.Canceled) {
+			slog.WarnContext(ctx, "request canceled by client", slog.String("handler", "Search"))
+			response.Error(w, http.StatusRequestTimeout, response.CodeCanceled, "Request canceled")
+			return
+		}
+
+		slog.ErrorContext(ctx, "search links failed",
+			slog.String("handler", "Search"),
+			slog.Any("error", err),
+		)
+		response.Error(w, http.StatusInternalServerError, response.CodeInternal, err.Error())
+		return
+	}
+
+	slog.DebugContext(ctx, "search links succeeded",
+		slog.String("handler", "Search"),
+		slog.Int("matches", result.Count),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		slog.ErrorContext(ctx, "failed to encode response",
+			slog.String("handler", "Search"),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// parseSearchQuery reads search conditions from query parameters, at least one is required.
+func parseSearchQuery(values url.Values) (models.SearchQuery, error) {
+	q := models.SearchQuery{
+		Query:   strings.TrimSpace(values.Get("q")),
+		Pattern: strings.TrimSpace(values.Get("pattern")),
+		Regex:   values.Get("regex"),
+		Host:    strings.TrimSpace(values.Get("host")),
+		Status:  models.LinkStatus(strings.TrimSpace(values.Get("status"))),
+	}
+	if q == (models.SearchQuery{}) {
+		return q, errors.New("q, pattern, regex, host or status: at least one query parameter is required")
+	}
+	if q.Status != "" && !slices.Contains(models.LinkStatuses, q.Status) {
+		return q, fmt.Errorf("status: unknown link status %q", q.Status)
+	}
+	return q, nil
+}
+
+// Stats handles GET /stats and returns statistics aggregated across all link groups.
+func (h *Handler) Stats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.RequestTimeout)
+	defer cancel()
+
+	result, err := h.Service.Stats(ctx)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			slog.WarnContext(ctx, "stats timeout", slog.String("handler", "Stats"))
+			response.Error(w, http.StatusRequestTimeout, response.CodeTimeout, "Stats timeout")
+			return
+		}
+		if errors.Is(err, context.Canceled) {
+			slog.WarnContext(ctx, "request canceled by client", slog.String("handler", "Stats"))
+			response.Error(w, http.StatusRequestTimeout, response.CodeCanceled, "Request canceled")
+			return
+		}
+
+		slog.ErrorContext(ctx, "stats failed",
+			slog.String("handler", "Stats"),
+			slog.Any("error", err),
+		)
+		response.Error(w, http.StatusInternalServerError, response.CodeInternal, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		slog.ErrorContext(ctx, "failed to encode response",
+			slog.String("handler", "Stats"),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// AdminStatus handles GET /admin/status and returns checks in progress and worker pool limits.
+func (h *Handler) AdminStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(h.Service.Status()); err != nil {
+		slog.ErrorContext(ctx, "failed to encode response",
+			slog.String("handler", "AdminStatus"),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// writeCheckError maps errors from link checking operations to HTTP responses.
+func writeCheckError(ctx context.Context, w http.ResponseWriter, handler string, err error) {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		slog.WarnContext(ctx, "check links timeout", slog.String("handler", handler))
+		response.Error(w, http.StatusRequestTimeout, response.CodeTimeout, "Link check timeout")
+	case errors.Is(err, context.Canceled):
+		slog.WarnContext(ctx, "request canceled by client", slog.String("handler", handler))
+		response.Error(w, http.StatusRequestTimeout, response.CodeCanceled, "Request canceled")
+	case errors.Is(err, models.ErrSourceUnavailable):
+		slog.WarnContext(ctx, "links source unavailable",
+			slog.String("handler", handler),
+			slog.Any("error", err),
+		)
+		response.Error(w, http.StatusBadGateway, response.CodeSourceUnavailable, err.Error())
+	case errors.Is(err, models.ErrShuttingDown):
+		slog.WarnContext(ctx, "check rejected during shutdown", slog.String("handler", handler))
+		response.Error(w, http.StatusServiceUnavailable, response.CodeShuttingDown, "Service is shutting down")
+	case errors.Is(err, models.ErrNoLinksFound):
+		slog.WarnContext(ctx, "links source is empty", slog.String("handler", handler))
+		response.Error(w, http.StatusUnprocessableEntity, response.CodeNoLinksFound, "No links found")
+	default:
+		slog.ErrorContext(ctx, "check many failed",
+			slog.String("handler", handler),
+			slog.Any("error", err),
+		)
+		response.Error(w, http.StatusInternalServerError, response.CodeInternal, err.Error())
+	}
+}
+
+// hasBrokenLinks reports whether results fail the fail_on_broken mode: any requires at least
+// one not available link, all requires every link to be not available. Skipped links are not broken
+// and ignored links are left out, so a batch of only ignored links never fails.
+func hasBrokenLinks(results []models.LinkResult, mode string) bool {
+	if mode == "" {
+		return false
+	}
+
+	counted, broken := 0, 0
+	for _, result := range results {
+		if result.Ignored {
+			continue
+		}
+		counted++
+		if result.Status == models.LinkStatusNotAvailable {
+			broken++
+		}
+	}
+
+	if mode == failOnBrokenAll {
+		return counted > 0 && broken == counted
+	}
+	return broken > 0
+}
+
+// parseTimeRange reads the optional RFC3339 "from" and "to" query parameters.
+// Missing parameters are returned as zero times.
+func parseTimeRange(query url.Values) (from, to time.Time, err error) {
+	if v := query.Get("from"); v != "" {
+		if from, err = time.Parse(time.RFC3339, v); err != nil {
+			return time.Time{}, time.Time{}, errors.New("from: must be an RFC3339 timestamp")
+		}
+	}
+	if v := query.Get("to"); v != "" {
+		if to, err = time.Parse(time.RFC3339, v); err != nil {
+			return time.Time{}, time.Time{}, errors.New("to: must be an RFC3339 timestamp")
+		}
+	}
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		return time.Time{}, time.Time{}, errors.New("from: must not be after to")
+	}
+	return from, to, nil
+}
+
+// isHTTPURL reports whether raw is an absolute http or https URL with a host.
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
 	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
 }
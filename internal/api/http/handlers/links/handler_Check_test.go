@@ -0,0 +1,106 @@
+package links
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/api/http/response"
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+// stubService implements service for handler tests, methods a test does not set panic.
+type stubService struct {
+	service
+	getJob func(ctx context.Context, id string) (models.Job, error)
+}
+
+func (s stubService) GetJob(ctx context.Context, id string) (models.Job, error) {
+	return s.getJob(ctx, id)
+}
+
+// decodeErrorResponse checks that w holds a JSON error envelope with status and returns it.
+func decodeErrorResponse(t *testing.T, w *httptest.ResponseRecorder, status int) response.ErrorResponse {
+	t.Helper()
+
+	if w.Code != status {
+		t.Fatalf("status = %d, want %d, body %s", w.Code, status, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+
+	var body response.ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatalf("decode body error = %v, want nil", err)
+	}
+	return body
+}
+
+func TestHandler_Check(t *testing.T) {
+	h := &Handler{Service: stubService{}, RequestTimeout: time.Second}
+
+	tests := []struct {
+		name      string
+		body      string
+		wantError string
+	}{
+		{name: "missing links", body: `{"link": "x"}`, wantError: "links: field is required"},
+		{name: "links of wrong type", body: `{"links": "https://example.com"}`, wantError: "links: must be an array, got string"},
+		{name: "not an object", body: `[]`, wantError: "request body must be a JSON object"},
+		{name: "unknown field", body: `{"links": ["https://example.com"], "linkss": []}`, wantError: "linkss: unknown field"},
+		{name: "unknown link field", body: `{"links": [{"url": "https://example.com", "methd": "GET"}]}`, wantError: "links.methd: unknown field"},
+		{name: "negative workers", body: `{"links": ["https://example.com"], "workers": -1}`, wantError: "workers must not be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/links", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			h.Check(w, r)
+
+			body := decodeErrorResponse(t, w, http.StatusBadRequest)
+			if body.Code != response.CodeInvalidRequest {
+				t.Errorf("code = %q, want %q", body.Code, response.CodeInvalidRequest)
+			}
+			if body.Error != tt.wantError {
+				t.Errorf("error = %q, want %q", body.Error, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestHasBrokenLinks(t *testing.T) {
+	available := models.LinkResult{URL: "https://example.com/ok", Status: models.LinkStatusAvailable}
+	broken := models.LinkResult{URL: "https://example.com/broken", Status: models.LinkStatusNotAvailable}
+	ignored := models.LinkResult{URL: "https://example.com/ignored", Status: models.LinkStatusNotAvailable, Ignored: true}
+
+	tests := []struct {
+		name    string
+		results []models.LinkResult
+		mode    string
+		want    bool
+	}{
+		{name: "mode not set", results: []models.LinkResult{broken}, mode: "", want: false},
+		{name: "any with a broken link", results: []models.LinkResult{available, broken}, mode: failOnBrokenAny, want: true},
+		{name: "any with only available links", results: []models.LinkResult{available}, mode: failOnBrokenAny, want: false},
+		{name: "any skips ignored links", results: []models.LinkResult{available, ignored}, mode: failOnBrokenAny, want: false},
+		{name: "all with every link broken", results: []models.LinkResult{broken, broken}, mode: failOnBrokenAll, want: true},
+		{name: "all with an available link", results: []models.LinkResult{available, broken}, mode: failOnBrokenAll, want: false},
+		{name: "all skips ignored links", results: []models.LinkResult{broken, ignored}, mode: failOnBrokenAll, want: true},
+		{name: "all with only ignored links", results: []models.LinkResult{ignored}, mode: failOnBrokenAll, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasBrokenLinks(tt.results, tt.mode); got != tt.want {
+				t.Errorf("hasBrokenLinks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
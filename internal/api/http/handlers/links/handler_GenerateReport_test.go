@@ -0,0 +1,25 @@
+package links
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/api/http/response"
+)
+
+func TestHandler_GenerateReport(t *testing.T) {
+	h := &Handler{Service: stubService{}, RequestTimeout: time.Second}
+
+	r := httptest.NewRequest(http.MethodPost, "/report", strings.NewReader(`{"links_num": [1], "links_nums": [2]}`))
+	w := httptest.NewRecorder()
+
+	h.GenerateReport(w, r)
+
+	body := decodeErrorResponse(t, w, http.StatusBadRequest)
+	if body.Code != response.CodeInvalidRequest || body.Error != "links_nums: unknown field" {
+		t.Errorf("body = %+v, want links_nums: unknown field with code %s", body, response.CodeInvalidRequest)
+	}
+}
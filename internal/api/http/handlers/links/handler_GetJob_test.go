@@ -0,0 +1,47 @@
+package links
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/api/http/response"
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestHandler_GetJob(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantError  string
+	}{
+		{name: "not found", err: models.ErrJobNotFound, wantStatus: http.StatusNotFound, wantCode: response.CodeNotFound, wantError: "Job not found"},
+		{name: "canceled", err: context.Canceled, wantStatus: http.StatusRequestTimeout, wantCode: response.CodeCanceled, wantError: "Request canceled"},
+		{name: "internal", err: errors.New("boom"), wantStatus: http.StatusInternalServerError, wantCode: response.CodeInternal, wantError: "boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := stubService{getJob: func(context.Context, string) (models.Job, error) {
+				return models.Job{}, tt.err
+			}}
+			h := &Handler{Service: svc, RequestTimeout: time.Second}
+
+			r := httptest.NewRequest(http.MethodGet, "/jobs/abc", nil)
+			r.SetPathValue("id", "abc")
+			w := httptest.NewRecorder()
+
+			h.GetJob(w, r)
+
+			body := decodeErrorResponse(t, w, tt.wantStatus)
+			if body.Code != tt.wantCode || body.Error != tt.wantError {
+				t.Errorf("body = %+v, want %q with code %s", body, tt.wantError, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package links
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/polonkoevv/linkchecker/internal/api/http/response"
+	"github.com/polonkoevv/linkchecker/internal/models"
+	"github.com/polonkoevv/linkchecker/internal/storage/persistence"
+)
+
+// exportFilename is the attachment name suggested for GET /export downloads.
+const exportFilename = "links_export.json"
+
+// Export handles GET /export and sends all stored link groups as a JSON attachment
+// in the storage snapshot format, which POST /import accepts back.
+func (h *Handler) Export(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.RequestTimeout)
+	defer cancel()
+
+	groups, err := h.Service.Export(ctx)
+	if err != nil {
+		writeSnapshotError(ctx, w, "Export", err)
+		return
+	}
+
+	slog.DebugContext(ctx, "export succeeded",
+		slog.String("handler", "Export"),
+		slog.Int("groups_count", len(groups)),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", "attachment; filename="+exportFilename)
+	if err := persistence.Encode(w, groups, false); err != nil {
+		slog.ErrorContext(ctx, "failed to send export to client",
+			slog.String("handler", "Export"),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// Import handles POST /import?mode=merge|replace with a body produced by GET /export.
+// The body is validated like a snapshot loaded on startup before anything is stored.
+// Merge (the default) appends the groups with fresh numbers, replace drops all stored groups first.
+func (h *Handler) Import(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.RequestTimeout)
+	defer cancel()
+
+	mode := models.ImportMode(r.URL.Query().Get("mode"))
+	switch mode {
+	case "":
+		mode = models.ImportModeMerge
+	case models.ImportModeMerge, models.ImportModeReplace:
+	default:
+		slog.WarnContext(ctx, "validation failed: invalid import mode",
+			slog.String("handler", "Import"),
+			slog.String("mode", string(mode)),
+		)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, `mode: must be "merge" or "replace"`)
+		return
+	}
+
+	groups, err := persistence.Decode(r.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			slog.WarnContext(ctx, "request body too large", slog.String("handler", "Import"))
+			response.Error(w, http.StatusRequestEntityTooLarge, response.CodeTooLarge, "Request body too large")
+			return
+		}
+		slog.WarnContext(ctx, "validation failed: invalid snapshot",
+			slog.String("handler", "Import"),
+			slog.Any("error", err),
+		)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
+		return
+	}
+	if groups == nil {
+		slog.WarnContext(ctx, "validation failed: empty import body", slog.String("handler", "Import"))
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "body must be a JSON array of link groups")
+		return
+	}
+
+	result, err := h.Service.Import(ctx, groups, mode)
+	if err != nil {
+		writeSnapshotError(ctx, w, "Import", err)
+		return
+	}
+
+	slog.DebugContext(ctx, "import succeeded",
+		slog.String("handler", "Import"),
+		slog.String("mode", string(mode)),
+		slog.Int("groups_count", len(result.LinksNum)),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		slog.ErrorContext(ctx, "failed to encode response",
+			slog.String("handler", "Import"),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// writeSnapshotError maps errors from export and import to HTTP responses.
+func writeSnapshotError(ctx context.Context, w http.ResponseWriter, handler string, err error) {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		slog.WarnContext(ctx, "snapshot timeout", slog.String("handler", handler))
+		response.Error(w, http.StatusRequestTimeout, response.CodeTimeout, handler+" timeout")
+	case errors.Is(err, context.Canceled):
+		slog.WarnContext(ctx, "request canceled by client", slog.String("handler", handler))
+		response.Error(w, http.StatusRequestTimeout, response.CodeCanceled, "Request canceled")
+	default:
+		slog.ErrorContext(ctx, "snapshot failed",
+			slog.String("handler", handler),
+			slog.Any("error", err),
+		)
+		response.Error(w, http.StatusInternalServerError, response.CodeInternal, err.Error())
+	}
+}
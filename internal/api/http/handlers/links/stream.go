@@ -0,0 +1,110 @@
+package links
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/polonkoevv/linkchecker/internal/api/http/response"
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+// CheckStream handles POST /links/stream and emits each checked link as a
+// Server-Sent Event, finishing with a "summary" event holding the full response.
+// JSON syntax is validated by middleware, the request shape by decodeRequest.
+func (h *Handler) CheckStream(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.RequestTimeout)
+	defer cancel()
+
+	var req CheckLinksRequest
+	if err := decodeRequest(r.Body, &req, "links"); err != nil {
+		slog.WarnContext(ctx, "validation failed: invalid request body",
+			slog.String("handler", "CheckStream"),
+			slog.Any("error", err),
+		)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
+		return
+	}
+
+	// Business validation: links array cannot be empty
+	if len(req.Links) == 0 {
+		slog.WarnContext(ctx, "validation failed: links array is empty", slog.String("handler", "CheckStream"))
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Links array cannot be empty")
+		return
+	}
+
+	if req.Workers < 0 {
+		slog.WarnContext(ctx, "validation failed: workers is negative", slog.String("handler", "CheckStream"))
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "workers must not be negative")
+		return
+	}
+
+	opts, err := req.checkOptions()
+	if err != nil {
+		slog.WarnContext(ctx, "validation failed: invalid check options",
+			slog.String("handler", "CheckStream"),
+			slog.Any("error", err),
+		)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
+		return
+	}
+
+	rc := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	send := func(event string, payload any) {
+		if err := writeEvent(w, event, payload); err != nil {
+			slog.WarnContext(ctx, "failed to write event",
+				slog.String("handler", "CheckStream"),
+				slog.String("event", event),
+				slog.Any("error", err),
+			)
+			return
+		}
+		if err := rc.Flush(); err != nil {
+			slog.WarnContext(ctx, "failed to flush event",
+				slog.String("handler", "CheckStream"),
+				slog.Any("error", err),
+			)
+		}
+	}
+
+	opts.OnResult = func(link models.Link) {
+		send("link", link)
+	}
+
+	result, err := h.Service.CheckMany(ctx, req.urls(), opts)
+	if err != nil {
+		slog.WarnContext(ctx, "stream check failed",
+			slog.String("handler", "CheckStream"),
+			slog.Any("error", err),
+		)
+		send("error", map[string]string{"error": err.Error()})
+		return
+	}
+
+	send("summary", result)
+
+	slog.DebugContext(ctx, "links streamed successfully",
+		slog.String("handler", "CheckStream"),
+		slog.Int("links_count", len(result.Links)),
+	)
+}
+
+// writeEvent writes a single Server-Sent Event with a JSON-encoded payload.
+func writeEvent(w http.ResponseWriter, event string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encode event: %w", err)
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+	return err
+}
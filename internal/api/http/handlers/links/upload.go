@@ -0,0 +1,249 @@
+package links
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/polonkoevv/linkchecker/internal/api/http/response"
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+const (
+	// uploadFileField is the multipart field holding the uploaded file.
+	uploadFileField = "file"
+	// uploadColumnField names the CSV column with URLs; without it the file is read as one URL per line.
+	uploadColumnField = "column"
+	// uploadWorkersField overrides the worker pool size, like "workers" in JSON requests.
+	uploadWorkersField = "workers"
+)
+
+// errTooManyLinks is returned when an uploaded file lists more links than allowed.
+var errTooManyLinks = errors.New("too many links")
+
+// Upload handles POST /links/upload: it reads URLs from a multipart file upload,
+// either a plain text file with one URL per line or a CSV file with a named column,
+// and checks them like POST /links.
+// Body size and Content-Type are validated by middleware.
+func (h *Handler) Upload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.RequestTimeout)
+	defer cancel()
+
+	mr, err := r.MultipartReader()
+	if err != nil {
+		slog.WarnContext(ctx, "invalid multipart request",
+			slog.String("handler", "Upload"),
+			slog.Any("error", err),
+		)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid multipart body: "+err.Error())
+		return
+	}
+
+	var (
+		content []byte
+		column  string
+		workers int
+		hasFile bool
+	)
+
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			writeUploadReadError(ctx, w, err)
+			return
+		}
+
+		switch part.FormName() {
+		case uploadColumnField:
+			value, err := readFormValue(part)
+			if err != nil {
+				writeUploadReadError(ctx, w, err)
+				return
+			}
+			column = value
+		case uploadWorkersField:
+			value, err := readFormValue(part)
+			if err != nil {
+				writeUploadReadError(ctx, w, err)
+				return
+			}
+			workers, err = strconv.Atoi(value)
+			if err != nil || workers < 0 {
+				slog.WarnContext(ctx, "validation failed: invalid workers", slog.String("handler", "Upload"))
+				response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "workers must be a non-negative integer")
+				return
+			}
+		case uploadFileField:
+			// column may follow the file in the form, so the file is parsed after all parts are read
+			content, err = io.ReadAll(part)
+			if err != nil {
+				writeUploadReadError(ctx, w, err)
+				return
+			}
+			hasFile = true
+		}
+	}
+
+	if !hasFile {
+		slog.WarnContext(ctx, "validation failed: file is missing", slog.String("handler", "Upload"))
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "file: multipart field is required")
+		return
+	}
+
+	links, err := parseUploadedLinks(bytes.NewReader(content), column, h.MaxLinks)
+	if err != nil {
+		if errors.Is(err, errTooManyLinks) {
+			slog.WarnContext(ctx, "too many links in uploaded file",
+				slog.String("handler", "Upload"),
+				slog.Int("max_links", h.MaxLinks),
+			)
+			response.Error(w, http.StatusRequestEntityTooLarge, response.CodeTooLarge, fmt.Sprintf("links: too many links, max %d", h.MaxLinks))
+			return
+		}
+		slog.WarnContext(ctx, "failed to parse uploaded file",
+			slog.String("handler", "Upload"),
+			slog.Any("error", err),
+		)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "file: "+err.Error())
+		return
+	}
+
+	if len(links) == 0 {
+		slog.WarnContext(ctx, "validation failed: uploaded file has no links", slog.String("handler", "Upload"))
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "file: no links found")
+		return
+	}
+
+	result, err := h.Service.CheckMany(ctx, links, models.CheckOptions{Workers: workers})
+	if err != nil {
+		writeCheckError(ctx, w, "Upload", err)
+		return
+	}
+
+	slog.DebugContext(ctx, "uploaded links checked successfully",
+		slog.String("handler", "Upload"),
+		slog.Int("links_count", len(links)),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		slog.ErrorContext(ctx, "failed to encode response",
+			slog.String("handler", "Upload"),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// readFormValue reads a small multipart form value.
+func readFormValue(part io.Reader) (string, error) {
+	value, err := io.ReadAll(part)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(value)), nil
+}
+
+// writeUploadReadError maps errors reading the multipart body to HTTP responses.
+func writeUploadReadError(ctx context.Context, w http.ResponseWriter, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		slog.WarnContext(ctx, "request body too large", slog.String("handler", "Upload"))
+		response.Error(w, http.StatusRequestEntityTooLarge, response.CodeTooLarge, "Request body too large")
+		return
+	}
+
+	slog.WarnContext(ctx, "failed to read multipart body",
+		slog.String("handler", "Upload"),
+		slog.Any("error", err),
+	)
+	response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid multipart body: "+err.Error())
+}
+
+// parseUploadedLinks reads URLs from r. With an empty column every non-empty line is a URL
+// and lines starting with "#" are skipped. Otherwise r is CSV with a header row and URLs
+// are taken from the column with that name. More than maxLinks URLs yield errTooManyLinks.
+func parseUploadedLinks(r io.Reader, column string, maxLinks int) ([]string, error) {
+	var links []string
+	add := func(link string) error {
+		link = strings.TrimSpace(link)
+		if link == "" {
+			return nil
+		}
+		if maxLinks > 0 && len(links) >= maxLinks {
+			return errTooManyLinks
+		}
+		links = append(links, link)
+		return nil
+	}
+
+	if column == "" {
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if strings.HasPrefix(strings.TrimSpace(line), "#") {
+				continue
+			}
+			if err := add(line); err != nil {
+				return nil, err
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return links, nil
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+
+	// spreadsheet exports may start the header with a UTF-8 BOM
+	idx := -1
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")), column) {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return nil, fmt.Errorf("CSV column %q not found", column)
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+		if idx >= len(record) {
+			continue
+		}
+		if err := add(record[idx]); err != nil {
+			return nil, err
+		}
+	}
+
+	return links, nil
+}
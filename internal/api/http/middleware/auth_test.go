@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuth(t *testing.T) {
+	ok := func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+	auth := Chain(
+		BasicAuth("admin", "secret", "/health"),
+		APIKey([]string{"key"}, "/health"),
+	)(ok)
+
+	tests := []struct {
+		name       string
+		path       string
+		user, pass string
+		key        string
+		wantStatus int
+	}{
+		{name: "health without credentials", path: "/health", wantStatus: http.StatusOK},
+		{name: "path under health", path: "/health/debug", wantStatus: http.StatusUnauthorized},
+		{name: "no credentials", path: "/links", wantStatus: http.StatusUnauthorized},
+		{name: "wrong password", path: "/links", user: "admin", pass: "wrong", key: "key", wantStatus: http.StatusUnauthorized},
+		{name: "missing api key", path: "/links", user: "admin", pass: "secret", wantStatus: http.StatusUnauthorized},
+		{name: "valid credentials", path: "/links", user: "admin", pass: "secret", key: "key", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.user != "" {
+				r.SetBasicAuth(tt.user, tt.pass)
+			}
+			if tt.key != "" {
+				r.Header.Set(APIKeyHeader, tt.key)
+			}
+			w := httptest.NewRecorder()
+
+			auth(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if w.Code == http.StatusUnauthorized && w.Header().Get("Content-Type") != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
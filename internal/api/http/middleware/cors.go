@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// corsMaxAge is how long browsers may cache preflight responses, in seconds.
+const corsMaxAge = 600
+
+// CORS adds Cross-Origin Resource Sharing headers for allowed origins and answers
+// OPTIONS preflight requests. "*" in allowedOrigins allows any origin.
+// When allowedOrigins is empty, no CORS headers are sent and browsers block cross-origin calls.
+func CORS(allowedOrigins, allowedMethods, allowedHeaders []string) func(http.HandlerFunc) http.HandlerFunc {
+	methods := strings.Join(allowedMethods, ", ")
+	headers := strings.Join(allowedHeaders, ", ")
+
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		if len(allowedOrigins) == 0 {
+			return next
+		}
+
+		return func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next(w, r)
+				return
+			}
+
+			w.Header().Add("Vary", "Origin")
+
+			if !originAllowed(allowedOrigins, origin) {
+				// Preflight for a disallowed origin is answered without CORS headers
+				if isPreflight(r) {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				next(w, r)
+				return
+			}
+
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+
+			if isPreflight(r) {
+				w.Header().Set("Access-Control-Allow-Methods", methods)
+				w.Header().Set("Access-Control-Allow-Headers", headers)
+				w.Header().Set("Access-Control-Max-Age", strconv.Itoa(corsMaxAge))
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			next(w, r)
+		}
+	}
+}
+
+// isPreflight reports whether r is a CORS preflight request.
+func isPreflight(r *http.Request) bool {
+	return r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+}
+
+// originAllowed reports whether origin is in the allowed list (case-insensitive) or "*" is allowed.
+func originAllowed(allowed []string, origin string) bool {
+	for _, o := range allowed {
+		if o == "*" || strings.EqualFold(o, origin) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCORS(t *testing.T) {
+	called := false
+	next := func(w http.ResponseWriter, _ *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	}
+	cors := CORS(
+		[]string{"https://app.example.com"},
+		[]string{"GET", "POST"},
+		[]string{"Content-Type", "X-API-Key"},
+	)(next)
+
+	t.Run("allowed origin is reflected", func(t *testing.T) {
+		called = false
+		r := httptest.NewRequest(http.MethodGet, "/links", nil)
+		r.Header.Set("Origin", "https://APP.example.com")
+		w := httptest.NewRecorder()
+
+		cors(w, r)
+
+		if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://APP.example.com" {
+			t.Errorf("Access-Control-Allow-Origin = %q, want the request origin", got)
+		}
+		if got := w.Header().Get("Vary"); got != "Origin" {
+			t.Errorf("Vary = %q, want Origin", got)
+		}
+		if !called {
+			t.Error("next handler not called")
+		}
+	})
+
+	t.Run("disallowed origin gets no CORS headers", func(t *testing.T) {
+		called = false
+		r := httptest.NewRequest(http.MethodGet, "/links", nil)
+		r.Header.Set("Origin", "https://evil.example.com")
+		w := httptest.NewRecorder()
+
+		cors(w, r)
+
+		if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+			t.Errorf("Access-Control-Allow-Origin = %q, want empty", got)
+		}
+		if got := w.Header().Get("Vary"); got != "Origin" {
+			t.Errorf("Vary = %q, want Origin", got)
+		}
+		if !called {
+			t.Error("next handler not called")
+		}
+	})
+
+	t.Run("preflight is answered with allowed methods and headers", func(t *testing.T) {
+		called = false
+		r := httptest.NewRequest(http.MethodOptions, "/links", nil)
+		r.Header.Set("Origin", "https://app.example.com")
+		r.Header.Set("Access-Control-Request-Method", "POST")
+		w := httptest.NewRecorder()
+
+		cors(w, r)
+
+		if w.Code != http.StatusNoContent {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNoContent)
+		}
+		if got := w.Header().Get("Access-Control-Allow-Methods"); got != "GET, POST" {
+			t.Errorf("Access-Control-Allow-Methods = %q, want GET, POST", got)
+		}
+		if got := w.Header().Get("Access-Control-Allow-Headers"); got != "Content-Type, X-API-Key" {
+			t.Errorf("Access-Control-Allow-Headers = %q, want Content-Type, X-API-Key", got)
+		}
+		if got := w.Header().Get("Access-Control-Max-Age"); got == "" {
+			t.Error("Access-Control-Max-Age missing")
+		}
+		if called {
+			t.Error("next handler called for a preflight request")
+		}
+	})
+
+	t.Run("request without origin passes through", func(t *testing.T) {
+		called = false
+		w := httptest.NewRecorder()
+
+		cors(w, httptest.NewRequest(http.MethodGet, "/links", nil))
+
+		if len(w.Header()) != 0 || !called {
+			t.Errorf("headers = %v, called = %t, want no headers and next called", w.Header(), called)
+		}
+	})
+}
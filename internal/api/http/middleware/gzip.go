@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// uncompressedTypes lists content types that are sent as is: binary payloads that
+// do not shrink and event streams that must reach the client without buffering.
+var uncompressedTypes = []string{"application/pdf", "text/event-stream"}
+
+// Gzip compresses responses with gzip when the client lists it in Accept-Encoding.
+// The decision is made on the first write, so PDF and event stream responses are skipped.
+func Gzip(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		if !acceptsGzip(r.Header.Get("Accept-Encoding")) {
+			next(w, r)
+			return
+		}
+
+		gw := &gzipResponseWriter{ResponseWriter: w}
+		defer gw.Close()
+
+		next(gw, r)
+	}
+}
+
+// acceptsGzip reports whether the Accept-Encoding header allows gzip.
+func acceptsGzip(header string) bool {
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if !strings.EqualFold(strings.TrimSpace(coding), "gzip") {
+			continue
+		}
+		q, ok := strings.CutPrefix(strings.TrimSpace(params), "q=")
+		if !ok {
+			return true
+		}
+		weight, err := strconv.ParseFloat(q, 64)
+		return err == nil && weight > 0
+	}
+	return false
+}
+
+// gzipResponseWriter compresses the body unless the response content type is excluded.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	gz          *gzip.Writer
+	wroteHeader bool
+}
+
+func (gw *gzipResponseWriter) WriteHeader(code int) {
+	if gw.wroteHeader {
+		return
+	}
+	gw.wroteHeader = true
+
+	h := gw.Header()
+	if code != http.StatusNoContent && code != http.StatusNotModified &&
+		h.Get("Content-Encoding") == "" && compressible(h.Get("Content-Type")) {
+		h.Set("Content-Encoding", "gzip")
+		h.Del("Content-Length")
+		gw.gz = gzip.NewWriter(gw.ResponseWriter)
+	}
+
+	gw.ResponseWriter.WriteHeader(code)
+}
+
+func (gw *gzipResponseWriter) Write(b []byte) (int, error) {
+	if !gw.wroteHeader {
+		if gw.Header().Get("Content-Type") == "" {
+			gw.Header().Set("Content-Type", http.DetectContentType(b))
+		}
+		gw.WriteHeader(http.StatusOK)
+	}
+	if gw.gz == nil {
+		return gw.ResponseWriter.Write(b)
+	}
+	return gw.gz.Write(b)
+}
+
+// Flush sends buffered compressed data to the client.
+func (gw *gzipResponseWriter) Flush() {
+	if gw.gz != nil {
+		_ = gw.gz.Flush()
+	}
+	_ = http.NewResponseController(gw.ResponseWriter).Flush()
+}
+
+// Close finishes the gzip stream, if one was started.
+func (gw *gzipResponseWriter) Close() {
+	if gw.gz != nil {
+		_ = gw.gz.Close()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (gw *gzipResponseWriter) Unwrap() http.ResponseWriter {
+	return gw.ResponseWriter
+}
+
+// compressible reports whether a response with the given content type should be compressed.
+func compressible(contentType string) bool {
+	for _, t := range uncompressedTypes {
+		if strings.HasPrefix(contentType, t) {
+			return false
+		}
+	}
+	return true
+}
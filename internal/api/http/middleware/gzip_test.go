@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGzip(t *testing.T) {
+	const body = `{"links":["https://example.com"]}`
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		contentType    string
+		status         int
+		wantGzip       bool
+	}{
+		{name: "json is compressed", acceptEncoding: "gzip, deflate", contentType: "application/json", status: http.StatusOK, wantGzip: true},
+		{name: "gzip with weight", acceptEncoding: "br;q=1, gzip;q=0.5", contentType: "application/json", status: http.StatusOK, wantGzip: true},
+		{name: "gzip refused with q=0", acceptEncoding: "gzip;q=0", contentType: "application/json", status: http.StatusOK},
+		{name: "no accept encoding", contentType: "application/json", status: http.StatusOK},
+		{name: "pdf is skipped", acceptEncoding: "gzip", contentType: "application/pdf", status: http.StatusOK},
+		{name: "event stream is skipped", acceptEncoding: "gzip", contentType: "text/event-stream", status: http.StatusOK},
+		{name: "no content", acceptEncoding: "gzip", contentType: "application/json", status: http.StatusNoContent},
+		{name: "not modified", acceptEncoding: "gzip", contentType: "application/json", status: http.StatusNotModified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := Gzip(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.Header().Set("Content-Length", "33")
+				w.WriteHeader(tt.status)
+				if tt.status == http.StatusOK {
+					_, _ = io.WriteString(w, body)
+				}
+			})
+			r := httptest.NewRequest(http.MethodGet, "/links", nil)
+			if tt.acceptEncoding != "" {
+				r.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			w := httptest.NewRecorder()
+
+			handler(w, r)
+
+			if got := w.Header().Get("Vary"); got != "Accept-Encoding" {
+				t.Errorf("Vary = %q, want Accept-Encoding", got)
+			}
+			encoding := w.Header().Get("Content-Encoding")
+			if !tt.wantGzip {
+				if encoding != "" {
+					t.Errorf("Content-Encoding = %q, want none", encoding)
+				}
+				if tt.status == http.StatusOK && w.Body.String() != body {
+					t.Errorf("body = %q, want %q", w.Body.String(), body)
+				}
+				return
+			}
+
+			if encoding != "gzip" {
+				t.Fatalf("Content-Encoding = %q, want gzip", encoding)
+			}
+			if got := w.Header().Get("Content-Length"); got != "" {
+				t.Errorf("Content-Length = %q, want it dropped for the compressed body", got)
+			}
+			if got := gunzip(t, w.Body); got != body {
+				t.Errorf("decompressed body = %q, want %q", got, body)
+			}
+		})
+	}
+}
+
+func TestGzip_Flush(t *testing.T) {
+	handler := Gzip(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		_, _ = io.WriteString(w, "first\n")
+		if err := http.NewResponseController(w).Flush(); err != nil {
+			t.Errorf("Flush() error = %v, want nil", err)
+		}
+		_, _ = io.WriteString(w, "second\n")
+	})
+	r := httptest.NewRequest(http.MethodPost, "/links/stream", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+
+	handler(w, r)
+
+	if !w.Flushed {
+		t.Error("response not flushed through http.ResponseController")
+	}
+	if got := gunzip(t, w.Body); got != "first\nsecond\n" {
+		t.Errorf("decompressed body = %q, want both lines", got)
+	}
+}
+
+// gunzip decompresses body.
+func gunzip(t *testing.T, body io.Reader) string {
+	t.Helper()
+
+	zr, err := gzip.NewReader(body)
+	if err != nil {
+		t.Fatalf("gzip.NewReader() error = %v, want nil", err)
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("read gzip body error = %v, want nil", err)
+	}
+	return string(data)
+}
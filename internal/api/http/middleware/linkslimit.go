@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/polonkoevv/linkchecker/internal/api/http/response"
+)
+
+// linksField is the request field holding the array of links to check.
+const linksField = "links"
+
+// LimitLinksCount rejects requests whose top-level "links" array has more than maxLinks
+// elements. The body is scanned as a token stream and the check stops as soon as the
+// limit is exceeded, so huge arrays are never decoded as a whole.
+// Malformed JSON is passed through for ValidateJSONStructure to report.
+func LimitLinksCount(maxLinks int) func(http.HandlerFunc) http.HandlerFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		if maxLinks <= 0 {
+			return next
+		}
+
+		return func(w http.ResponseWriter, r *http.Request) {
+			var consumed bytes.Buffer
+			count, exceeded := countLinks(io.TeeReader(r.Body, &consumed), maxLinks)
+
+			if exceeded {
+				slog.WarnContext(r.Context(), "too many links in request",
+					slog.String("method", r.Method),
+					slog.String("path", r.URL.Path),
+					slog.Int("max_links", maxLinks),
+				)
+				response.Error(w, http.StatusRequestEntityTooLarge, response.CodeTooLarge, fmt.Sprintf("links: too many links, max %d", maxLinks))
+				return
+			}
+
+			// Restore body for next handler: scanned part followed by the unread rest
+			r.Body = readCloser{
+				Reader: io.MultiReader(bytes.NewReader(consumed.Bytes()), r.Body),
+				Closer: r.Body,
+			}
+
+			slog.DebugContext(r.Context(), "links count within limit", slog.Int("links_count", count))
+
+			next(w, r)
+		}
+	}
+}
+
+// readCloser combines a reader with the closer of the original body.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// countLinks counts elements of the top-level "links" array in the JSON object read from r.
+// It reports exceeded as soon as the count goes over maxLinks. Decoding errors stop the scan.
+func countLinks(r io.Reader, maxLinks int) (count int, exceeded bool) {
+	dec := json.NewDecoder(r)
+
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return 0, false
+	}
+
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return count, false
+		}
+
+		if key != linksField {
+			if err := skipValue(dec); err != nil {
+				return count, false
+			}
+			continue
+		}
+
+		if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+			return 0, false
+		}
+		for dec.More() {
+			count++
+			if count > maxLinks {
+				return count, true
+			}
+			if err := skipValue(dec); err != nil {
+				return count, false
+			}
+		}
+		return count, false
+	}
+
+	return 0, false
+}
+
+// skipValue consumes the next JSON value from dec without building it.
+func skipValue(dec *json.Decoder) error {
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+
+		if depth == 0 {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLimitLinksCount(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{name: "at the limit", body: `{"links": ["a", "b", "c"]}`, wantStatus: http.StatusOK},
+		{name: "one over the limit", body: `{"links": ["a", "b", "c", "d"]}`, wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "links after other keys", body: `{"workers": 2, "options": {"links": [1, 2, 3, 4]}, "links": ["a", "b", "c", "d"]}`, wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "link objects at the limit", body: `{"links": [{"url": "a"}, {"url": "b", "labels": ["x", "y"]}, "c"]}`, wantStatus: http.StatusOK},
+		{name: "malformed json passes through", body: `{"links": ["a" "b", "c", "d", "e"]}`, wantStatus: http.StatusOK},
+		{name: "links not an array", body: `{"links": "a"}`, wantStatus: http.StatusOK},
+		{name: "not an object", body: `["a", "b", "c", "d"]`, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			handler := LimitLinksCount(3)(func(w http.ResponseWriter, r *http.Request) {
+				data, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Fatalf("read body error = %v, want nil", err)
+				}
+				got = string(data)
+			})
+			w := httptest.NewRecorder()
+
+			handler(w, httptest.NewRequest(http.MethodPost, "/links", strings.NewReader(tt.body)))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusOK && got != tt.body {
+				t.Errorf("next handler read body %q, want the full body %q", got, tt.body)
+			}
+		})
+	}
+
+	t.Run("body after the scanned part is restored", func(t *testing.T) {
+		body := `{"links": ["a"], "name": "` + strings.Repeat("x", 64<<10) + `"}`
+		var got string
+		handler := LimitLinksCount(3)(func(w http.ResponseWriter, r *http.Request) {
+			data, _ := io.ReadAll(r.Body)
+			got = string(data)
+		})
+
+		handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/links", strings.NewReader(body)))
+
+		if got != body {
+			t.Errorf("next handler read %d bytes, want %d", len(got), len(body))
+		}
+	})
+}
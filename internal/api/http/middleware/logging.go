@@ -19,7 +19,7 @@ func Logging(next http.HandlerFunc) http.HandlerFunc {
 
 		duration := time.Since(start)
 
-		slog.Info("HTTP request",
+		slog.InfoContext(r.Context(), "HTTP request",
 			slog.String("method", r.Method),
 			slog.String("path", r.URL.Path),
 			slog.String("remote_addr", r.RemoteAddr),
@@ -39,3 +39,8 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.statusCode = code
 	rw.ResponseWriter.WriteHeader(code)
 }
+
+// Unwrap exposes the underlying writer to http.ResponseController (e.g. for flushing streams).
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
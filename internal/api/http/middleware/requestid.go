@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/polonkoevv/linkchecker/internal/logger"
+)
+
+// RequestIDHeader is the header used to receive and echo the request id.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength limits incoming request ids to keep logs sane.
+const maxRequestIDLength = 128
+
+// RequestID reads the X-Request-ID header or generates a new id, stores it in the
+// request context for logging and echoes it in the response header.
+func RequestID(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if id == "" || len(id) > maxRequestIDLength {
+			id = newRequestID()
+		}
+
+		w.Header().Set(RequestIDHeader, id)
+
+		ctx := logger.WithRequestID(r.Context(), id)
+		next(w, r.WithContext(ctx))
+	}
+}
+
+// newRequestID returns a random 128-bit hex-encoded id.
+func newRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b[:])
+}
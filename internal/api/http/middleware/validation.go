@@ -7,12 +7,15 @@ import (
 	"log/slog"
 	"net/http"
 	"strings"
+
+	"github.com/polonkoevv/linkchecker/internal/api/http/response"
 )
 
 const (
 	// MaxRequestBodySize limits request body size to 1MB
-	MaxRequestBodySize = 1 << 20 // 1 MB
-	contentTypeJSON    = "application/json"
+	MaxRequestBodySize   = 1 << 20 // 1 MB
+	contentTypeJSON      = "application/json"
+	contentTypeMultipart = "multipart/form-data"
 )
 
 // ValidateJSONContentType validates that POST/PUT/PATCH requests have JSON Content-Type.
@@ -22,22 +25,22 @@ func ValidateJSONContentType(next http.HandlerFunc) http.HandlerFunc {
 		if r.Method == http.MethodPost || r.Method == http.MethodPut || r.Method == http.MethodPatch {
 			contentType := r.Header.Get("Content-Type")
 			if contentType == "" {
-				slog.Warn("missing Content-Type header",
+				slog.WarnContext(r.Context(), "missing Content-Type header",
 					slog.String("method", r.Method),
 					slog.String("path", r.URL.Path),
 				)
-				http.Error(w, "Content-Type header is required", http.StatusBadRequest)
+				response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Content-Type header is required")
 				return
 			}
 
 			// Allow Content-Type with charset, e.g., "application/json; charset=utf-8"
 			if !strings.HasPrefix(contentType, contentTypeJSON) {
-				slog.Warn("invalid Content-Type header",
+				slog.WarnContext(r.Context(), "invalid Content-Type header",
 					slog.String("method", r.Method),
 					slog.String("path", r.URL.Path),
 					slog.String("content_type", contentType),
 				)
-				http.Error(w, "Content-Type must be application/json", http.StatusUnsupportedMediaType)
+				response.Error(w, http.StatusUnsupportedMediaType, response.CodeUnsupportedMediaType, "Content-Type must be application/json")
 				return
 			}
 		}
@@ -46,6 +49,33 @@ func ValidateJSONContentType(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
+// ValidateMultipartContentType validates that POST requests carry a multipart/form-data body.
+func ValidateMultipartContentType(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		contentType := r.Header.Get("Content-Type")
+		if contentType == "" {
+			slog.WarnContext(r.Context(), "missing Content-Type header",
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+			)
+			response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Content-Type header is required")
+			return
+		}
+
+		if !strings.HasPrefix(contentType, contentTypeMultipart) {
+			slog.WarnContext(r.Context(), "invalid Content-Type header",
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.String("content_type", contentType),
+			)
+			response.Error(w, http.StatusUnsupportedMediaType, response.CodeUnsupportedMediaType, "Content-Type must be multipart/form-data")
+			return
+		}
+
+		next(w, r)
+	}
+}
+
 // ValidateBodySize limits the size of request body to prevent DoS attacks.
 func ValidateBodySize(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -56,6 +86,18 @@ func ValidateBodySize(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
+// LimitBodySize limits the size of request body to maxBytes, for routes accepting
+// larger bodies than MaxRequestBodySize.
+func LimitBodySize(maxBytes int64) func(http.HandlerFunc) http.HandlerFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+
+			next(w, r)
+		}
+	}
+}
+
 // ValidateJSONStructure validates that request body is valid JSON (without decoding into specific struct).
 func ValidateJSONStructure(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -65,19 +107,19 @@ func ValidateJSONStructure(next http.HandlerFunc) http.HandlerFunc {
 			body, err := io.ReadAll(r.Body)
 			if err != nil {
 				if err.Error() == "http: request body too large" {
-					slog.Warn("request body too large",
+					slog.WarnContext(r.Context(), "request body too large",
 						slog.String("method", r.Method),
 						slog.String("path", r.URL.Path),
 					)
-					http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+					response.Error(w, http.StatusRequestEntityTooLarge, response.CodeTooLarge, "Request body too large")
 					return
 				}
-				slog.Warn("failed to read request body",
+				slog.WarnContext(r.Context(), "failed to read request body",
 					slog.String("method", r.Method),
 					slog.String("path", r.URL.Path),
 					slog.Any("error", err),
 				)
-				http.Error(w, "Failed to read request body", http.StatusBadRequest)
+				response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Failed to read request body")
 				return
 			}
 
@@ -88,12 +130,12 @@ func ValidateJSONStructure(next http.HandlerFunc) http.HandlerFunc {
 			if len(body) > 0 {
 				var jsonValue interface{}
 				if err := json.Unmarshal(body, &jsonValue); err != nil {
-					slog.Warn("invalid JSON structure",
+					slog.WarnContext(r.Context(), "invalid JSON structure",
 						slog.String("method", r.Method),
 						slog.String("path", r.URL.Path),
 						slog.Any("error", err),
 					)
-					http.Error(w, "Invalid JSON: "+err.Error(), http.StatusBadRequest)
+					response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid JSON: "+err.Error())
 					return
 				}
 			}
@@ -0,0 +1,42 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Error codes returned in ErrorResponse.Code. Clients should match on codes, messages may change.
+const (
+	CodeInvalidRequest       = "invalid_request"
+	CodeUnauthorized         = "unauthorized"
+	CodeNotFound             = "not_found"
+	CodeTimeout              = "timeout"
+	CodeCanceled             = "canceled"
+	CodeTooLarge             = "too_large"
+	CodeUnsupportedMediaType = "unsupported_media_type"
+	CodeReportTooLarge       = "report_too_large"
+	CodeNoLinksFound         = "no_links_found"
+	CodeSourceUnavailable    = "source_unavailable"
+	CodeShuttingDown         = "shutting_down"
+	CodeJobFinished          = "job_finished"
+	CodeMonitorPending       = "monitor_pending"
+	CodeInternal             = "internal_error"
+)
+
+// ErrorResponse is the JSON body of every error response.
+type ErrorResponse struct {
+	Error string `json:"error"`
+	Code  string `json:"code"`
+}
+
+// Error replies to the request with status and an ErrorResponse holding code and message.
+// Like http.Error, it drops Content-Length set for a body that is no longer sent.
+func Error(w http.ResponseWriter, status int, code, message string) {
+	h := w.Header()
+	h.Del("Content-Length")
+	h.Del("Content-Disposition")
+	h.Set("Content-Type", "application/json")
+	h.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(ErrorResponse{Error: message, Code: code})
+}
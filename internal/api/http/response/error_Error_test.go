@@ -0,0 +1,34 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestError(t *testing.T) {
+	w := httptest.NewRecorder()
+	w.Header().Set("Content-Length", "1024")
+	w.Header().Set("Content-Disposition", "attachment; filename=link_report.pdf")
+
+	Error(w, http.StatusNotFound, CodeNotFound, "Job not found")
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+	if w.Header().Get("Content-Length") != "" || w.Header().Get("Content-Disposition") != "" {
+		t.Errorf("headers of the replaced body kept: %v", w.Header())
+	}
+
+	var body ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatalf("decode body error = %v, want nil", err)
+	}
+	if body.Error != "Job not found" || body.Code != CodeNotFound {
+		t.Errorf("body = %+v, want Job not found with code %s", body, CodeNotFound)
+	}
+}
@@ -6,38 +6,112 @@ import (
 
 	"github.com/polonkoevv/linkchecker/internal/api/http/handlers/links"
 	"github.com/polonkoevv/linkchecker/internal/api/http/middleware"
+	"github.com/polonkoevv/linkchecker/internal/config"
 )
 
-// ConfigRoutes registers HTTP routes for link operations with middleware and returns a mux.
-func ConfigRoutes(linksHandler *links.Handler) *http.ServeMux {
+// healthPath is the liveness endpoint, excluded from authentication.
+const healthPath = "/health"
+
+// maxImportBodySize limits POST /import bodies, which carry the whole storage state.
+const maxImportBodySize = 64 << 20 // 64 MB
+
+// health reports that the server is up and able to handle requests.
+func health(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = w.Write([]byte("ok"))
+}
+
+// ConfigRoutes registers HTTP routes for link operations with middleware and returns the root handler.
+// Request id assignment, logging and authentication wrap the whole mux, so every route is checked and
+// rejected requests are still logged. CORS stays outside because browsers send preflight requests
+// without credentials.
+func ConfigRoutes(linksHandler *links.Handler, apiCfg config.APIConfig) http.Handler {
 	mux := http.NewServeMux()
 
-	// Middleware chain for POST requests (validation + logging)
+	// Middleware chain for POST requests (compression + validation)
 	postMiddleware := middleware.Chain(
-		middleware.Logging,
+		middleware.Gzip,
+		middleware.ValidateBodySize,
+		middleware.ValidateJSONContentType,
+		middleware.ValidateJSONStructure,
+	)
+
+	// Middleware chain for link check requests, rejecting oversized links arrays before full decode
+	linksMiddleware := middleware.Chain(
+		middleware.Gzip,
 		middleware.ValidateBodySize,
 		middleware.ValidateJSONContentType,
+		middleware.LimitLinksCount(apiCfg.MaxLinks),
 		middleware.ValidateJSONStructure,
 	)
 
-	// Middleware chain for GET requests (only logging)
+	// Middleware chain for file uploads, the links limit is enforced by the handler while parsing
+	uploadMiddleware := middleware.Chain(
+		middleware.Gzip,
+		middleware.ValidateBodySize,
+		middleware.ValidateMultipartContentType,
+	)
+
+	// Middleware chain for storage imports, the body is validated as a whole by the handler
+	importMiddleware := middleware.Chain(
+		middleware.Gzip,
+		middleware.LimitBodySize(maxImportBodySize),
+		middleware.ValidateJSONContentType,
+	)
+
+	// Middleware chain for POST and DELETE requests without a body, e.g. actions on stored groups and jobs
+	actionMiddleware := middleware.Chain(
+		middleware.Gzip,
+	)
+
+	// Middleware chain for GET requests (compression)
 	getMiddleware := middleware.Chain(
-		middleware.Logging,
+		middleware.Gzip,
 	)
 
-	mux.HandleFunc("POST /links", postMiddleware(linksHandler.Check))
+	mux.HandleFunc("GET "+healthPath, getMiddleware(health))
+	mux.HandleFunc("POST /links", linksMiddleware(linksHandler.Check))
+	mux.HandleFunc("POST /links/sitemap", postMiddleware(linksHandler.CheckSitemap))
+	mux.HandleFunc("POST /links/crawl", postMiddleware(linksHandler.Crawl))
+	mux.HandleFunc("POST /links/stream", linksMiddleware(linksHandler.CheckStream))
+	mux.HandleFunc("POST /links/upload", uploadMiddleware(linksHandler.Upload))
+	mux.HandleFunc("POST /links/report", linksMiddleware(linksHandler.CheckReport))
 	mux.HandleFunc("GET /links", getMiddleware(linksHandler.GetAll))
+	mux.HandleFunc("GET /links/search", getMiddleware(linksHandler.Search))
+	mux.HandleFunc("GET /links/{num}", getMiddleware(linksHandler.GetGroup))
+	mux.HandleFunc("POST /links/{num}/recheck", actionMiddleware(linksHandler.Recheck))
+	mux.HandleFunc("PUT /links/{num}/ignored", postMiddleware(linksHandler.SetIgnored))
+	mux.HandleFunc("GET /groups", getMiddleware(linksHandler.ListGroups))
 	mux.HandleFunc("POST /report", postMiddleware(linksHandler.GenerateReport))
+	mux.HandleFunc("GET /stats", getMiddleware(linksHandler.Stats))
+	mux.HandleFunc("GET /jobs/{id}", getMiddleware(linksHandler.GetJob))
+	mux.HandleFunc("DELETE /jobs/{id}", actionMiddleware(linksHandler.CancelJob))
+	mux.HandleFunc("GET /monitor", getMiddleware(linksHandler.Monitor))
+	mux.HandleFunc("GET /admin/status", getMiddleware(linksHandler.AdminStatus))
+	mux.HandleFunc("GET /export", getMiddleware(linksHandler.Export))
+	mux.HandleFunc("POST /import", importMiddleware(linksHandler.Import))
+
+	auth := middleware.Chain(
+		middleware.BasicAuth(apiCfg.Username, apiCfg.Password, healthPath),
+		middleware.APIKey(apiCfg.Keys, healthPath),
+	)
+	cors := middleware.CORS(apiCfg.CORSAllowedOrigins, apiCfg.CORSAllowedMethods, apiCfg.CORSAllowedHeaders)
+
+	root := middleware.Chain(
+		middleware.RequestID,
+		middleware.Logging,
+		auth,
+	)
 
-	return mux
+	return cors(root(mux.ServeHTTP))
 }
 
 // NewServer constructs an http.Server with the provided address, handler and timeouts.
-func NewServer(addr string, mux *http.ServeMux, readHeaderTimeout, readTimeout, writeTimeout, idleTimeout time.Duration) *http.Server {
+func NewServer(addr string, handler http.Handler, readHeaderTimeout, readTimeout, writeTimeout, idleTimeout time.Duration) *http.Server {
 
 	return &http.Server{
 		Addr:              addr,
-		Handler:           mux,
+		Handler:           handler,
 		ReadTimeout:       readTimeout,
 		ReadHeaderTimeout: readHeaderTimeout,
 		WriteTimeout:      writeTimeout,
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/api/http/handlers/links"
+	"github.com/polonkoevv/linkchecker/internal/api/http/middleware"
+	"github.com/polonkoevv/linkchecker/internal/config"
+)
+
+func TestConfigRoutes_Auth(t *testing.T) {
+	handler := ConfigRoutes(links.New(nil, time.Second, 0, http.StatusUnprocessableEntity), config.APIConfig{
+		Username: "admin",
+		Password: "secret",
+		Keys:     []string{"key"},
+	})
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+	}{
+		{name: "health is not authenticated", path: healthPath, wantStatus: http.StatusOK},
+		{name: "links require credentials", path: "/links", wantStatus: http.StatusUnauthorized},
+		{name: "unknown route requires credentials", path: "/healthz", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if w.Header().Get(middleware.RequestIDHeader) == "" {
+				t.Errorf("%s header missing, rejected requests must carry a request id", middleware.RequestIDHeader)
+			}
+		})
+	}
+}
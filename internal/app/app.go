@@ -2,39 +2,106 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/polonkoevv/linkchecker/internal/api/http/handlers/links"
 	"github.com/polonkoevv/linkchecker/internal/api/http/server"
 	"github.com/polonkoevv/linkchecker/internal/config"
+	"github.com/polonkoevv/linkchecker/internal/models"
 	"github.com/polonkoevv/linkchecker/internal/service/link"
+	"github.com/polonkoevv/linkchecker/internal/storage"
 	"github.com/polonkoevv/linkchecker/internal/storage/inmemory"
+	"github.com/polonkoevv/linkchecker/internal/storage/persistence"
+	"github.com/polonkoevv/linkchecker/internal/storage/sqlite"
+	"github.com/polonkoevv/linkchecker/internal/urlchecker"
 )
 
 // App wires together configuration, storage, services and HTTP server.
 type App struct {
 	cfg     *config.Config
-	storage *inmemory.Storage
-	server  *http.Server
+	storage storage.Storage
+	// snapshots is set for in-memory storage, which is snapshotted on shutdown.
+	snapshots *snapshotter
+	service   *link.Service
+	server    *http.Server
 }
 
 const shutdownTimeout = 5 * time.Second
 
+// drainTimeout is the grace period for checks and async jobs to finish after the server stopped.
+const drainTimeout = 10 * time.Second
+
+// persistenceTimeout bounds loading and saving the storage snapshot.
+const persistenceTimeout = 30 * time.Second
+
+// maxSweepInterval bounds the period between purges of groups older than GROUP_TTL.
+const maxSweepInterval = time.Hour
+
 // New constructs the application with all required dependencies.
 func New(cfg *config.Config) (*App, error) {
-	stg := inmemory.New()
-	if err := stg.LoadFromFile(cfg.Storage.FileStoragePath); err != nil {
-		return nil, fmt.Errorf("load storage from file: %w", err)
+	stg, snapshots, err := newStorage(cfg.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Checker.InsecureTLS {
+		slog.Warn("TLS certificate verification is disabled for checks",
+			slog.Any("hosts", cfg.Checker.InsecureTLSHosts),
+		)
 	}
-	slog.Info("in-memory storage initialized", slog.String("file", cfg.Storage.FileStoragePath))
 
-	srv := link.New(stg, cfg.Server.MaxWorkersNum)
+	basicAuth := make([]urlchecker.HostCredentials, 0, len(cfg.Checker.BasicAuth))
+	for _, cred := range cfg.Checker.BasicAuth {
+		basicAuth = append(basicAuth, urlchecker.HostCredentials(cred))
+	}
+
+	srv := link.New(stg,
+		link.Config{
+			WorkerCount:         cfg.Server.MaxWorkersNum,
+			MaxWorkerCount:      cfg.Server.MaxWorkersLimit,
+			MaxConcurrentChecks: cfg.Server.MaxConcurrentChecks,
+			SharedWorkers:       cfg.Server.SharedWorkersNum,
+			ChannelBuffer:       cfg.Server.CheckChannelBuffer,
+			CheckTimeout:        cfg.Server.PerCheckTimeout,
+			MaxReportGroups:     cfg.Report.MaxGroups,
+			MaxReportLinks:      cfg.Report.MaxLinks,
+			IdempotencyKeyTTL:   cfg.Server.IdempotencyKeyTTL,
+			PerHostDelay:        cfg.Server.PerHostDelay,
+			PerHostJitter:       cfg.Server.PerHostJitter,
+			SkipHosts:           cfg.Checker.SkipHosts,
+			SlowThreshold:       cfg.Checker.SlowThreshold,
+			MaxBodyBytes:        cfg.Checker.MaxBodyBytes,
+			CollapseWWW:         cfg.Checker.CollapseWWW,
+			Percentiles:         cfg.Report.Percentiles,
+			MonitorURLs:         cfg.Monitor.URLs,
+			MonitorName:         cfg.Monitor.Name,
+			MonitorInterval:     cfg.Monitor.Interval,
+		},
+		urlchecker.WithMetaRefresh(cfg.Checker.FollowMetaRefresh),
+		urlchecker.WithInsecureSkipVerify(cfg.Checker.InsecureTLS, cfg.Checker.InsecureTLSHosts),
+		urlchecker.WithDefaultScheme(cfg.Checker.DefaultScheme, cfg.Checker.SchemeFallback),
+		urlchecker.WithMaxRedirects(cfg.Checker.MaxRedirects),
+		urlchecker.WithRetryAfter(cfg.Checker.RateLimitRetries, cfg.Checker.MaxRetryAfter),
+		urlchecker.WithSizeProbe(cfg.Checker.ProbeSize),
+		urlchecker.WithHeaderLogging(cfg.Checker.LogHeaders),
+		urlchecker.WithTiming(cfg.Checker.Timing),
+		urlchecker.WithBasicAuth(basicAuth),
+		urlchecker.WithStatusCodes(cfg.Checker.AvailableCodes, cfg.Checker.NotAvailableCodes),
+		urlchecker.WithPrivateAddressBlocking(cfg.Checker.BlockPrivateAddresses),
+		urlchecker.WithUserAgent(cfg.Checker.UserAgent),
+		urlchecker.WithAccept(cfg.Checker.Accept),
+		urlchecker.WithMaxBodySize(cfg.Checker.MaxBodyBytes),
+		urlchecker.WithConnectionPool(cfg.Checker.MaxIdleConns, cfg.Checker.MaxIdleConnsPerHost, cfg.Checker.IdleConnTimeout),
+		urlchecker.WithTimeouts(cfg.Checker.DialTimeout, cfg.Checker.ResponseHeaderTimeout),
+	)
 
-	handler := links.New(srv, cfg.Server.RequestTimeout)
-	mux := server.ConfigRoutes(handler)
+	handler := links.New(srv, cfg.Server.RequestTimeout, cfg.API.MaxLinks, cfg.API.BrokenStatus)
+	mux := server.ConfigRoutes(handler, cfg.API)
 
 	addr := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
 	httpServer := server.NewServer(
@@ -47,9 +114,11 @@ func New(cfg *config.Config) (*App, error) {
 	)
 
 	return &App{
-		cfg:     cfg,
-		storage: stg,
-		server:  httpServer,
+		cfg:       cfg,
+		storage:   stg,
+		snapshots: snapshots,
+		service:   srv,
+		server:    httpServer,
 	}, nil
 }
 
@@ -65,6 +134,22 @@ func (a *App) Run(ctx context.Context) error {
 		}
 	}()
 
+	var background sync.WaitGroup
+	if ttl := a.cfg.Storage.GroupTTL; ttl > 0 {
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			a.sweepExpired(ctx, ttl)
+		}()
+	}
+	if len(a.cfg.Monitor.URLs) > 0 {
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			a.runMonitor(ctx, a.cfg.Monitor.Interval)
+		}()
+	}
+
 	// wait for cancellation (signal from main)
 	<-ctx.Done()
 	slog.Info("shutdown signal received")
@@ -79,12 +164,201 @@ func (a *App) Run(ctx context.Context) error {
 		slog.Info("server shutdown gracefully")
 	}
 
-	// persist storage after server has stopped
-	if err := a.storage.SaveToFile(a.cfg.Storage.FileStoragePath); err != nil {
-		slog.Error("failed to save storage to file", slog.Any("error", err))
-		return err
+	// let in-flight checks and async jobs store their results before the snapshot
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancelDrain()
+
+	if err := a.service.Shutdown(drainCtx); err != nil {
+		slog.Warn("link checks did not finish before shutdown", slog.Any("error", err))
+	}
+
+	// a purge or monitor round in progress finishes before the snapshot and storage close
+	background.Wait()
+
+	saveErr := a.saveSnapshot()
+
+	groups, err := a.storage.GetAll()
+	if err != nil {
+		slog.Error("failed to load links for exit report", slog.Any("error", err))
+	} else if err := emitExitReport(groups, a.cfg.Report.Percentiles, a.cfg.Report.ExitReportPath); err != nil {
+		slog.Error("failed to write exit report", slog.Any("error", err))
+	}
+
+	if err := a.storage.Close(); err != nil {
+		slog.Error("failed to close storage", slog.Any("error", err))
 	}
 
-	slog.Info("storage saved to file", slog.String("file", a.cfg.Storage.FileStoragePath))
+	return saveErr
+}
+
+// sweepExpired purges groups older than ttl right away and then periodically until ctx is canceled.
+func (a *App) sweepExpired(ctx context.Context, ttl time.Duration) {
+	ticker := time.NewTicker(min(ttl, maxSweepInterval))
+	defer ticker.Stop()
+
+	for {
+		a.expireGroups(ttl)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// expireGroups removes groups whose links were all checked more than ttl ago.
+// In-memory storage drops them from the next saved snapshot.
+func (a *App) expireGroups(ttl time.Duration) {
+	expired, err := a.storage.Expire(time.Now().Add(-ttl))
+	if err != nil {
+		slog.Error("failed to purge expired link groups", slog.Any("error", err))
+		return
+	}
+	if len(expired) > 0 {
+		slog.Info("purged expired link groups",
+			slog.Duration("ttl", ttl),
+			slog.Any("purged_nums", expired),
+		)
+	}
+}
+
+// runMonitor checks the monitored URLs right away and then every interval until ctx is canceled.
+// A round that takes longer than interval delays the next one instead of overlapping it.
+func (a *App) runMonitor(ctx context.Context, interval time.Duration) {
+	slog.Info("starting monitor",
+		slog.Int("urls", len(a.cfg.Monitor.URLs)),
+		slog.Duration("interval", interval),
+	)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if !a.checkMonitor(ctx) {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkMonitor runs one monitor round and logs its outcome.
+// It reports false once the service is shutting down.
+func (a *App) checkMonitor(ctx context.Context) bool {
+	res, err := a.service.CheckMonitor(ctx)
+	switch {
+	case errors.Is(err, models.ErrShuttingDown):
+		return false
+	case err != nil && ctx.Err() != nil:
+		slog.Warn("monitor round canceled by shutdown")
+		return false
+	case err != nil:
+		slog.Error("monitor round failed", slog.Any("error", err))
+		return true
+	}
+
+	available := 0
+	for _, result := range res.Results {
+		if !result.Ignored && result.Status == models.LinkStatusAvailable {
+			available++
+		}
+	}
+	slog.Info("monitor round finished",
+		slog.Int("links_num", res.LinksNum),
+		slog.Int("total", len(res.Results)),
+		slog.Int("available", available),
+	)
+	return true
+}
+
+// saveSnapshot persists in-memory storage, it does nothing for storages that persist themselves.
+func (a *App) saveSnapshot() error {
+	if a.snapshots == nil {
+		return nil
+	}
+
+	if err := a.snapshots.save(); err != nil {
+		slog.Error("failed to save storage snapshot", slog.Any("error", err))
+		return err
+	}
+	slog.Info("storage snapshot saved", slog.String("location", a.snapshots.backend.Location()))
 	return nil
 }
+
+// snapshotter saves snapshots of in-memory storage to its backend one at a time,
+// so that flushes after inserts and the snapshot on shutdown do not overlap.
+type snapshotter struct {
+	mu      sync.Mutex
+	memory  *inmemory.Storage
+	backend persistence.Backend
+}
+
+// save persists the current state of the storage.
+func (s *snapshotter) save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// called on shutdown, when ctx of Run is already canceled, and from insert flushes
+	ctx, cancel := context.WithTimeout(context.Background(), persistenceTimeout)
+	defer cancel()
+
+	return s.backend.Save(ctx, s.memory.Snapshot())
+}
+
+// flush saves a snapshot after inserts, failures are logged and retried with the next flush.
+func (s *snapshotter) flush() {
+	if err := s.save(); err != nil {
+		slog.Error("failed to flush storage snapshot", slog.Any("error", err))
+		return
+	}
+	slog.Debug("storage snapshot flushed", slog.String("location", s.backend.Location()))
+}
+
+// newStorage opens the storage selected in the configuration. In-memory storage is
+// returned with its snapshotter, restored from the last saved snapshot.
+func newStorage(cfg config.StorageConfig) (storage.Storage, *snapshotter, error) {
+	if cfg.Backend == persistence.BackendSQLite {
+		db, err := sqlite.New(cfg.SQLitePath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("open sqlite storage: %w", err)
+		}
+		slog.Info("sqlite storage initialized", slog.String("path", cfg.SQLitePath))
+		return db, nil, nil
+	}
+
+	backend, err := persistence.New(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create storage persistence: %w", err)
+	}
+
+	loadCtx, cancel := context.WithTimeout(context.Background(), persistenceTimeout)
+	defer cancel()
+
+	groups, err := backend.Load(loadCtx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load storage snapshot: %w", err)
+	}
+
+	snapshots := &snapshotter{backend: backend}
+	memory := inmemory.New(
+		inmemory.WithMaxGroups(cfg.MaxStoredGroups),
+		inmemory.WithFlushEvery(cfg.PersistEveryN, snapshots.flush),
+	)
+	memory.Restore(groups)
+	snapshots.memory = memory
+	slog.Info("in-memory storage initialized",
+		slog.String("backend", cfg.Backend),
+		slog.String("location", backend.Location()),
+		slog.Int("groups", len(groups)),
+		slog.Int("max_groups", cfg.MaxStoredGroups),
+		slog.Int("persist_every_n", cfg.PersistEveryN),
+		slog.Duration("group_ttl", cfg.GroupTTL),
+	)
+
+	return memory, snapshots, nil
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+	"github.com/polonkoevv/linkchecker/internal/stats"
+)
+
+// exitReportSlowest is the number of slowest links listed in the exit report.
+const exitReportSlowest = 5
+
+// exitReport summarizes all stored groups when the application stops.
+type exitReport struct {
+	Statistics          models.Statistics `json:"statistics"`
+	AvailabilityPercent float64           `json:"availability_percent"`
+	Slowest             []models.Link     `json:"slowest"`
+}
+
+// newExitReport builds the exit report over the given groups with the given duration percentiles.
+func newExitReport(groups []models.Links, percentiles []float64) exitReport {
+	statistics := stats.CalculateGroups(groups, percentiles...)
+
+	return exitReport{
+		Statistics:          statistics,
+		AvailabilityPercent: stats.AvailabilityPercent(statistics),
+		Slowest:             stats.Slowest(groups, exitReportSlowest),
+	}
+}
+
+// emitExitReport logs the exit report and writes it to path as JSON when path is set.
+func emitExitReport(groups []models.Links, percentiles []float64, path string) error {
+	report := newExitReport(groups, percentiles)
+
+	slowest := make([]string, 0, len(report.Slowest))
+	for _, link := range report.Slowest {
+		slowest = append(slowest, fmt.Sprintf("%s (%s)", link.URL, link.Duration.Round(time.Millisecond)))
+	}
+
+	slog.Info("exit report",
+		slog.Int("groups", report.Statistics.Groups),
+		slog.Int("links", report.Statistics.Total),
+		slog.Int("available", report.Statistics.Available),
+		slog.Int("not_available", report.Statistics.NotAvailable),
+		slog.String("availability", fmt.Sprintf("%.1f%%", report.AvailabilityPercent)),
+		slog.Any("slowest", slowest),
+	)
+
+	if path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode exit report: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("write exit report: %w", err)
+	}
+
+	slog.Info("exit report written", slog.String("path", path))
+	return nil
+}
@@ -3,7 +3,9 @@ package config
 import (
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -14,29 +16,162 @@ type Config struct {
 	Server  HTTPConfig
 	Logger  LoggerConfig
 	Storage StorageConfig
+	Checker CheckerConfig
+	Report  ReportConfig
+	API     APIConfig
+	Monitor MonitorConfig
+}
+
+// MonitorConfig holds the URLs checked on an interval in the background, empty URLs disable it.
+type MonitorConfig struct {
+	URLs     []string
+	Interval time.Duration
+	// Name labels the group the monitored URLs are checked into.
+	Name string
+}
+
+// ReportConfig holds limits for report generation and the shutdown summary.
+type ReportConfig struct {
+	MaxGroups int
+	// MaxLinks bounds the total number of links in a single report.
+	MaxLinks int
+	// ExitReportPath is the file the shutdown summary is written to as JSON, empty to only log it.
+	ExitReportPath string
+	// Percentiles of available check durations shown in statistics, nil for the defaults.
+	Percentiles []float64
+}
+
+// APIConfig holds access control settings for the HTTP API.
+type APIConfig struct {
+	Username string
+	Password string
+	Keys     []string
+	MaxLinks int
+	// BrokenStatus is returned by POST /links?fail_on_broken when the batch has broken links.
+	BrokenStatus int
+
+	CORSAllowedOrigins []string
+	CORSAllowedMethods []string
+	CORSAllowedHeaders []string
+}
+
+// CheckerConfig holds configuration for URL availability checks.
+type CheckerConfig struct {
+	FollowMetaRefresh bool
+	InsecureTLS       bool
+	InsecureTLSHosts  []string
+
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+
+	// DialTimeout bounds establishing a check connection, ResponseHeaderTimeout waiting for
+	// response headers, zero means no header timeout.
+	DialTimeout           time.Duration
+	ResponseHeaderTimeout time.Duration
+
+	DefaultScheme  string
+	SchemeFallback bool
+	MaxRedirects   int
+
+	// RateLimitRetries is how many times a 429 response is retried after its Retry-After delay,
+	// zero disables retries. MaxRetryAfter is the longest delay waited for.
+	RateLimitRetries int
+	MaxRetryAfter    time.Duration
+
+	// ProbeSize enables a ranged GET for the content size when HEAD omits Content-Length.
+	ProbeSize bool
+	// LogHeaders logs the complete response headers of every check at debug level.
+	LogHeaders bool
+	// Timing records DNS, connect, TLS and time-to-first-byte durations of every check.
+	Timing bool
+	// SkipHosts holds glob patterns of hosts that must never be requested.
+	SkipHosts []string
+	// AvailableCodes and NotAvailableCodes override the default "below 400 is available" rule.
+	AvailableCodes    []int
+	NotAvailableCodes []int
+	// BasicAuth holds credentials sent to matching hosts, first match wins.
+	BasicAuth []HostCredentials
+	// BlockPrivateAddresses rejects checks of hosts resolving to private, loopback or link-local addresses.
+	BlockPrivateAddresses bool
+	// SlowThreshold flags available links checked slower than it as slow, zero disables it.
+	SlowThreshold time.Duration
+	// MaxBodyBytes caps response bodies read by checks and page crawls, zero keeps their defaults.
+	MaxBodyBytes int64
+	// CollapseWWW treats www and non-www hosts as the same for deduplication and per-host report statistics.
+	CollapseWWW bool
+
+	// UserAgent is sent with check requests, empty keeps the checker default.
+	UserAgent string
+	// Accept is sent with check requests, empty keeps the checker default.
+	Accept string
+}
+
+// HostCredentials are basic auth credentials for hosts matching a glob pattern.
+type HostCredentials struct {
+	Host     string
+	Username string
+	Password string
 }
 
 // StorageConfig holds configuration for persistence layer.
 type StorageConfig struct {
+	Backend         string
 	FileStoragePath string
+	S3              S3Config
+	// SQLitePath is the database file used when Backend is sqlite.
+	SQLitePath string
+	// MaxStoredGroups caps groups kept by in-memory storage, zero means unlimited.
+	MaxStoredGroups int
+	// GroupTTL is the age after which groups are purged by their latest check time, zero keeps them forever.
+	GroupTTL time.Duration
+	// PersistEveryN saves an in-memory storage snapshot after every N inserted groups, zero disables it.
+	PersistEveryN int
+	// CompactJSON writes snapshots without indentation to save space.
+	CompactJSON bool
+}
+
+// S3Config holds connection settings for the S3-compatible storage backend.
+type S3Config struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	Key             string
+	AccessKeyID     string
+	SecretAccessKey string
+	UseSSL          bool
 }
 
 // HTTPConfig contains HTTP server address and timeout settings.
 type HTTPConfig struct {
-	Host              string
-	Port              string
-	ReadHeaderTimeout time.Duration
-	ReadTimeout       time.Duration
-	WriteTimeout      time.Duration
-	IdleTimeout       time.Duration
-	RequestTimeout    time.Duration
-	MaxWorkersNum     int
+	Host                string
+	Port                string
+	ReadHeaderTimeout   time.Duration
+	ReadTimeout         time.Duration
+	WriteTimeout        time.Duration
+	IdleTimeout         time.Duration
+	RequestTimeout      time.Duration
+	PerCheckTimeout     time.Duration
+	MaxWorkersNum       int
+	MaxWorkersLimit     int
+	MaxConcurrentChecks int
+	// SharedWorkersNum is the size of a long-lived worker pool shared by all requests, zero disables it.
+	SharedWorkersNum int
+	// CheckChannelBuffer sizes the jobs and results channels of a check, zero keeps them unbuffered.
+	// An unset CHECK_CHANNEL_BUFFER sizes them proportionally to the workers, see defaultCheckChannelBuffer.
+	CheckChannelBuffer int
+	// IdempotencyKeyTTL is how long an Idempotency-Key of POST /links is remembered.
+	IdempotencyKeyTTL time.Duration
+	// PerHostDelay and PerHostJitter space out consecutive checks of the same host.
+	PerHostDelay  time.Duration
+	PerHostJitter time.Duration
 }
 
 // LoggerConfig describes logging level and destination file.
 type LoggerConfig struct {
 	LevelInfo string
 	LogPath   string
+	Format    string
 }
 
 const (
@@ -46,17 +181,65 @@ const (
 
 // Default values
 const (
-	defaultHost              = "localhost"
-	defaultPort              = "8080"
-	defaultReadHeaderTimeout = 5   // seconds
-	defaultReadTimeout       = 10  // seconds
-	defaultWriteTimeout      = 10  // seconds
-	defaultIdleTimeout       = 120 // seconds
-	defaultRequestTimeout    = 30  // seconds
-	defaultMaxWorkersNum     = 4
-	defaultLogLevel          = "info"
-	defaultLogPath           = "logs/app.log"
-	defaultFileStoragePath   = "storage/links.json"
+	defaultHost                = "localhost"
+	defaultPort                = "8080"
+	defaultReadHeaderTimeout   = 5   // seconds
+	defaultReadTimeout         = 10  // seconds
+	defaultWriteTimeout        = 10  // seconds
+	defaultIdleTimeout         = 120 // seconds
+	defaultRequestTimeout      = 30  // seconds
+	defaultPerCheckTimeout     = 10  // seconds
+	defaultMaxWorkersNum       = 4
+	defaultMaxWorkersLimit     = 32
+	defaultMaxConcurrentChecks = 64
+	defaultSharedWorkersNum    = 0     // workers per request
+	defaultCheckChannelBuffer  = -1    // proportional to workers
+	defaultIdempotencyKeyTTL   = 86400 // seconds
+	defaultPerHostDelay        = 0     // milliseconds, disabled
+	defaultPerHostJitter       = 0     // milliseconds, disabled
+	defaultLogLevel            = "info"
+	defaultLogPath             = "logs/app.log"
+	defaultLogFormat           = "text"
+	defaultStorageBackend      = "file"
+	defaultFileStoragePath     = "storage/links.json"
+	defaultSQLitePath          = "storage/links.db"
+	defaultMaxStoredGroups     = 0 // unlimited
+	defaultPersistEveryN       = 0 // snapshots on shutdown only
+	defaultGroupTTL            = 0 // seconds, disabled
+	defaultStorageCompactJSON  = false
+	defaultS3Key               = "links.json"
+	defaultS3UseSSL            = true
+	defaultFollowMetaRefresh   = false
+	defaultInsecureTLS         = false
+	defaultMaxIdleConns        = 100
+	defaultMaxIdleConnsPerHost = 10
+	defaultIdleConnTimeout     = 90 // seconds
+	defaultDialTimeout         = 30 // seconds
+	defaultRespHeaderTimeout   = 0  // seconds, disabled
+	defaultScheme              = "https"
+	defaultSchemeFallback      = false
+	defaultMaxRedirects        = 10
+	defaultRateLimitRetries    = 0  // disabled
+	defaultMaxRetryAfter       = 60 // seconds
+	defaultProbeSize           = false
+	defaultLogHeaders          = false
+	defaultTiming              = false
+	defaultBlockPrivate        = false
+	defaultCollapseWWW         = false
+	defaultSlowThreshold       = 0 // milliseconds, disabled
+	defaultMaxBodyBytes        = 0 // checker and crawler defaults
+	defaultReportMaxGroups     = 100
+	defaultReportMaxLinks      = 100000
+	defaultMaxLinks            = 10000
+	defaultBrokenStatus        = 422
+	defaultMonitorInterval     = 60 // seconds
+	defaultMonitorName         = "monitor"
+)
+
+// Default CORS values
+var (
+	defaultCORSAllowedMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
+	defaultCORSAllowedHeaders = []string{"Content-Type", "Accept", "Authorization", "X-API-Key", "Idempotency-Key"}
 )
 
 // MustLoad loads configuration or panics if it fails.
@@ -94,6 +277,97 @@ func getEnvInt(key string, defaultValue int) (int, error) {
 	return intValue, nil
 }
 
+// getEnvNonNegativeInt is getEnvInt for keys where zero is meaningful, e.g. disables a limit.
+func getEnvNonNegativeInt(key string, defaultValue int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	intValue, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert %s to int: %w", key, err)
+	}
+	if intValue < 0 {
+		return 0, fmt.Errorf("%s must not be negative, got: %d", key, intValue)
+	}
+	return intValue, nil
+}
+
+// getEnvBool returns environment variable value as bool or default if empty/invalid.
+func getEnvBool(key string, defaultValue bool) (bool, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	boolValue, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to convert %s to bool: %w", key, err)
+	}
+	return boolValue, nil
+}
+
+// getEnvList returns comma-separated environment variable value as a slice of trimmed, non-empty items.
+func getEnvList(key string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// getEnvStatusCodes returns a comma-separated list of HTTP status codes, nil if unset.
+func getEnvStatusCodes(key string) ([]int, error) {
+	items := getEnvList(key)
+	codes := make([]int, 0, len(items))
+	for _, item := range items {
+		code, err := strconv.Atoi(item)
+		if err != nil || code < 100 || code > 599 {
+			return nil, fmt.Errorf("%s: invalid status code %q", key, item)
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
+
+// getEnvPercentiles returns a comma-separated list of percentiles in (0, 100], nil if unset.
+func getEnvPercentiles(key string) ([]float64, error) {
+	items := getEnvList(key)
+	percentiles := make([]float64, 0, len(items))
+	for _, item := range items {
+		p, err := strconv.ParseFloat(item, 64)
+		if err != nil || !(p > 0 && p <= 100) {
+			return nil, fmt.Errorf("%s: invalid percentile %q", key, item)
+		}
+		percentiles = append(percentiles, p)
+	}
+	return percentiles, nil
+}
+
+// parseHostCredentials parses "host=user:password" entries, the password may contain colons.
+func parseHostCredentials(items []string) ([]HostCredentials, error) {
+	creds := make([]HostCredentials, 0, len(items))
+	for _, item := range items {
+		host, userinfo, ok := strings.Cut(item, "=")
+		if !ok || host == "" {
+			return nil, fmt.Errorf("entry %d: expected host=user:password", len(creds)+1)
+		}
+		username, password, ok := strings.Cut(userinfo, ":")
+		if !ok || username == "" {
+			return nil, fmt.Errorf("entry %q: expected host=user:password", host)
+		}
+		creds = append(creds, HostCredentials{Host: host, Username: username, Password: password})
+	}
+	return creds, nil
+}
+
 // validateRequired checks that required string values are not empty.
 func validateRequired(key, value string) error {
 	if value == "" {
@@ -169,19 +443,324 @@ func load() (*Config, error) {
 	}
 	cfg.Server.RequestTimeout = time.Duration(requestTimeout) * time.Second
 
+	perCheckTimeout, err := getEnvInt("PER_CHECK_TIMEOUT", defaultPerCheckTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("PER_CHECK_TIMEOUT: %w", err)
+	}
+	cfg.Server.PerCheckTimeout = time.Duration(perCheckTimeout) * time.Second
+
 	maxWorkersNum, err := getEnvInt("MAX_WORKERS_NUM", defaultMaxWorkersNum)
 	if err != nil {
 		return nil, fmt.Errorf("MAX_WORKERS_NUM: %w", err)
 	}
 	cfg.Server.MaxWorkersNum = maxWorkersNum
 
+	maxWorkersLimit, err := getEnvInt("MAX_WORKERS_LIMIT", defaultMaxWorkersLimit)
+	if err != nil {
+		return nil, fmt.Errorf("MAX_WORKERS_LIMIT: %w", err)
+	}
+	cfg.Server.MaxWorkersLimit = maxWorkersLimit
+
+	maxConcurrentChecks, err := getEnvNonNegativeInt("MAX_CONCURRENT_CHECKS", defaultMaxConcurrentChecks)
+	if err != nil {
+		return nil, fmt.Errorf("MAX_CONCURRENT_CHECKS: %w", err)
+	}
+	cfg.Server.MaxConcurrentChecks = maxConcurrentChecks
+
+	sharedWorkersNum, err := getEnvNonNegativeInt("SHARED_WORKERS_NUM", defaultSharedWorkersNum)
+	if err != nil {
+		return nil, fmt.Errorf("SHARED_WORKERS_NUM: %w", err)
+	}
+	cfg.Server.SharedWorkersNum = sharedWorkersNum
+
+	checkChannelBuffer, err := getEnvNonNegativeInt("CHECK_CHANNEL_BUFFER", defaultCheckChannelBuffer)
+	if err != nil {
+		return nil, fmt.Errorf("CHECK_CHANNEL_BUFFER: %w", err)
+	}
+	cfg.Server.CheckChannelBuffer = checkChannelBuffer
+
+	idempotencyKeyTTL, err := getEnvNonNegativeInt("IDEMPOTENCY_KEY_TTL", defaultIdempotencyKeyTTL)
+	if err != nil {
+		return nil, fmt.Errorf("IDEMPOTENCY_KEY_TTL: %w", err)
+	}
+	cfg.Server.IdempotencyKeyTTL = time.Duration(idempotencyKeyTTL) * time.Second
+
+	perHostDelay, err := getEnvNonNegativeInt("PER_HOST_DELAY", defaultPerHostDelay)
+	if err != nil {
+		return nil, fmt.Errorf("PER_HOST_DELAY: %w", err)
+	}
+	cfg.Server.PerHostDelay = time.Duration(perHostDelay) * time.Millisecond
+
+	perHostJitter, err := getEnvNonNegativeInt("PER_HOST_JITTER", defaultPerHostJitter)
+	if err != nil {
+		return nil, fmt.Errorf("PER_HOST_JITTER: %w", err)
+	}
+	cfg.Server.PerHostJitter = time.Duration(perHostJitter) * time.Millisecond
+
 	// Logger load with defaults
 	cfg.Logger.LevelInfo = getEnvString("LEVEL_INFO", defaultLogLevel)
 	cfg.Logger.LogPath = getEnvString("LOGGING_PATH", defaultLogPath)
+	cfg.Logger.Format = getEnvString("LOG_FORMAT", defaultLogFormat)
+	if cfg.Logger.Format != "text" && cfg.Logger.Format != "json" {
+		return nil, fmt.Errorf("LOG_FORMAT must be text or json, got: %s", cfg.Logger.Format)
+	}
 
 	// Storage load with default
+	cfg.Storage.Backend = getEnvString("STORAGE_BACKEND", defaultStorageBackend)
 	cfg.Storage.FileStoragePath = getEnvString("FILE_STORAGE_PATH", defaultFileStoragePath)
 
+	maxStoredGroups, err := getEnvNonNegativeInt("MAX_STORED_GROUPS", defaultMaxStoredGroups)
+	if err != nil {
+		return nil, fmt.Errorf("MAX_STORED_GROUPS: %w", err)
+	}
+	cfg.Storage.MaxStoredGroups = maxStoredGroups
+
+	groupTTL, err := getEnvNonNegativeInt("GROUP_TTL", defaultGroupTTL)
+	if err != nil {
+		return nil, fmt.Errorf("GROUP_TTL: %w", err)
+	}
+	cfg.Storage.GroupTTL = time.Duration(groupTTL) * time.Second
+
+	persistEveryN, err := getEnvNonNegativeInt("PERSIST_EVERY_N", defaultPersistEveryN)
+	if err != nil {
+		return nil, fmt.Errorf("PERSIST_EVERY_N: %w", err)
+	}
+	cfg.Storage.PersistEveryN = persistEveryN
+
+	compactJSON, err := getEnvBool("STORAGE_COMPACT_JSON", defaultStorageCompactJSON)
+	if err != nil {
+		return nil, fmt.Errorf("STORAGE_COMPACT_JSON: %w", err)
+	}
+	cfg.Storage.CompactJSON = compactJSON
+
+	switch cfg.Storage.Backend {
+	case "file":
+	case "sqlite":
+		cfg.Storage.SQLitePath = getEnvString("SQLITE_PATH", defaultSQLitePath)
+	case "s3":
+		cfg.Storage.S3.Endpoint = os.Getenv("S3_ENDPOINT")
+		if err := validateRequired("S3_ENDPOINT", cfg.Storage.S3.Endpoint); err != nil {
+			return nil, err
+		}
+		cfg.Storage.S3.Bucket = os.Getenv("S3_BUCKET")
+		if err := validateRequired("S3_BUCKET", cfg.Storage.S3.Bucket); err != nil {
+			return nil, err
+		}
+		cfg.Storage.S3.Region = os.Getenv("S3_REGION")
+		cfg.Storage.S3.Key = getEnvString("S3_KEY", defaultS3Key)
+		cfg.Storage.S3.AccessKeyID = os.Getenv("S3_ACCESS_KEY_ID")
+		cfg.Storage.S3.SecretAccessKey = os.Getenv("S3_SECRET_ACCESS_KEY")
+
+		useSSL, err := getEnvBool("S3_USE_SSL", defaultS3UseSSL)
+		if err != nil {
+			return nil, fmt.Errorf("S3_USE_SSL: %w", err)
+		}
+		cfg.Storage.S3.UseSSL = useSSL
+	default:
+		return nil, fmt.Errorf("STORAGE_BACKEND must be file, s3 or sqlite, got: %s", cfg.Storage.Backend)
+	}
+
+	// Checker load with defaults
+	followMetaRefresh, err := getEnvBool("CHECKER_FOLLOW_META_REFRESH", defaultFollowMetaRefresh)
+	if err != nil {
+		return nil, fmt.Errorf("CHECKER_FOLLOW_META_REFRESH: %w", err)
+	}
+	cfg.Checker.FollowMetaRefresh = followMetaRefresh
+
+	insecureTLS, err := getEnvBool("CHECKER_INSECURE_TLS", defaultInsecureTLS)
+	if err != nil {
+		return nil, fmt.Errorf("CHECKER_INSECURE_TLS: %w", err)
+	}
+	cfg.Checker.InsecureTLS = insecureTLS
+	cfg.Checker.InsecureTLSHosts = getEnvList("CHECKER_INSECURE_TLS_HOSTS")
+
+	maxIdleConns, err := getEnvInt("CHECKER_MAX_IDLE_CONNS", defaultMaxIdleConns)
+	if err != nil {
+		return nil, fmt.Errorf("CHECKER_MAX_IDLE_CONNS: %w", err)
+	}
+	cfg.Checker.MaxIdleConns = maxIdleConns
+
+	maxIdleConnsPerHost, err := getEnvInt("CHECKER_MAX_IDLE_CONNS_PER_HOST", defaultMaxIdleConnsPerHost)
+	if err != nil {
+		return nil, fmt.Errorf("CHECKER_MAX_IDLE_CONNS_PER_HOST: %w", err)
+	}
+	cfg.Checker.MaxIdleConnsPerHost = maxIdleConnsPerHost
+
+	idleConnTimeout, err := getEnvInt("CHECKER_IDLE_CONN_TIMEOUT", defaultIdleConnTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("CHECKER_IDLE_CONN_TIMEOUT: %w", err)
+	}
+	cfg.Checker.IdleConnTimeout = time.Duration(idleConnTimeout) * time.Second
+
+	dialTimeout, err := getEnvInt("DIAL_TIMEOUT", defaultDialTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("DIAL_TIMEOUT: %w", err)
+	}
+	cfg.Checker.DialTimeout = time.Duration(dialTimeout) * time.Second
+
+	responseHeaderTimeout, err := getEnvNonNegativeInt("RESPONSE_HEADER_TIMEOUT", defaultRespHeaderTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("RESPONSE_HEADER_TIMEOUT: %w", err)
+	}
+	cfg.Checker.ResponseHeaderTimeout = time.Duration(responseHeaderTimeout) * time.Second
+
+	cfg.Checker.DefaultScheme = getEnvString("CHECKER_DEFAULT_SCHEME", defaultScheme)
+	if cfg.Checker.DefaultScheme != "http" && cfg.Checker.DefaultScheme != "https" {
+		return nil, fmt.Errorf("CHECKER_DEFAULT_SCHEME must be http or https, got: %s", cfg.Checker.DefaultScheme)
+	}
+
+	schemeFallback, err := getEnvBool("CHECKER_SCHEME_FALLBACK", defaultSchemeFallback)
+	if err != nil {
+		return nil, fmt.Errorf("CHECKER_SCHEME_FALLBACK: %w", err)
+	}
+	cfg.Checker.SchemeFallback = schemeFallback
+
+	maxRedirects, err := getEnvNonNegativeInt("CHECKER_MAX_REDIRECTS", defaultMaxRedirects)
+	if err != nil {
+		return nil, fmt.Errorf("CHECKER_MAX_REDIRECTS: %w", err)
+	}
+	cfg.Checker.MaxRedirects = maxRedirects
+
+	rateLimitRetries, err := getEnvNonNegativeInt("CHECKER_RATE_LIMIT_RETRIES", defaultRateLimitRetries)
+	if err != nil {
+		return nil, fmt.Errorf("CHECKER_RATE_LIMIT_RETRIES: %w", err)
+	}
+	cfg.Checker.RateLimitRetries = rateLimitRetries
+
+	maxRetryAfter, err := getEnvInt("CHECKER_MAX_RETRY_AFTER", defaultMaxRetryAfter)
+	if err != nil {
+		return nil, fmt.Errorf("CHECKER_MAX_RETRY_AFTER: %w", err)
+	}
+	cfg.Checker.MaxRetryAfter = time.Duration(maxRetryAfter) * time.Second
+
+	probeSize, err := getEnvBool("CHECKER_PROBE_SIZE", defaultProbeSize)
+	if err != nil {
+		return nil, fmt.Errorf("CHECKER_PROBE_SIZE: %w", err)
+	}
+	cfg.Checker.ProbeSize = probeSize
+
+	logHeaders, err := getEnvBool("CHECKER_LOG_HEADERS", defaultLogHeaders)
+	if err != nil {
+		return nil, fmt.Errorf("CHECKER_LOG_HEADERS: %w", err)
+	}
+	cfg.Checker.LogHeaders = logHeaders
+
+	timing, err := getEnvBool("CHECKER_TIMING", defaultTiming)
+	if err != nil {
+		return nil, fmt.Errorf("CHECKER_TIMING: %w", err)
+	}
+	cfg.Checker.Timing = timing
+	cfg.Checker.SkipHosts = getEnvList("CHECKER_SKIP_HOSTS")
+
+	availableCodes, err := getEnvStatusCodes("CHECKER_AVAILABLE_CODES")
+	if err != nil {
+		return nil, err
+	}
+	notAvailableCodes, err := getEnvStatusCodes("CHECKER_NOT_AVAILABLE_CODES")
+	if err != nil {
+		return nil, err
+	}
+	for _, code := range availableCodes {
+		if slices.Contains(notAvailableCodes, code) {
+			return nil, fmt.Errorf("status code %d is in both CHECKER_AVAILABLE_CODES and CHECKER_NOT_AVAILABLE_CODES", code)
+		}
+	}
+	cfg.Checker.AvailableCodes = availableCodes
+	cfg.Checker.NotAvailableCodes = notAvailableCodes
+
+	basicAuth, err := parseHostCredentials(getEnvList("CHECKER_BASIC_AUTH"))
+	if err != nil {
+		return nil, fmt.Errorf("CHECKER_BASIC_AUTH: %w", err)
+	}
+	cfg.Checker.BasicAuth = basicAuth
+
+	collapseWWW, err := getEnvBool("CHECKER_COLLAPSE_WWW", defaultCollapseWWW)
+	if err != nil {
+		return nil, fmt.Errorf("CHECKER_COLLAPSE_WWW: %w", err)
+	}
+	cfg.Checker.CollapseWWW = collapseWWW
+
+	blockPrivate, err := getEnvBool("CHECKER_BLOCK_PRIVATE_ADDRESSES", defaultBlockPrivate)
+	if err != nil {
+		return nil, fmt.Errorf("CHECKER_BLOCK_PRIVATE_ADDRESSES: %w", err)
+	}
+	cfg.Checker.BlockPrivateAddresses = blockPrivate
+
+	slowThreshold, err := getEnvNonNegativeInt("CHECKER_SLOW_THRESHOLD", defaultSlowThreshold)
+	if err != nil {
+		return nil, fmt.Errorf("CHECKER_SLOW_THRESHOLD: %w", err)
+	}
+	cfg.Checker.SlowThreshold = time.Duration(slowThreshold) * time.Millisecond
+
+	maxBodyBytes, err := getEnvNonNegativeInt("MAX_BODY_BYTES", defaultMaxBodyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("MAX_BODY_BYTES: %w", err)
+	}
+	cfg.Checker.MaxBodyBytes = int64(maxBodyBytes)
+	cfg.Checker.UserAgent = os.Getenv("USER_AGENT")
+	cfg.Checker.Accept = os.Getenv("CHECKER_ACCEPT")
+
+	// Report load with defaults
+	reportMaxGroups, err := getEnvNonNegativeInt("REPORT_MAX_GROUPS", defaultReportMaxGroups)
+	if err != nil {
+		return nil, fmt.Errorf("REPORT_MAX_GROUPS: %w", err)
+	}
+	cfg.Report.MaxGroups = reportMaxGroups
+	reportMaxLinks, err := getEnvNonNegativeInt("REPORT_MAX_LINKS", defaultReportMaxLinks)
+	if err != nil {
+		return nil, fmt.Errorf("REPORT_MAX_LINKS: %w", err)
+	}
+	cfg.Report.MaxLinks = reportMaxLinks
+	cfg.Report.ExitReportPath = os.Getenv("EXIT_REPORT_PATH")
+	percentiles, err := getEnvPercentiles("REPORT_PERCENTILES")
+	if err != nil {
+		return nil, err
+	}
+	cfg.Report.Percentiles = percentiles
+
+	// API auth load, disabled when unset
+	cfg.API.Username = os.Getenv("API_USERNAME")
+	cfg.API.Password = os.Getenv("API_PASSWORD")
+	if (cfg.API.Username == "") != (cfg.API.Password == "") {
+		return nil, fmt.Errorf("API_USERNAME and API_PASSWORD must be set together")
+	}
+	cfg.API.Keys = getEnvList("API_KEYS")
+
+	maxLinks, err := getEnvInt("MAX_LINKS_PER_REQUEST", defaultMaxLinks)
+	if err != nil {
+		return nil, fmt.Errorf("MAX_LINKS_PER_REQUEST: %w", err)
+	}
+	cfg.API.MaxLinks = maxLinks
+
+	brokenStatus, err := getEnvInt("FAIL_ON_BROKEN_STATUS", defaultBrokenStatus)
+	if err != nil {
+		return nil, fmt.Errorf("FAIL_ON_BROKEN_STATUS: %w", err)
+	}
+	if brokenStatus < 400 || brokenStatus > 599 {
+		return nil, fmt.Errorf("FAIL_ON_BROKEN_STATUS must be a 4xx or 5xx status, got: %d", brokenStatus)
+	}
+	cfg.API.BrokenStatus = brokenStatus
+
+	// Monitor load, disabled when no URLs are set
+	cfg.Monitor.URLs = getEnvList("MONITOR_URLS")
+	monitorInterval, err := getEnvInt("MONITOR_INTERVAL", defaultMonitorInterval)
+	if err != nil {
+		return nil, fmt.Errorf("MONITOR_INTERVAL: %w", err)
+	}
+	cfg.Monitor.Interval = time.Duration(monitorInterval) * time.Second
+	cfg.Monitor.Name = getEnvString("MONITOR_NAME", defaultMonitorName)
+
+	// CORS load, disabled when no origins are allowed
+	cfg.API.CORSAllowedOrigins = getEnvList("CORS_ALLOWED_ORIGINS")
+	cfg.API.CORSAllowedMethods = getEnvList("CORS_ALLOWED_METHODS")
+	if len(cfg.API.CORSAllowedMethods) == 0 {
+		cfg.API.CORSAllowedMethods = defaultCORSAllowedMethods
+	}
+	cfg.API.CORSAllowedHeaders = getEnvList("CORS_ALLOWED_HEADERS")
+	if len(cfg.API.CORSAllowedHeaders) == 0 {
+		cfg.API.CORSAllowedHeaders = defaultCORSAllowedHeaders
+	}
+
 	return &cfg, nil
 }
 
@@ -0,0 +1,169 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// setConfigFile points CONFIG_PATH at an empty config file, so load reads the environment only.
+func setConfigFile(t *testing.T) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.env")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatalf("write config file: %v", err)
+	}
+	t.Setenv(Path, path)
+}
+
+func TestLoad_Limits(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		check   func(cfg *Config) bool
+		wantErr bool
+	}{
+		{
+			name:  "concurrency capped by default",
+			check: func(cfg *Config) bool { return cfg.Server.MaxConcurrentChecks == defaultMaxConcurrentChecks },
+		},
+		{
+			name:  "concurrency cap",
+			env:   map[string]string{"MAX_CONCURRENT_CHECKS": "16"},
+			check: func(cfg *Config) bool { return cfg.Server.MaxConcurrentChecks == 16 },
+		},
+		{
+			name:  "zero disables the concurrency cap",
+			env:   map[string]string{"MAX_CONCURRENT_CHECKS": "0"},
+			check: func(cfg *Config) bool { return cfg.Server.MaxConcurrentChecks == 0 },
+		},
+		{
+			name:    "negative concurrency cap",
+			env:     map[string]string{"MAX_CONCURRENT_CHECKS": "-1"},
+			wantErr: true,
+		},
+		{
+			name:  "channel buffer proportional when unset",
+			check: func(cfg *Config) bool { return cfg.Server.CheckChannelBuffer == defaultCheckChannelBuffer },
+		},
+		{
+			name:  "zero channel buffer keeps channels unbuffered",
+			env:   map[string]string{"CHECK_CHANNEL_BUFFER": "0"},
+			check: func(cfg *Config) bool { return cfg.Server.CheckChannelBuffer == 0 },
+		},
+		{
+			name:    "negative channel buffer",
+			env:     map[string]string{"CHECK_CHANNEL_BUFFER": "-1"},
+			wantErr: true,
+		},
+		{
+			name:  "zero redirects",
+			env:   map[string]string{"CHECKER_MAX_REDIRECTS": "0"},
+			check: func(cfg *Config) bool { return cfg.Checker.MaxRedirects == 0 },
+		},
+		{
+			name:    "negative redirects",
+			env:     map[string]string{"CHECKER_MAX_REDIRECTS": "-1"},
+			wantErr: true,
+		},
+		{
+			name:  "zero disables idempotency keys",
+			env:   map[string]string{"IDEMPOTENCY_KEY_TTL": "0"},
+			check: func(cfg *Config) bool { return cfg.Server.IdempotencyKeyTTL == 0 },
+		},
+		{
+			name: "zero per-host delay and jitter",
+			env:  map[string]string{"PER_HOST_DELAY": "0", "PER_HOST_JITTER": "0"},
+			check: func(cfg *Config) bool {
+				return cfg.Server.PerHostDelay == 0 && cfg.Server.PerHostJitter == 0
+			},
+		},
+		{
+			name: "per-host delay in milliseconds",
+			env:  map[string]string{"PER_HOST_DELAY": "250", "PER_HOST_JITTER": "50"},
+			check: func(cfg *Config) bool {
+				return cfg.Server.PerHostDelay == 250*time.Millisecond && cfg.Server.PerHostJitter == 50*time.Millisecond
+			},
+		},
+		{
+			name:  "zero disables the report links cap",
+			env:   map[string]string{"REPORT_MAX_LINKS": "0"},
+			check: func(cfg *Config) bool { return cfg.Report.MaxLinks == 0 },
+		},
+		{
+			name:  "zero disables the report groups cap",
+			env:   map[string]string{"REPORT_MAX_GROUPS": "0"},
+			check: func(cfg *Config) bool { return cfg.Report.MaxGroups == 0 },
+		},
+		{
+			name:  "zero disables the shared pool",
+			env:   map[string]string{"SHARED_WORKERS_NUM": "0"},
+			check: func(cfg *Config) bool { return cfg.Server.SharedWorkersNum == 0 },
+		},
+		{
+			name:  "zero stores unlimited groups",
+			env:   map[string]string{"MAX_STORED_GROUPS": "0"},
+			check: func(cfg *Config) bool { return cfg.Storage.MaxStoredGroups == 0 },
+		},
+		{
+			name:  "zero disables group expiry",
+			env:   map[string]string{"GROUP_TTL": "0"},
+			check: func(cfg *Config) bool { return cfg.Storage.GroupTTL == 0 },
+		},
+		{
+			name:  "zero persists on shutdown only",
+			env:   map[string]string{"PERSIST_EVERY_N": "0"},
+			check: func(cfg *Config) bool { return cfg.Storage.PersistEveryN == 0 },
+		},
+		{
+			name:  "zero disables the response header timeout",
+			env:   map[string]string{"RESPONSE_HEADER_TIMEOUT": "0"},
+			check: func(cfg *Config) bool { return cfg.Checker.ResponseHeaderTimeout == 0 },
+		},
+		{
+			name:  "zero disables rate limit retries",
+			env:   map[string]string{"CHECKER_RATE_LIMIT_RETRIES": "0"},
+			check: func(cfg *Config) bool { return cfg.Checker.RateLimitRetries == 0 },
+		},
+		{
+			name:  "zero disables slow links",
+			env:   map[string]string{"CHECKER_SLOW_THRESHOLD": "0"},
+			check: func(cfg *Config) bool { return cfg.Checker.SlowThreshold == 0 },
+		},
+		{
+			name:  "zero keeps default body caps",
+			env:   map[string]string{"MAX_BODY_BYTES": "0"},
+			check: func(cfg *Config) bool { return cfg.Checker.MaxBodyBytes == 0 },
+		},
+		{
+			name:    "zero workers",
+			env:     map[string]string{"MAX_WORKERS_NUM": "0"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setConfigFile(t)
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, err := load()
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("load() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("load() error = %v, want nil", err)
+			}
+			if !tt.check(cfg) {
+				t.Errorf("load() with %v = %+v", tt.env, cfg)
+			}
+		})
+	}
+}
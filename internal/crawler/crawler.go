@@ -0,0 +1,151 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// defaultMaxPageSize limits the size of a downloaded HTML page unless set with WithMaxBodySize.
+const defaultMaxPageSize = 5 << 20 // 5 MB
+
+// Extractor downloads HTML pages and extracts links from their anchors.
+type Extractor struct {
+	client      *http.Client
+	maxPageSize int64
+}
+
+// Option configures an Extractor.
+type Option func(*Extractor)
+
+// WithTransport makes the Extractor download pages through rt, e.g. the transport of URL checks
+// so that the same TLS and address restrictions apply.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(e *Extractor) {
+		e.client.Transport = rt
+	}
+}
+
+// WithMaxBodySize caps how many bytes of a page are read, links past the limit are not extracted.
+// Non-positive values keep the default of 5 MB.
+func WithMaxBodySize(n int64) Option {
+	return func(e *Extractor) {
+		if n > 0 {
+			e.maxPageSize = n
+		}
+	}
+}
+
+// NewExtractor creates a new Extractor with a default HTTP client and the given options.
+func NewExtractor(opts ...Option) *Extractor {
+	e := &Extractor{
+		client:      &http.Client{},
+		maxPageSize: defaultMaxPageSize,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// ExtractLinks fetches the page at pageURL and returns absolute, deduplicated
+// URLs of all <a href> elements. mailto:, tel: and fragment-only links are skipped.
+func (e *Extractor) ExtractLinks(ctx context.Context, pageURL string) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("create page request: %w", err)
+	}
+	req.Header.Set("User-Agent", "WebStatusChecker/1.0")
+	req.Header.Set("Accept", "text/html, */*")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch page: unexpected status code %d", resp.StatusCode)
+	}
+
+	// Relative links are resolved against the final URL after redirects
+	base := resp.Request.URL
+
+	doc, err := html.Parse(io.LimitReader(resp.Body, e.maxPageSize))
+	if err != nil {
+		return nil, fmt.Errorf("parse page: %w", err)
+	}
+
+	hrefs := make([]string, 0)
+	collectHrefs(doc, &hrefs)
+
+	seen := make(map[string]struct{}, len(hrefs))
+	links := make([]string, 0, len(hrefs))
+	for _, href := range hrefs {
+		link, ok := resolveLink(base, href)
+		if !ok {
+			continue
+		}
+		if _, dup := seen[link]; dup {
+			continue
+		}
+		seen[link] = struct{}{}
+		links = append(links, link)
+	}
+
+	slog.DebugContext(ctx, "extracted links from page",
+		slog.String("url", pageURL),
+		slog.Int("anchors", len(hrefs)),
+		slog.Int("links_count", len(links)),
+	)
+
+	return links, nil
+}
+
+// collectHrefs walks the HTML tree and appends href values of all anchors.
+func collectHrefs(n *html.Node, hrefs *[]string) {
+	if n.Type == html.ElementNode && n.Data == "a" {
+		for _, attr := range n.Attr {
+			if attr.Key == "href" {
+				*hrefs = append(*hrefs, strings.TrimSpace(attr.Val))
+				break
+			}
+		}
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		collectHrefs(c, hrefs)
+	}
+}
+
+// resolveLink converts href into an absolute http(s) URL without fragment.
+// Returns false for links that should not be checked.
+func resolveLink(base *url.URL, href string) (string, bool) {
+	if href == "" || strings.HasPrefix(href, "#") {
+		return "", false
+	}
+
+	lower := strings.ToLower(href)
+	if strings.HasPrefix(lower, "mailto:") || strings.HasPrefix(lower, "tel:") {
+		return "", false
+	}
+
+	ref, err := url.Parse(href)
+	if err != nil {
+		return "", false
+	}
+
+	abs := base.ResolveReference(ref)
+	if abs.Scheme != "http" && abs.Scheme != "https" {
+		return "", false
+	}
+	abs.Fragment = ""
+
+	return abs.String(), true
+}
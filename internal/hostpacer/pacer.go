@@ -0,0 +1,91 @@
+package hostpacer
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// Pacer spaces out requests to the same host by a fixed delay plus random jitter.
+// Requests to different hosts are paced independently.
+type Pacer struct {
+	delay  time.Duration
+	jitter time.Duration
+	// next holds the earliest time the next request to each host may start.
+	next map[string]time.Time
+	mtx  sync.Mutex
+	// now and jitterFn are replaced in tests.
+	now      func() time.Time
+	jitterFn func(n time.Duration) time.Duration
+}
+
+// New creates a Pacer keeping at least delay plus up to jitter between requests to a host.
+// It returns nil if both are zero, a nil Pacer never waits.
+func New(delay, jitter time.Duration) *Pacer {
+	if delay <= 0 && jitter <= 0 {
+		return nil
+	}
+	return &Pacer{
+		delay:    max(delay, 0),
+		jitter:   max(jitter, 0),
+		next:     make(map[string]time.Time),
+		now:      time.Now,
+		jitterFn: randomJitter,
+	}
+}
+
+// Wait reserves the next request slot for host and blocks until it starts.
+// It returns ctx.Err() if ctx is done first, the reserved slot is then left unused.
+func (p *Pacer) Wait(ctx context.Context, host string) error {
+	if p == nil || host == "" {
+		return nil
+	}
+
+	wait := p.reserve(host)
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve books the earliest free slot of host and returns how long to wait for it.
+func (p *Pacer) reserve(host string) time.Duration {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	now := p.now()
+	slot := now
+	if next, ok := p.next[host]; ok && next.After(now) {
+		slot = next
+	}
+
+	// forget hosts that have been idle long enough, keeping the map small
+	for h, next := range p.next {
+		if !next.After(now) {
+			delete(p.next, h)
+		}
+	}
+
+	gap := p.delay
+	if p.jitter > 0 {
+		gap += p.jitterFn(p.jitter)
+	}
+	p.next[host] = slot.Add(gap)
+
+	return slot.Sub(now)
+}
+
+// randomJitter returns a random duration in [0, n).
+func randomJitter(n time.Duration) time.Duration {
+	return rand.N(n)
+}
@@ -0,0 +1,66 @@
+package hostpacer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPacer_Wait(t *testing.T) {
+	t.Run("spaces requests to the same host", func(t *testing.T) {
+		now := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+		p := New(100*time.Millisecond, 50*time.Millisecond)
+		p.now = func() time.Time { return now }
+		p.jitterFn = func(time.Duration) time.Duration { return 20 * time.Millisecond }
+
+		waits := []time.Duration{p.reserve("example.com"), p.reserve("example.com"), p.reserve("example.com")}
+		want := []time.Duration{0, 120 * time.Millisecond, 240 * time.Millisecond}
+		for i := range want {
+			if waits[i] != want[i] {
+				t.Errorf("reserve() #%d = %v, want %v", i+1, waits[i], want[i])
+			}
+		}
+	})
+
+	t.Run("does not delay other hosts", func(t *testing.T) {
+		p := New(time.Hour, 0)
+
+		if err := p.Wait(context.Background(), "a.example.com"); err != nil {
+			t.Fatalf("Wait() error = %v, want nil", err)
+		}
+
+		done := make(chan error, 1)
+		go func() { done <- p.Wait(context.Background(), "b.example.com") }()
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("Wait() error = %v, want nil", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Wait() for another host blocked")
+		}
+	})
+
+	t.Run("returns when context is done", func(t *testing.T) {
+		p := New(time.Hour, 0)
+		_ = p.Wait(context.Background(), "example.com")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		if err := p.Wait(ctx, "example.com"); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Wait() error = %v, want %v", err, context.DeadlineExceeded)
+		}
+	})
+
+	t.Run("nil pacer never waits", func(t *testing.T) {
+		p := New(0, 0)
+		if p != nil {
+			t.Fatalf("New(0, 0) = %v, want nil", p)
+		}
+		if err := p.Wait(context.Background(), "example.com"); err != nil {
+			t.Errorf("Wait() error = %v, want nil", err)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package idempotency
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Store remembers which link group was created for an idempotency key, for ttl.
+type Store struct {
+	ttl     time.Duration
+	entries map[string]entry
+	mtx     sync.Mutex
+	// inflight holds a channel per key of a running check, closed by its release.
+	inflight map[string]chan struct{}
+	// now is replaced in tests.
+	now func() time.Time
+}
+
+type entry struct {
+	linksNum  int
+	expiresAt time.Time
+}
+
+// NewStore creates an empty Store keeping keys for ttl.
+func NewStore(ttl time.Duration) *Store {
+	return &Store{
+		ttl:      ttl,
+		entries:  make(map[string]entry),
+		inflight: make(map[string]chan struct{}),
+		now:      time.Now,
+	}
+}
+
+// Acquire marks key as in flight until release is called. If another check holds key,
+// it waits for that check to release it, so the caller sees its stored group with Get.
+// It returns ctx.Err() if ctx is done first. With ttl of zero keys are not remembered
+// and Acquire returns at once.
+func (s *Store) Acquire(ctx context.Context, key string) (release func(), err error) {
+	if s.ttl <= 0 {
+		return func() {}, nil
+	}
+
+	s.mtx.Lock()
+	for {
+		busy, ok := s.inflight[key]
+		if !ok {
+			break
+		}
+		s.mtx.Unlock()
+
+		select {
+		case <-busy:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		s.mtx.Lock()
+	}
+	done := make(chan struct{})
+	s.inflight[key] = done
+	s.mtx.Unlock()
+
+	return func() {
+		s.mtx.Lock()
+		defer s.mtx.Unlock()
+
+		delete(s.inflight, key)
+		close(done)
+	}, nil
+}
+
+// Get returns the group number stored for key, if the key was seen within ttl.
+func (s *Store) Get(key string) (int, bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	e, ok := s.entries[key]
+	if !ok {
+		return 0, false
+	}
+	if !s.now().Before(e.expiresAt) {
+		delete(s.entries, key)
+		return 0, false
+	}
+	return e.linksNum, true
+}
+
+// Put remembers linksNum as the result of key and drops expired keys.
+func (s *Store) Put(key string, linksNum int) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	now := s.now()
+	for k, e := range s.entries {
+		if !now.Before(e.expiresAt) {
+			delete(s.entries, k)
+		}
+	}
+
+	s.entries[key] = entry{linksNum: linksNum, expiresAt: now.Add(s.ttl)}
+
+	slog.Debug("idempotency key stored",
+		slog.String("key", key),
+		slog.Int("links_num", linksNum),
+		slog.Int("keys", len(s.entries)),
+	)
+}
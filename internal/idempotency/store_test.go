@@ -0,0 +1,96 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStore(t *testing.T) {
+	t.Run("get returns stored group within ttl", func(t *testing.T) {
+		store := NewStore(time.Hour)
+		store.Put("key-1", 7)
+
+		num, ok := store.Get("key-1")
+		if !ok || num != 7 {
+			t.Errorf("Get() = %d, %v, want 7, true", num, ok)
+		}
+		if _, ok := store.Get("key-2"); ok {
+			t.Error("Get() of unknown key ok = true, want false")
+		}
+	})
+
+	t.Run("keys expire after ttl", func(t *testing.T) {
+		now := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+		store := NewStore(time.Hour)
+		store.now = func() time.Time { return now }
+
+		store.Put("old", 1)
+		now = now.Add(time.Hour)
+
+		if _, ok := store.Get("old"); ok {
+			t.Error("Get() of expired key ok = true, want false")
+		}
+
+		store.Put("old-again", 2)
+		now = now.Add(time.Hour)
+		store.Put("new", 3)
+		if len(store.entries) != 1 {
+			t.Errorf("Put() kept %d keys, want expired keys dropped", len(store.entries))
+		}
+	})
+
+	t.Run("acquire waits for the holder of the key", func(t *testing.T) {
+		store := NewStore(time.Hour)
+		release, err := store.Acquire(context.Background(), "key-1")
+		if err != nil {
+			t.Fatalf("Acquire() error = %v, want nil", err)
+		}
+
+		acquired := make(chan struct{})
+		go func() {
+			defer close(acquired)
+			second, err := store.Acquire(context.Background(), "key-1")
+			if err != nil {
+				t.Errorf("second Acquire() error = %v, want nil", err)
+				return
+			}
+			second()
+		}()
+
+		other, err := store.Acquire(context.Background(), "key-2")
+		if err != nil {
+			t.Fatalf("Acquire() of other key error = %v, want nil", err)
+		}
+		other()
+
+		select {
+		case <-acquired:
+			t.Fatal("second Acquire() returned while the key was held")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		release()
+		select {
+		case <-acquired:
+		case <-time.After(time.Second):
+			t.Fatal("second Acquire() did not return after release")
+		}
+	})
+
+	t.Run("acquire gives up when ctx is done", func(t *testing.T) {
+		store := NewStore(time.Hour)
+		release, err := store.Acquire(context.Background(), "key-1")
+		if err != nil {
+			t.Fatalf("Acquire() error = %v, want nil", err)
+		}
+		defer release()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if _, err := store.Acquire(ctx, "key-1"); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Acquire() error = %v, want %v", err, context.DeadlineExceeded)
+		}
+	})
+}
@@ -0,0 +1,146 @@
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+// Store keeps state of asynchronous check jobs in memory.
+type Store struct {
+	jobs map[string]*entry
+	mtx  sync.RWMutex
+}
+
+// entry is a stored job together with the means to stop it and wait for it.
+type entry struct {
+	job models.Job
+	// cancel stops the job, it is dropped once the job is finished.
+	cancel context.CancelFunc
+	// done is closed once the job is finished.
+	done chan struct{}
+}
+
+// NewStore creates an empty job Store.
+func NewStore() *Store {
+	return &Store{
+		jobs: make(map[string]*entry),
+	}
+}
+
+// Create registers a new pending job for total links and returns its snapshot.
+// cancel is called by Cancel to stop the job, it may be nil for jobs that cannot be stopped.
+func (s *Store) Create(total int, cancel context.CancelFunc) (models.Job, error) {
+	id, err := newID()
+	if err != nil {
+		return models.Job{}, fmt.Errorf("generate job id: %w", err)
+	}
+
+	e := &entry{
+		job: models.Job{
+			ID:        id,
+			Status:    models.JobStatusPending,
+			Total:     total,
+			CreatedAt: time.Now(),
+		},
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	s.mtx.Lock()
+	s.jobs[id] = e
+	s.mtx.Unlock()
+
+	slog.Debug("job created", slog.String("job_id", id), slog.Int("total", total))
+
+	return e.job, nil
+}
+
+// Get returns a snapshot of the job with the given id.
+func (s *Store) Get(id string) (models.Job, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	e, ok := s.jobs[id]
+	if !ok {
+		return models.Job{}, models.ErrJobNotFound
+	}
+	return e.job, nil
+}
+
+// Update applies fn to the job with the given id under the store lock.
+// Once fn leaves the job in a final status, the job is finished: Wait returns and Cancel fails.
+func (s *Store) Update(id string, fn func(job *models.Job)) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	e, ok := s.jobs[id]
+	if !ok {
+		return models.ErrJobNotFound
+	}
+	if e.job.Status.IsFinal() {
+		return nil
+	}
+	fn(&e.job)
+	if e.job.Status.IsFinal() {
+		e.cancel = nil
+		close(e.done)
+	}
+	return nil
+}
+
+// Cancel stops the job with the given id. The job stays in its current status until
+// it records being stopped through Update.
+// It returns models.ErrJobFinished if the job is already finished.
+func (s *Store) Cancel(id string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	e, ok := s.jobs[id]
+	if !ok {
+		return models.ErrJobNotFound
+	}
+	if e.job.Status.IsFinal() {
+		return models.ErrJobFinished
+	}
+	if e.cancel != nil {
+		e.cancel()
+	}
+	return nil
+}
+
+// Wait blocks until the job with the given id is finished and returns its final snapshot.
+// It returns ctx.Err() if ctx is done first.
+func (s *Store) Wait(ctx context.Context, id string) (models.Job, error) {
+	s.mtx.RLock()
+	e, ok := s.jobs[id]
+	s.mtx.RUnlock()
+	if !ok {
+		return models.Job{}, models.ErrJobNotFound
+	}
+
+	select {
+	case <-ctx.Done():
+		return models.Job{}, ctx.Err()
+	case <-e.done:
+		return s.Get(id)
+	}
+}
+
+// newID generates a random RFC 4122 version 4 UUID.
+func newID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // variant 10
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
@@ -0,0 +1,117 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestStore(t *testing.T) {
+	t.Run("create returns pending job with uuid", func(t *testing.T) {
+		store := NewStore()
+
+		job, err := store.Create(10, nil)
+		if err != nil {
+			t.Fatalf("Create() error = %v, want nil", err)
+		}
+		if !uuidPattern.MatchString(job.ID) {
+			t.Errorf("Create() ID = %s, want UUID v4", job.ID)
+		}
+		if job.Status != models.JobStatusPending {
+			t.Errorf("Create() Status = %s, want %s", job.Status, models.JobStatusPending)
+		}
+		if job.Total != 10 {
+			t.Errorf("Create() Total = %d, want 10", job.Total)
+		}
+	})
+
+	t.Run("update is visible in get", func(t *testing.T) {
+		store := NewStore()
+		job, _ := store.Create(3, nil)
+
+		err := store.Update(job.ID, func(j *models.Job) {
+			j.Status = models.JobStatusRunning
+			j.Checked = 2
+		})
+		if err != nil {
+			t.Fatalf("Update() error = %v, want nil", err)
+		}
+
+		got, err := store.Get(job.ID)
+		if err != nil {
+			t.Fatalf("Get() error = %v, want nil", err)
+		}
+		if got.Status != models.JobStatusRunning || got.Checked != 2 {
+			t.Errorf("Get() = %+v, want running with 2 checked", got)
+		}
+	})
+
+	t.Run("unknown id returns ErrJobNotFound", func(t *testing.T) {
+		store := NewStore()
+
+		if _, err := store.Get("missing"); !errors.Is(err, models.ErrJobNotFound) {
+			t.Errorf("Get() error = %v, want ErrJobNotFound", err)
+		}
+		if err := store.Update("missing", func(*models.Job) {}); !errors.Is(err, models.ErrJobNotFound) {
+			t.Errorf("Update() error = %v, want ErrJobNotFound", err)
+		}
+	})
+
+	t.Run("cancel calls the cancel function of a running job", func(t *testing.T) {
+		store := NewStore()
+		ctx, cancel := context.WithCancel(context.Background())
+		job, _ := store.Create(3, cancel)
+
+		if err := store.Cancel(job.ID); err != nil {
+			t.Fatalf("Cancel() error = %v, want nil", err)
+		}
+		if ctx.Err() == nil {
+			t.Error("Cancel() did not cancel the job context")
+		}
+	})
+
+	t.Run("wait returns once the job is finished", func(t *testing.T) {
+		store := NewStore()
+		job, _ := store.Create(1, nil)
+
+		go func() {
+			_ = store.Update(job.ID, func(j *models.Job) {
+				j.Status = models.JobStatusCanceled
+			})
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		got, err := store.Wait(ctx, job.ID)
+		if err != nil {
+			t.Fatalf("Wait() error = %v, want nil", err)
+		}
+		if got.Status != models.JobStatusCanceled {
+			t.Errorf("Wait() Status = %s, want %s", got.Status, models.JobStatusCanceled)
+		}
+	})
+
+	t.Run("finished job cannot be canceled or changed", func(t *testing.T) {
+		store := NewStore()
+		job, _ := store.Create(1, nil)
+		_ = store.Update(job.ID, func(j *models.Job) {
+			j.Status = models.JobStatusDone
+		})
+
+		if err := store.Cancel(job.ID); !errors.Is(err, models.ErrJobFinished) {
+			t.Errorf("Cancel() error = %v, want ErrJobFinished", err)
+		}
+		_ = store.Update(job.ID, func(j *models.Job) {
+			j.Status = models.JobStatusFailed
+		})
+		if got, _ := store.Get(job.ID); got.Status != models.JobStatusDone {
+			t.Errorf("Get() Status = %s, want %s", got.Status, models.JobStatusDone)
+		}
+	})
+}
@@ -7,8 +7,9 @@ import (
 	"path/filepath"
 )
 
-// SetupLogger configures slog logger writing to file and stdout based on level.
-func SetupLogger(logFile, logLevel string) (*slog.Logger, func() error, error) {
+// SetupLogger configures slog logger writing to file and stdout based on level and format.
+// Format "json" selects slog.JSONHandler, anything else falls back to slog.TextHandler.
+func SetupLogger(logFile, logLevel, logFormat string) (*slog.Logger, func() error, error) {
 	if logFile != "" {
 		logDir := filepath.Dir(logFile)
 		if err := os.MkdirAll(logDir, 0755); err != nil {
@@ -57,8 +58,14 @@ func SetupLogger(logFile, logLevel string) (*slog.Logger, func() error, error) {
 		Level: level,
 	}
 
-	handler := slog.NewTextHandler(multiWriter, opts)
-	logger := slog.New(handler)
+	var handler slog.Handler
+	switch logFormat {
+	case "json":
+		handler = slog.NewJSONHandler(multiWriter, opts)
+	default:
+		handler = slog.NewTextHandler(multiWriter, opts)
+	}
+	logger := slog.New(contextHandler{Handler: handler})
 
 	return logger, closeFile, nil
 }
@@ -1,6 +1,70 @@
 package models
 
-import "time"
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrSourceUnavailable is returned when a remote document with links (sitemap, page) cannot be fetched.
+var ErrSourceUnavailable = errors.New("links source unavailable")
+
+// ErrNoLinksFound is returned when a links source contains no links to check.
+var ErrNoLinksFound = errors.New("no links found in source")
+
+// ErrGroupNotFound is returned when requested link groups do not exist.
+var ErrGroupNotFound = errors.New("link group not found")
+
+// GroupNotFoundError lists link group numbers that were requested but do not exist.
+type GroupNotFoundError struct {
+	Nums []int
+}
+
+func (e *GroupNotFoundError) Error() string {
+	return fmt.Sprintf("link groups not found: %v", e.Nums)
+}
+
+// Is makes GroupNotFoundError match ErrGroupNotFound in errors.Is.
+func (e *GroupNotFoundError) Is(target error) bool {
+	return target == ErrGroupNotFound
+}
+
+// ErrJobNotFound is returned when an asynchronous job with the given id does not exist.
+var ErrJobNotFound = errors.New("job not found")
+
+// ErrJobFinished is returned when canceling an asynchronous job that is already finished.
+var ErrJobFinished = errors.New("job already finished")
+
+// ErrInvalidReportOptions is returned when report customization options cannot be applied.
+var ErrInvalidReportOptions = errors.New("invalid report options")
+
+// ErrReportTooLarge is returned when a report would cover more link groups or links than allowed.
+var ErrReportTooLarge = errors.New("report too large")
+
+// ErrShuttingDown is returned when a check is requested after the service started shutting down.
+var ErrShuttingDown = errors.New("service is shutting down")
+
+// ErrInvalidSnapshot is returned when stored or imported link groups cannot be decoded or restored.
+var ErrInvalidSnapshot = errors.New("invalid storage snapshot")
+
+// ErrInvalidStatusRange is returned when an expected status code or range cannot be parsed.
+var ErrInvalidStatusRange = errors.New("invalid status range")
+
+// ErrInvalidSearch is returned when a search pattern or status is invalid.
+var ErrInvalidSearch = errors.New("invalid search")
+
+// ErrMonitorDisabled is returned when monitor results are requested but no URLs are monitored.
+var ErrMonitorDisabled = errors.New("monitor is disabled")
+
+// ErrMonitorPending is returned when monitor results are requested before the first round is stored.
+var ErrMonitorPending = errors.New("monitor has no results yet")
+
+// ErrLinkNotFound is returned when a stored group has no link with the given URL.
+var ErrLinkNotFound = errors.New("link not found in group")
 
 // LinkStatus describes availability status of a checked link.
 type LinkStatus string
@@ -8,12 +72,71 @@ type LinkStatus string
 const (
 	LinkStatusAvailable    LinkStatus = "available"
 	LinkStatusNotAvailable LinkStatus = "not available"
+	// LinkStatusUnsupportedScheme marks URLs with an explicit non-HTTP scheme (ftp:, mailto:, ...).
+	LinkStatusUnsupportedScheme LinkStatus = "unsupported_scheme"
+	// LinkStatusSkipped marks links left unchecked because the check budget ran out.
+	LinkStatusSkipped LinkStatus = "skipped"
+	// LinkStatusSkippedDenylist marks links never checked because their host is on the skip list.
+	LinkStatusSkippedDenylist LinkStatus = "skipped_denylist"
+	// LinkStatusBlockedPrivateAddress marks links resolving to a private, loopback or link-local
+	// address while private address blocking is enabled.
+	LinkStatusBlockedPrivateAddress LinkStatus = "blocked_private_address"
+	// LinkStatusRateLimited marks links still answered with 429 Too Many Requests after
+	// the retries allowed by their Retry-After headers.
+	LinkStatusRateLimited LinkStatus = "rate_limited"
+)
+
+// LinkStatuses lists every status a checked link can have.
+var LinkStatuses = []LinkStatus{
+	LinkStatusAvailable,
+	LinkStatusNotAvailable,
+	LinkStatusUnsupportedScheme,
+	LinkStatusSkipped,
+	LinkStatusSkippedDenylist,
+	LinkStatusBlockedPrivateAddress,
+	LinkStatusRateLimited,
+}
+
+// IsSkipped reports whether the link was left unchecked, for any reason.
+func (s LinkStatus) IsSkipped() bool {
+	return s == LinkStatusSkipped || s == LinkStatusSkippedDenylist
+}
+
+// Reasons reported in Link.Error for links that are not available.
+const (
+	LinkErrorTooManyRedirects = "too_many_redirects"
+	LinkErrorUnexpectedStatus = "unexpected_status"
+	LinkErrorContentMismatch  = "content_mismatch"
+	LinkErrorTimeout          = "timeout"
+	// LinkErrorBodyTooLarge marks a content mismatch on a body cut at the body size limit,
+	// the expected content may be in the part that was not read.
+	LinkErrorBodyTooLarge = "body_too_large"
+	// LinkErrorCORSRejected marks a CORS preflight whose response does not allow the requested
+	// origin, method or headers.
+	LinkErrorCORSRejected = "cors_rejected"
 )
 
 // Links groups a slice of links with its assigned group number.
 type Links struct {
 	Links    []Link `json:"links"`
 	LinksNum int    `json:"links_num"`
+	// Name is an optional human-readable label given to the group when it was checked.
+	Name string `json:"name,omitempty"`
+}
+
+// GroupSummary describes a stored group without its links.
+type GroupSummary struct {
+	LinksNum  int    `json:"links_num"`
+	Name      string `json:"name,omitempty"`
+	Total     int    `json:"total"`
+	Available int    `json:"available"`
+	// Ignored counts links marked as ignored, they are not counted in Available.
+	Ignored int `json:"ignored"`
+	// AvailabilityPercent is the share of available links among the links not ignored, from 0 to 100.
+	AvailabilityPercent float64 `json:"availability_percent"`
+	// FirstCheckedAt and LastCheckedAt span the check times of the group links.
+	FirstCheckedAt time.Time `json:"first_checked_at"`
+	LastCheckedAt  time.Time `json:"last_checked_at"`
 }
 
 // Link holds the result of a single URL availability check.
@@ -22,21 +145,541 @@ type Link struct {
 	Status    LinkStatus    `json:"status"`
 	Duration  time.Duration `json:"duration"`
 	CheckedAt time.Time     `json:"checked_at"`
+	// MetaRefreshTarget is the followed <meta http-equiv="refresh"> URL, if any.
+	MetaRefreshTarget string `json:"meta_refresh_target,omitempty"`
+	// LastModified is the Last-Modified header of the response, nil if missing or invalid.
+	LastModified *time.Time `json:"last_modified,omitempty"`
+	// Scheme is the scheme the check was made over, which matters for URLs given without one.
+	Scheme string `json:"scheme,omitempty"`
+	// Method is the HTTP method the check was made with, empty if no request was sent.
+	Method string `json:"method,omitempty"`
+	// ETag is the ETag header of the response, sent as If-None-Match when the URL is re-checked.
+	ETag string `json:"etag,omitempty"`
+	// Unchanged is set when a conditional re-check (If-None-Match or If-Modified-Since) got 304 Not Modified.
+	Unchanged bool `json:"unchanged,omitempty"`
+	// Slow is set when the link is available but its check took longer than the configured threshold.
+	Slow bool `json:"slow,omitempty"`
+	// StatusCode is the HTTP status code of the final response, zero if the request failed.
+	StatusCode int `json:"status_code,omitempty"`
+	// ExpectedStatus is the status code or range the link was required to answer with, if any.
+	ExpectedStatus string `json:"expected_status,omitempty"`
+	// ContentMatched reports whether the body matched the expected content, nil if content was not checked.
+	ContentMatched *bool `json:"content_matched,omitempty"`
+	// RedirectCount is the number of redirects followed during the check.
+	RedirectCount int `json:"redirect_count"`
+	// ContentLength is the size of the content in bytes, zero if the server did not report it.
+	ContentLength int64 `json:"content_length,omitempty"`
+	// Error is a machine-readable reason the link is not available, if known.
+	Error string `json:"error,omitempty"`
+	// GroupNum is the number of the stored group the link belongs to.
+	GroupNum int `json:"group_num,omitempty"`
+	// Label is an optional client-supplied description of the link.
+	Label string `json:"label,omitempty"`
+	// Latency aggregates durations when the link was checked several times, nil for a single check.
+	Latency *LatencyStats `json:"latency,omitempty"`
+	// Timing breaks Duration down into request phases, nil unless timing is enabled.
+	Timing *Timing `json:"timing,omitempty"`
+	// CORS is the outcome of a CORS preflight check, nil unless the link was checked with one.
+	CORS *CORSResult `json:"cors,omitempty"`
+	// RateLimitRetries is how many times a 429 response was retried after its Retry-After delay.
+	RateLimitRetries int `json:"rate_limit_retries,omitempty"`
+	// Ignored marks a link known to be broken and accepted: it is still checked and shown,
+	// but left out of availability counts. Re-checks of its group keep the mark.
+	Ignored bool `json:"ignored,omitempty"`
+}
+
+// CORSResult records the Access-Control-Allow-* headers a CORS preflight was answered with
+// and whether they allow the requested origin, method and headers.
+type CORSResult struct {
+	AllowOrigin      string `json:"allow_origin,omitempty"`
+	AllowMethods     string `json:"allow_methods,omitempty"`
+	AllowHeaders     string `json:"allow_headers,omitempty"`
+	AllowCredentials bool   `json:"allow_credentials,omitempty"`
+	MaxAge           string `json:"max_age,omitempty"`
+	OriginAllowed    bool   `json:"origin_allowed"`
+	MethodAllowed    bool   `json:"method_allowed"`
+	HeadersAllowed   bool   `json:"headers_allowed"`
+}
+
+// Allowed reports whether the preflight allows the request.
+func (r CORSResult) Allowed() bool {
+	return r.OriginAllowed && r.MethodAllowed && r.HeadersAllowed
+}
+
+// Timing is a breakdown of a check into request phases, summed over redirects.
+// Phases skipped thanks to a reused connection are zero.
+type Timing struct {
+	DNS     time.Duration `json:"dns"`
+	Connect time.Duration `json:"connect"`
+	TLS     time.Duration `json:"tls"`
+	// TTFB is the time from the start of the check to the first byte of the final response.
+	TTFB time.Duration `json:"ttfb"`
+}
+
+// Clone returns a deep copy of the link that shares no pointers with it.
+func (l Link) Clone() Link {
+	if l.LastModified != nil {
+		lastModified := *l.LastModified
+		l.LastModified = &lastModified
+	}
+	if l.ContentMatched != nil {
+		matched := *l.ContentMatched
+		l.ContentMatched = &matched
+	}
+	if l.Latency != nil {
+		latency := *l.Latency
+		l.Latency = &latency
+	}
+	if l.Timing != nil {
+		timing := *l.Timing
+		l.Timing = &timing
+	}
+	if l.CORS != nil {
+		cors := *l.CORS
+		l.CORS = &cors
+	}
+	return l
+}
+
+// LatencyStats aggregates the durations of repeated checks of a link.
+type LatencyStats struct {
+	// Samples is the number of completed checks.
+	Samples int           `json:"samples"`
+	Min     time.Duration `json:"min"`
+	Avg     time.Duration `json:"avg"`
+	Max     time.Duration `json:"max"`
+}
+
+// LinkResult is a URL with its status as returned to clients.
+type LinkResult struct {
+	URL    string     `json:"url"`
+	Status LinkStatus `json:"status"`
+	// Slow is set for available links checked slower than the configured threshold.
+	Slow bool `json:"slow,omitempty"`
+	// Unchanged is set when the page did not change since its previous check.
+	Unchanged bool          `json:"unchanged,omitempty"`
+	Method    string        `json:"method,omitempty"`
+	Label     string        `json:"label,omitempty"`
+	Latency   *LatencyStats `json:"latency,omitempty"`
+	// Ignored is set for links marked as ignored in the group.
+	Ignored bool `json:"ignored,omitempty"`
 }
 
 // LinksResponse is returned from POST /links with statuses and group id.
+// Results holds the same statuses as Links, ordered as the URLs were submitted.
 type LinksResponse struct {
 	Links    map[string]LinkStatus `json:"links"`
+	Results  []LinkResult          `json:"results"`
 	LinksNum int                   `json:"links_num"`
+	Name     string                `json:"name,omitempty"`
+	// Changes compares a re-check of a stored group with it, nil for other checks.
+	Changes *StatusChanges `json:"changes,omitempty"`
+	// Checked holds the full checked links in submission order for VerboseLinksResponse, it is not encoded.
+	Checked []Link `json:"-"`
+}
+
+// VerboseLinksResponse is returned from POST /links?verbose=true with the full checked links,
+// including durations, check times and status codes, instead of their statuses.
+type VerboseLinksResponse struct {
+	Links    []Link `json:"links"`
+	LinksNum int    `json:"links_num"`
+	Name     string `json:"name,omitempty"`
+}
+
+// Verbose returns the verbose form of the response.
+func (r LinksResponse) Verbose() VerboseLinksResponse {
+	links := r.Checked
+	if links == nil {
+		links = []Link{}
+	}
+	return VerboseLinksResponse{Links: links, LinksNum: r.LinksNum, Name: r.Name}
+}
+
+// MonitorResponse is returned from GET /monitor with the latest results of the monitored URLs.
+type MonitorResponse struct {
+	// LinksNum is the group the monitored URLs are checked into, replaced on every round.
+	LinksNum int    `json:"links_num"`
+	Name     string `json:"name"`
+	// IntervalSeconds is how often the monitored URLs are checked.
+	IntervalSeconds int `json:"interval_seconds"`
+	// CheckedAt is when the latest stored round finished.
+	CheckedAt time.Time `json:"checked_at"`
+	Total     int       `json:"total"`
+	Available int       `json:"available"`
+	// Ignored counts links marked as ignored, they are not counted in Available.
+	Ignored int    `json:"ignored"`
+	Links   []Link `json:"links"`
+}
+
+// StatusChanges lists URLs of a re-checked group by how their availability changed.
+// Links that are skipped in either check or ignored are not listed.
+type StatusChanges struct {
+	NowBroken []string `json:"now_broken"`
+	NowFixed  []string `json:"now_fixed"`
+	Unchanged []string `json:"unchanged"`
+}
+
+// CheckOptions holds per-request overrides for a CheckMany call.
+type CheckOptions struct {
+	// Workers overrides the default worker pool size when positive.
+	Workers int
+	// Progress, if set, is called after each checked link.
+	Progress func(checked, total int)
+	// OnResult, if set, is called with each checked link as soon as it is ready.
+	OnResult func(link Link)
+	// ExpectedStatus, if set, replaces the default "below 400 is available" rule for every link.
+	ExpectedStatus StatusRange
+	// ExpectedStatusByURL overrides ExpectedStatus for individual links.
+	ExpectedStatusByURL map[string]StatusRange
+	// Method is the HTTP method every link is checked with, empty for HEAD.
+	Method string
+	// MethodByURL overrides Method for individual links.
+	MethodByURL map[string]string
+	// Labels holds client-supplied labels of links by URL, stored with the checked links.
+	Labels map[string]string
+	// Ignored holds URLs whose checked links are stored marked as ignored, e.g. by a re-check.
+	Ignored map[string]bool
+	// ContentMatch, if set, requires the body of every available link to match it.
+	ContentMatch ContentMatch
+	// Accept overrides the Accept header of check requests, empty keeps the configured one.
+	Accept string
+	// CORS, if set, checks every link with a CORS preflight OPTIONS request instead of the check method.
+	CORS CORSPreflight
+	// Name labels the stored group.
+	Name string
+	// GroupNum, if positive, replaces the links of that stored group instead of storing a new one.
+	// A missing group is stored as a new one.
+	GroupNum int
+	// Budget, if positive, bounds the wall-clock time of the whole check. Links not checked
+	// in time are stored as skipped instead of failing the check.
+	Budget time.Duration
+	// IdempotencyKey, if set, makes a repeated CheckMany with the same key return the group stored first.
+	IdempotencyKey string
+	// Samples, if above one, checks every link that many times and aggregates the durations into Link.Latency.
+	Samples int
+}
+
+// CORSPreflight describes the cross-origin request a CORS preflight asks permission for.
+type CORSPreflight struct {
+	// Origin is sent in the Origin header.
+	Origin string
+	// Method is sent in Access-Control-Request-Method.
+	Method string
+	// Headers are sent in Access-Control-Request-Headers, if any.
+	Headers []string
+}
+
+// IsZero reports whether no preflight is configured.
+func (p CORSPreflight) IsZero() bool {
+	return p.Origin == ""
+}
+
+// ContentMatch describes text a page body must contain to be considered available.
+// When both fields are set, the body must satisfy both.
+type ContentMatch struct {
+	// Contains is a substring the body must contain.
+	Contains string
+	// Pattern is a regular expression the body must match.
+	Pattern *regexp.Regexp
+}
+
+// IsZero reports whether no content match is configured.
+func (m ContentMatch) IsZero() bool {
+	return m.Contains == "" && m.Pattern == nil
 }
 
+// Match reports whether body satisfies the content match.
+func (m ContentMatch) Match(body []byte) bool {
+	if m.Contains != "" && !bytes.Contains(body, []byte(m.Contains)) {
+		return false
+	}
+	if m.Pattern != nil && !m.Pattern.Match(body) {
+		return false
+	}
+	return true
+}
+
+// ExpectedStatusFor returns the expected status range for url, zero if the default rule applies.
+func (o CheckOptions) ExpectedStatusFor(url string) StatusRange {
+	if r, ok := o.ExpectedStatusByURL[url]; ok {
+		return r
+	}
+	return o.ExpectedStatus
+}
+
+// MethodFor returns the HTTP method url is checked with, empty if the default HEAD applies.
+func (o CheckOptions) MethodFor(url string) string {
+	if m, ok := o.MethodByURL[url]; ok {
+		return m
+	}
+	return o.Method
+}
+
+// CheckMethods are the HTTP methods links may be checked with. They are safe methods,
+// so checking a link never changes state on the server.
+var CheckMethods = []string{"HEAD", "GET", "OPTIONS"}
+
+// ParseCheckMethod validates a case-insensitive HTTP method against CheckMethods
+// and returns it in upper case.
+func ParseCheckMethod(s string) (string, error) {
+	method := strings.ToUpper(strings.TrimSpace(s))
+	for _, m := range CheckMethods {
+		if method == m {
+			return method, nil
+		}
+	}
+	return "", fmt.Errorf("must be one of %s", strings.Join(CheckMethods, ", "))
+}
+
+// StatusRange is an inclusive range of HTTP status codes.
+type StatusRange struct {
+	Min int
+	Max int
+}
+
+// ParseStatusRange parses a status code ("200"), a class ("2xx") or a range ("200-299").
+func ParseStatusRange(s string) (StatusRange, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	var r StatusRange
+	switch {
+	case len(s) == 3 && strings.HasSuffix(s, "xx"):
+		class, err := strconv.Atoi(s[:1])
+		if err != nil {
+			return StatusRange{}, fmt.Errorf("%w: %q", ErrInvalidStatusRange, s)
+		}
+		r = StatusRange{Min: class * 100, Max: class*100 + 99}
+	case strings.Contains(s, "-"):
+		lo, hi, _ := strings.Cut(s, "-")
+		minCode, errMin := strconv.Atoi(strings.TrimSpace(lo))
+		maxCode, errMax := strconv.Atoi(strings.TrimSpace(hi))
+		if errMin != nil || errMax != nil {
+			return StatusRange{}, fmt.Errorf("%w: %q", ErrInvalidStatusRange, s)
+		}
+		r = StatusRange{Min: minCode, Max: maxCode}
+	default:
+		code, err := strconv.Atoi(s)
+		if err != nil {
+			return StatusRange{}, fmt.Errorf("%w: %q", ErrInvalidStatusRange, s)
+		}
+		r = StatusRange{Min: code, Max: code}
+	}
+
+	if r.Min < 100 || r.Max > 599 || r.Min > r.Max {
+		return StatusRange{}, fmt.Errorf("%w: %q", ErrInvalidStatusRange, s)
+	}
+	return r, nil
+}
+
+// IsZero reports whether the range is unset.
+func (r StatusRange) IsZero() bool {
+	return r == StatusRange{}
+}
+
+// Contains reports whether code is within the range.
+func (r StatusRange) Contains(code int) bool {
+	return code >= r.Min && code <= r.Max
+}
+
+// String formats the range in the form accepted by ParseStatusRange.
+func (r StatusRange) String() string {
+	switch {
+	case r.Min == r.Max:
+		return strconv.Itoa(r.Min)
+	case r.Min%100 == 0 && r.Max == r.Min+99:
+		return fmt.Sprintf("%dxx", r.Min/100)
+	default:
+		return fmt.Sprintf("%d-%d", r.Min, r.Max)
+	}
+}
+
+// ServiceStatus describes the current load of the link checking service.
+type ServiceStatus struct {
+	// ActiveBatches is the number of CheckMany calls and async jobs in progress.
+	ActiveBatches int64 `json:"active_batches"`
+	// ActiveChecks is the number of single URL checks in progress.
+	ActiveChecks int64 `json:"active_checks"`
+	// CompletedChecks is the number of URL checks finished since start.
+	CompletedChecks int64 `json:"completed_checks"`
+	// Workers is the default worker pool size of a batch, MaxWorkers its per-request upper bound.
+	Workers    int `json:"workers"`
+	MaxWorkers int `json:"max_workers"`
+	// SharedWorkers is the size of the long-lived pool shared by all batches, zero if disabled.
+	SharedWorkers int `json:"shared_workers"`
+	// MaxConcurrentChecks bounds checks across all batches, zero means unlimited.
+	MaxConcurrentChecks int  `json:"max_concurrent_checks"`
+	ShuttingDown        bool `json:"shutting_down"`
+}
+
+// JobStatus describes the lifecycle state of an asynchronous check job.
+type JobStatus string
+
+const (
+	JobStatusPending JobStatus = "pending"
+	JobStatusRunning JobStatus = "running"
+	JobStatusDone    JobStatus = "done"
+	JobStatusFailed  JobStatus = "failed"
+	// JobStatusCanceled marks a job stopped by the client, its result holds the links checked until then.
+	JobStatusCanceled JobStatus = "canceled"
+)
+
+// IsFinal reports whether a job in this status has finished and will not change anymore.
+func (s JobStatus) IsFinal() bool {
+	return s == JobStatusDone || s == JobStatusFailed || s == JobStatusCanceled
+}
+
+// Job is an asynchronous check of a batch of links.
+type Job struct {
+	ID         string         `json:"id"`
+	Status     JobStatus      `json:"status"`
+	Checked    int            `json:"checked"`
+	Total      int            `json:"total"`
+	Result     *LinksResponse `json:"result,omitempty"`
+	Error      string         `json:"error,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+	FinishedAt *time.Time     `json:"finished_at,omitempty"`
+}
+
+// CrawlResponse is returned from POST /links/crawl with statuses and the source page.
+type CrawlResponse struct {
+	LinksResponse
+	Source string `json:"source"`
+}
+
+// ReportOptions customizes report appearance. Empty fields fall back to defaults.
+type ReportOptions struct {
+	Title       string `json:"title,omitempty"`
+	AccentColor string `json:"accent_color,omitempty"` // hex, e.g. "#000080"
+	FooterText  string `json:"footer_text,omitempty"`
+	Timezone    string `json:"timezone,omitempty"` // IANA name, e.g. "Europe/Moscow"
+	// OnlyFailures limits the detailed links table to not available links that are not ignored,
+	// statistics are still computed over all links.
+	OnlyFailures bool `json:"only_failures,omitempty"`
+	// Format is the document format, chosen by the Accept header or format query parameter
+	// instead of the request body.
+	Format ReportFormat `json:"-"`
+}
+
+// ReportFormat is the document format of a report.
+type ReportFormat string
+
+const (
+	ReportFormatPDF  ReportFormat = "pdf"
+	ReportFormatHTML ReportFormat = "html"
+)
+
 // GenerateReportRequest represents a list of link group numbers to report on.
 type GenerateReportRequest struct {
 	LinksNum []int `json:"links_num"`
+	ReportOptions
+}
+
+// CheckReportResponse is returned from POST /links/report when JSON is requested,
+// combining the check results with the statistics of the report.
+type CheckReportResponse struct {
+	LinksResponse
+	Statistics Statistics       `json:"statistics"`
+	Hosts      []HostStatistics `json:"hosts"`
+}
+
+// ImportMode selects how imported link groups are combined with stored ones.
+type ImportMode string
+
+const (
+	// ImportModeMerge appends imported groups as new groups with fresh numbers.
+	ImportModeMerge ImportMode = "merge"
+	// ImportModeReplace drops all stored groups, imported groups keep their numbers.
+	ImportModeReplace ImportMode = "replace"
+)
+
+// ImportResponse describes link groups stored by an import.
+type ImportResponse struct {
+	Mode ImportMode `json:"mode"`
+	// LinksNum holds the numbers the imported groups are stored under, in import order.
+	LinksNum   []int `json:"links_num"`
+	LinksCount int   `json:"links_count"`
+}
+
+// SearchResponse holds stored links whose URL matched a search query.
+type SearchResponse struct {
+	Query   string     `json:"query"`
+	Pattern string     `json:"pattern,omitempty"`
+	Regex   string     `json:"regex,omitempty"`
+	Host    string     `json:"host,omitempty"`
+	Status  LinkStatus `json:"status,omitempty"`
+	Links   []Link     `json:"links"`
+	Count   int        `json:"count"`
+}
+
+// SearchQuery selects stored links, all set conditions must hold.
+type SearchQuery struct {
+	// Query is a substring of the URL, matched case-insensitively.
+	Query string
+	// Pattern is a glob matched against the whole URL case-insensitively,
+	// * matches any run of characters and ? a single one.
+	Pattern string
+	// Regex is a RE2 expression matched against the URL.
+	Regex string
+	// Host is a host name or glob pattern (e.g. "*.example.com") of the URL.
+	Host string
+	// Status is the status of the link.
+	Status LinkStatus
+}
+
+// Statistics aggregates availability counts and average check durations.
+type Statistics struct {
+	Groups       int `json:"groups"`
+	Total        int `json:"total"`
+	Available    int `json:"available"`
+	NotAvailable int `json:"not_available"`
+	Skipped      int `json:"skipped"`
+	// Ignored counts links marked as ignored, they are in neither availability count.
+	Ignored int `json:"ignored"`
+	// Slow counts available links flagged as slow, they are also counted in Available.
+	Slow                        int           `json:"slow"`
+	AverageAvailableDuration    time.Duration `json:"average_available_duration"`
+	AverageNotAvailableDuration time.Duration `json:"average_not_available_duration"`
+	// AvailableDurationPercentiles holds percentiles of check durations of available links,
+	// empty when no link is available.
+	AvailableDurationPercentiles []DurationPercentile `json:"available_duration_percentiles,omitempty"`
+}
+
+// DurationPercentile is the check duration that the given percentage of checks did not exceed.
+type DurationPercentile struct {
+	Percentile float64       `json:"percentile"`
+	Duration   time.Duration `json:"duration"`
+}
+
+// HostStatistics aggregates availability and latency of links sharing a host.
+type HostStatistics struct {
+	Host                string        `json:"host"`
+	Total               int           `json:"total"`
+	Available           int           `json:"available"`
+	NotAvailable        int           `json:"not_available"`
+	Ignored             int           `json:"ignored"`
+	AvailabilityPercent float64       `json:"availability_percent"`
+	AverageDuration     time.Duration `json:"average_duration"`
+}
+
+// Report holds statistics of the reported groups together with the size of the PDF written for them.
+type Report struct {
+	Size       int
+	Statistics Statistics
+	Hosts      []HostStatistics
+	Groups     []ReportGroup
+}
+
+// ReportGroup identifies a link group included in a report.
+type ReportGroup struct {
+	LinksNum int    `json:"links_num"`
+	Name     string `json:"name,omitempty"`
 }
 
 // GenerateReportResponse is a JSON metadata response for generated PDF report.
 type GenerateReportResponse struct {
-	Message string `json:"message"`
-	Size    int    `json:"size_bytes"`
+	Message    string           `json:"message"`
+	Size       int              `json:"size_bytes"`
+	Statistics Statistics       `json:"statistics"`
+	Hosts      []HostStatistics `json:"hosts"`
+	Groups     []ReportGroup    `json:"groups"`
 }
@@ -0,0 +1,311 @@
+package pdfgenerator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+	"github.com/polonkoevv/linkchecker/internal/stats"
+)
+
+// HTMLGenerator renders the same statistics and tables as GoFPDFGenerator into a single
+// self-contained HTML page with inline CSS, for embedding in dashboards.
+type HTMLGenerator struct {
+	settings
+}
+
+// NewHTMLGenerator creates a new HTMLGenerator instance.
+func NewHTMLGenerator(opts ...Option) *HTMLGenerator {
+	g := &HTMLGenerator{}
+	for _, opt := range opts {
+		opt(&g.settings)
+	}
+	return g
+}
+
+// htmlReport is the data of the report template.
+type htmlReport struct {
+	Title       string
+	AccentColor string
+	FooterText  string
+	AsOf        string
+	// Summary lists every group of reports over more than one group, nil otherwise.
+	Summary *htmlSummary
+	Groups  []htmlGroup
+	Hosts   []models.HostStatistics
+}
+
+// htmlSummary is the table of groups opening a multi-group report.
+type htmlSummary struct {
+	Title  string
+	Groups []htmlSummaryRow
+	Total  models.Statistics
+}
+
+// htmlSummaryRow is a group in the summary table.
+type htmlSummaryRow struct {
+	Anchor     string
+	LinksNum   int
+	Name       string
+	Statistics models.Statistics
+}
+
+// htmlGroup is the section of a single group with its statistics and links.
+type htmlGroup struct {
+	Anchor       string
+	LinksNum     int
+	Name         string
+	Statistics   models.Statistics
+	LinksHeading string
+	Links        []htmlLinkRow
+}
+
+// htmlLinkRow is a row of the detailed links table.
+type htmlLinkRow struct {
+	URL          string
+	Status       models.LinkStatus
+	Ignored      bool
+	Class        string
+	Duration     time.Duration
+	CheckedAt    string
+	LastModified string
+	Redirects    int
+	Size         string
+}
+
+// GenerateMultipleReports renders an HTML report for several link groups and writes it to w.
+// Reports of more than one group open with a summary table linking to every group section.
+// Rendering stops with ctx.Err() at the next group once ctx is done; nothing is written when
+// the options are invalid or rendering was stopped.
+func (g *HTMLGenerator) GenerateMultipleReports(ctx context.Context, w io.Writer, linksSlice []models.Links, opts models.ReportOptions) error {
+	slog.InfoContext(ctx, "generating HTML report", slog.Int("groups", len(linksSlice)))
+
+	style, err := resolveStyle(opts, linksSlice)
+	if err != nil {
+		return err
+	}
+
+	report := htmlReport{
+		Title:       style.title,
+		AccentColor: fmt.Sprintf("#%02x%02x%02x", style.accentColor[0], style.accentColor[1], style.accentColor[2]),
+		FooterText:  style.footerText,
+		AsOf:        style.asOf.Format(timeLayout),
+		Groups:      make([]htmlGroup, 0, len(linksSlice)),
+		Hosts:       stats.ByHost(linksSlice, g.collapseWWW),
+	}
+
+	if len(linksSlice) > 1 {
+		heading := summaryTitle
+		if style.title != title {
+			heading = style.title + " - SUMMARY"
+		}
+		report.Summary = &htmlSummary{Title: heading, Total: stats.CalculateGroups(linksSlice)}
+		for _, links := range linksSlice {
+			report.Summary.Groups = append(report.Summary.Groups, htmlSummaryRow{
+				Anchor:     groupAnchor(links.LinksNum),
+				LinksNum:   links.LinksNum,
+				Name:       links.Name,
+				Statistics: stats.Calculate(links.Links),
+			})
+		}
+	}
+
+	for _, links := range linksSlice {
+		if err := ctx.Err(); err != nil {
+			slog.WarnContext(ctx, "HTML report generation stopped", slog.Any("error", err))
+			return err
+		}
+		report.Groups = append(report.Groups, newHTMLGroup(style, links, g.percentiles))
+	}
+
+	var buf bytes.Buffer
+	if err := htmlReportTemplate.Execute(&buf, report); err != nil {
+		slog.ErrorContext(ctx, "failed to render HTML report", slog.Any("error", err))
+		return fmt.Errorf("failed to render HTML report: %w", err)
+	}
+	if _, err := buf.WriteTo(w); err != nil {
+		return fmt.Errorf("failed to write HTML report: %w", err)
+	}
+
+	slog.DebugContext(ctx, "HTML report generated", slog.Int("groups", len(linksSlice)))
+
+	return nil
+}
+
+// newHTMLGroup builds the section of links with the rows of the detailed links table.
+func newHTMLGroup(style reportStyle, links models.Links, percentiles []float64) htmlGroup {
+	group := htmlGroup{
+		Anchor:       groupAnchor(links.LinksNum),
+		LinksNum:     links.LinksNum,
+		Name:         links.Name,
+		Statistics:   stats.Calculate(links.Links, percentiles...),
+		LinksHeading: "DETAILED LINK REPORT",
+	}
+
+	rows := links.Links
+	if style.onlyFailures {
+		group.LinksHeading = "FAILED LINKS"
+		rows = failedLinks(links.Links)
+	}
+
+	group.Links = make([]htmlLinkRow, 0, len(rows))
+	for _, link := range rows {
+		lastModified := "-"
+		if link.LastModified != nil {
+			lastModified = link.LastModified.In(style.location).Format(dateLayout)
+		}
+		group.Links = append(group.Links, htmlLinkRow{
+			URL:          link.URL,
+			Status:       link.Status,
+			Ignored:      link.Ignored,
+			Class:        statusClass(link),
+			Duration:     link.Duration,
+			CheckedAt:    link.CheckedAt.In(style.location).Format(timeLayout),
+			LastModified: lastModified,
+			Redirects:    link.RedirectCount,
+			Size:         formatSize(link.ContentLength),
+		})
+	}
+	return group
+}
+
+// groupAnchor is the id of the section of group num.
+func groupAnchor(num int) string {
+	return fmt.Sprintf("group-%d", num)
+}
+
+// statusClass returns the CSS class coloring the row of link, matching the PDF status colors.
+func statusClass(link models.Link) string {
+	if link.Ignored {
+		return "ignored"
+	}
+	if link.Slow {
+		return "slow"
+	}
+	switch link.Status {
+	case models.LinkStatusAvailable:
+		return "available"
+	case models.LinkStatusNotAvailable:
+		return "not-available"
+	case models.LinkStatusUnsupportedScheme, models.LinkStatusBlockedPrivateAddress:
+		return "unchecked"
+	case models.LinkStatusSkipped, models.LinkStatusSkippedDenylist, models.LinkStatusRateLimited:
+		return "skipped"
+	default:
+		return ""
+	}
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"duration": func(d time.Duration) string {
+		return d.Round(time.Millisecond).String()
+	},
+	"percent": func(s models.Statistics) string {
+		return fmt.Sprintf("%.1f%%", stats.AvailabilityPercent(s))
+	},
+}).Parse(htmlReportLayout))
+
+// htmlReportLayout is the report page; all styles are inline so that the file stands alone.
+const htmlReportLayout = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: Arial, Helvetica, sans-serif; margin: 2em; color: #000; }
+h1, h2 { color: {{.AccentColor}}; }
+h1 { text-align: center; font-size: 1.6em; }
+h2 { font-size: 1.3em; }
+h3 { font-size: 1.1em; }
+.as-of { text-align: center; color: #606060; font-size: 0.85em; }
+table { border-collapse: collapse; margin-bottom: 1.5em; font-size: 0.85em; }
+th, td { border: 1px solid #999; padding: 4px 8px; }
+th { background: #c8c8c8; }
+td.num { text-align: center; }
+.failing { color: #ff0000; }
+tr.available td.status { color: #008000; }
+tr.not-available { background: #fde8e8; }
+tr.not-available td.status { color: #ff0000; }
+tr.unchecked { background: #f0f0f0; }
+tr.unchecked td.status { color: #808080; }
+tr.skipped { background: #fff1e0; }
+tr.skipped td.status { color: #ff8c00; }
+tr.slow { background: #fbf5e0; }
+tr.slow td.status, tr.slow td.duration { color: #cc9900; }
+tr.ignored { color: #808080; }
+footer { margin-top: 2em; text-align: center; color: #808080; font-size: 0.8em; }
+</style>
+</head>
+<body>
+{{- with .Summary}}
+<section>
+<h1>{{.Title}}</h1>
+<p class="as-of">Checked as of: {{$.AsOf}}</p>
+<table>
+<tr><th>Group</th><th>Name</th><th>Links</th><th>Available</th><th>Availability</th></tr>
+{{- range .Groups}}
+<tr><td class="num"><a href="#{{.Anchor}}">{{.LinksNum}}</a></td><td><a href="#{{.Anchor}}">{{or .Name "-"}}</a></td><td class="num">{{.Statistics.Total}}</td><td class="num">{{.Statistics.Available}}</td><td class="num{{if .Statistics.NotAvailable}} failing{{end}}">{{percent .Statistics}}</td></tr>
+{{- end}}
+<tr><th colspan="2">TOTAL ({{.Total.Groups}} groups)</th><th>{{.Total.Total}}</th><th>{{.Total.Available}}</th><th>{{percent .Total}}</th></tr>
+</table>
+</section>
+{{- end}}
+{{- range .Groups}}
+<section id="{{.Anchor}}">
+<h1>{{$.Title}} {{.LinksNum}}</h1>
+{{- if .Name}}
+<h2 style="text-align: center">{{.Name}}</h2>
+{{- end}}
+<p class="as-of">Checked as of: {{$.AsOf}}</p>
+<h3>STATISTICS SUMMARY</h3>
+<table>
+<tr><th>Metric</th><th>Count</th><th>Average Time</th></tr>
+<tr><td>Available Links</td><td class="num">{{.Statistics.Available}}</td><td class="num">{{duration .Statistics.AverageAvailableDuration}}</td></tr>
+<tr><td>Not Available Links</td><td class="num">{{.Statistics.NotAvailable}}</td><td class="num">{{duration .Statistics.AverageNotAvailableDuration}}</td></tr>
+{{- if .Statistics.Slow}}
+<tr><td>&nbsp;&nbsp;of them Slow</td><td class="num">{{.Statistics.Slow}}</td><td class="num">-</td></tr>
+{{- end}}
+{{- if .Statistics.Skipped}}
+<tr><td>Skipped Links</td><td class="num">{{.Statistics.Skipped}}</td><td class="num">-</td></tr>
+{{- end}}
+{{- if .Statistics.Ignored}}
+<tr><td>Ignored Links</td><td class="num">{{.Statistics.Ignored}}</td><td class="num">-</td></tr>
+{{- end}}
+<tr><th>TOTAL</th><th>{{.Statistics.Total}}</th><th>-</th></tr>
+{{- range .Statistics.AvailableDurationPercentiles}}
+<tr><td>Available p{{.Percentile}}</td><td class="num">-</td><td class="num">{{duration .Duration}}</td></tr>
+{{- end}}
+</table>
+<h3>{{.LinksHeading}}</h3>
+{{- if .Links}}
+<table>
+<tr><th>URL</th><th>Status</th><th>Duration</th><th>Checked At</th><th>Last Modified</th><th>Redirects</th><th>Size</th></tr>
+{{- range .Links}}
+<tr class="{{.Class}}"><td>{{.URL}}</td><td class="num status">{{.Status}}{{if .Ignored}} (ignored){{end}}</td><td class="num duration">{{duration .Duration}}</td><td class="num">{{.CheckedAt}}</td><td class="num">{{.LastModified}}</td><td class="num">{{.Redirects}}</td><td class="num">{{.Size}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p>No failed links</p>
+{{- end}}
+</section>
+{{- end}}
+<section>
+<h3>HOSTS SUMMARY</h3>
+<table>
+<tr><th>Host</th><th>Links</th><th>Available</th><th>Availability</th><th>Average Time</th></tr>
+{{- range .Hosts}}
+<tr><td>{{or .Host "-"}}</td><td class="num">{{.Total}}</td><td class="num">{{.Available}}</td><td class="num{{if .NotAvailable}} failing{{end}}">{{printf "%.1f%%" .AvailabilityPercent}}</td><td class="num">{{duration .AverageDuration}}</td></tr>
+{{- end}}
+</table>
+</section>
+{{- if .FooterText}}
+<footer>{{.FooterText}}</footer>
+{{- end}}
+</body>
+</html>
+`
@@ -0,0 +1,127 @@
+package pdfgenerator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestHTMLGenerator_GenerateMultipleReports(t *testing.T) {
+	checkedAt := time.Date(2024, time.January, 15, 10, 30, 0, 0, time.UTC)
+	groups := []models.Links{
+		{
+			LinksNum: 1,
+			Name:     "docs",
+			Links: []models.Link{
+				{URL: "https://example.com", Status: models.LinkStatusAvailable, CheckedAt: checkedAt},
+				{URL: "https://example.org/<script>", Status: models.LinkStatusNotAvailable, CheckedAt: checkedAt},
+			},
+		},
+		{
+			LinksNum: 2,
+			Links: []models.Link{
+				{URL: "https://example.net", Status: models.LinkStatusRateLimited, CheckedAt: checkedAt},
+			},
+		},
+	}
+	opts := models.ReportOptions{Timezone: "UTC"}
+	ctx := context.Background()
+	g := NewHTMLGenerator()
+
+	t.Run("renders a summary linking to every group", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := g.GenerateMultipleReports(ctx, &buf, groups, opts); err != nil {
+			t.Fatalf("GenerateMultipleReports() error = %v, want nil", err)
+		}
+		page := buf.String()
+
+		for _, want := range []string{
+			"<!DOCTYPE html>",
+			summaryTitle,
+			`href="#group-1"`,
+			`id="group-2"`,
+			`<tr class="not-available">`,
+			`<tr class="skipped">`,
+			"HOSTS SUMMARY",
+		} {
+			if !strings.Contains(page, want) {
+				t.Errorf("GenerateMultipleReports() page does not contain %q", want)
+			}
+		}
+		if strings.Contains(page, "<script>") {
+			t.Error("GenerateMultipleReports() did not escape the link URL")
+		}
+	})
+
+	t.Run("single group has no summary", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := g.GenerateMultipleReports(ctx, &buf, groups[:1], opts); err != nil {
+			t.Fatalf("GenerateMultipleReports() error = %v, want nil", err)
+		}
+		if strings.Contains(buf.String(), summaryTitle) {
+			t.Error("GenerateMultipleReports() rendered a summary for a single group")
+		}
+	})
+
+	t.Run("only failures", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := g.GenerateMultipleReports(ctx, &buf, groups[:1], models.ReportOptions{OnlyFailures: true}); err != nil {
+			t.Fatalf("GenerateMultipleReports() error = %v, want nil", err)
+		}
+		page := buf.String()
+		if !strings.Contains(page, "FAILED LINKS") || strings.Contains(page, `<tr class="available">`) {
+			t.Error("GenerateMultipleReports() with only failures listed more than the failed links")
+		}
+	})
+
+	t.Run("shows ignored links apart", func(t *testing.T) {
+		ignored := models.Links{LinksNum: 3, Links: []models.Link{
+			{URL: "https://example.com/404", Status: models.LinkStatusNotAvailable, CheckedAt: checkedAt, Ignored: true},
+		}}
+
+		var buf bytes.Buffer
+		if err := g.GenerateMultipleReports(ctx, &buf, []models.Links{ignored}, models.ReportOptions{OnlyFailures: true}); err != nil {
+			t.Fatalf("GenerateMultipleReports() error = %v, want nil", err)
+		}
+		page := buf.String()
+		if !strings.Contains(page, "Ignored Links") || !strings.Contains(page, "No failed links") {
+			t.Error("GenerateMultipleReports() counted the ignored link as failed")
+		}
+
+		buf.Reset()
+		if err := g.GenerateMultipleReports(ctx, &buf, []models.Links{ignored}, opts); err != nil {
+			t.Fatalf("GenerateMultipleReports() error = %v, want nil", err)
+		}
+		if !strings.Contains(buf.String(), `<tr class="ignored">`) || !strings.Contains(buf.String(), "not available (ignored)") {
+			t.Error("GenerateMultipleReports() did not mark the ignored link row")
+		}
+	})
+
+	t.Run("invalid options write nothing", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := g.GenerateMultipleReports(ctx, &buf, groups, models.ReportOptions{Timezone: "Nowhere/Invalid"}); err == nil {
+			t.Error("GenerateMultipleReports() error = nil, want an invalid timezone error")
+		}
+		if buf.Len() != 0 {
+			t.Errorf("GenerateMultipleReports() wrote %d bytes, want 0", buf.Len())
+		}
+	})
+
+	t.Run("stops on a canceled context", func(t *testing.T) {
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		var buf bytes.Buffer
+		if err := g.GenerateMultipleReports(canceled, &buf, groups, opts); !errors.Is(err, context.Canceled) {
+			t.Errorf("GenerateMultipleReports() error = %v, want context.Canceled", err)
+		}
+		if buf.Len() != 0 {
+			t.Errorf("GenerateMultipleReports() wrote %d bytes, want 0", buf.Len())
+		}
+	})
+}
@@ -1,148 +1,403 @@
 package pdfgenerator
 
 import (
-	"bytes"
+	"context"
 	"fmt"
+	"io"
 	"log/slog"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/jung-kurt/gofpdf"
 	"github.com/polonkoevv/linkchecker/internal/models"
+	"github.com/polonkoevv/linkchecker/internal/stats"
 )
 
-// GoFPDFGenerator generates PDF reports using gofpdf
+// GoFPDFGenerator generates PDF reports using gofpdf.
+// Reports are written to the given io.Writer once rendered; gofpdf still assembles the
+// document in memory, so callers should bound the number of links in a single report.
+// Output is deterministic: the same groups and options always produce byte-identical PDFs.
+// Groups and links are rendered in the given order, and the document dates are the time
+// of the latest check in the report instead of the generation time.
 type GoFPDFGenerator struct {
+	settings
 }
 
-type pdfStatistic struct {
-	available                int
-	notAvailable             int
-	averageAvailableSpeed    time.Duration
-	averageNotAvailableSpeed time.Duration
-	total                    int
+// settings are shared by the PDF and HTML generators.
+type settings struct {
+	// collapseWWW counts "www.example.com" links under "example.com" in host statistics.
+	collapseWWW bool
+	// percentiles of available check durations shown in statistics, nil for the stats defaults.
+	percentiles []float64
+}
+
+// Option configures a GoFPDFGenerator or an HTMLGenerator.
+type Option func(*settings)
+
+// WithCollapseWWW makes host statistics treat www and non-www hosts as the same host.
+func WithCollapseWWW(enabled bool) Option {
+	return func(s *settings) {
+		s.collapseWWW = enabled
+	}
+}
+
+// WithPercentiles sets the percentiles of available check durations shown in statistics,
+// empty keeps stats.DefaultPercentiles.
+func WithPercentiles(percentiles []float64) Option {
+	return func(s *settings) {
+		s.percentiles = percentiles
+	}
 }
 
 const title = "LINK STATUS REPORT - GROUP"
 
+// summaryTitle heads the summary page of multi-group reports with the default title.
+const summaryTitle = "LINK STATUS REPORT - SUMMARY"
+
+// defaultAccentColor is the navy blue used for report headers.
+var defaultAccentColor = [3]int{0, 0, 128}
+
+// timeLayout is used for all timestamps rendered in reports.
+const timeLayout = "15:04:05 02.01.2006 -07:00"
+
+// dateLayout is used for dates where the time of day is not relevant.
+const dateLayout = "02.01.2006"
+
+// reportStyle is a resolved form of models.ReportOptions.
+type reportStyle struct {
+	title       string
+	accentColor [3]int
+	footerText  string
+	location    *time.Location
+	// asOf is the time of the latest check in the report, used for the header and document dates.
+	asOf time.Time
+	// onlyFailures limits the detailed links table to not available links.
+	onlyFailures bool
+}
+
 // Page settings
 const orientationStr string = "P"
 const unitStr string = "mm"
 const sizeStr string = "A4"
 const fontDirStr string = ""
 
+// footerHeight is the distance from the page bottom at which the footer starts.
+const footerHeight float64 = 15
+
 // Font
 const familyStr string = "Arial"
 const styleStr string = "B"
 const size float64 = 20
 
 // NewGoFPDFGenerator creates a new GoFPDFGenerator instance.
-func NewGoFPDFGenerator() *GoFPDFGenerator {
-	return &GoFPDFGenerator{}
+func NewGoFPDFGenerator(opts ...Option) *GoFPDFGenerator {
+	g := &GoFPDFGenerator{}
+	for _, opt := range opts {
+		opt(&g.settings)
+	}
+	return g
 }
 
-// GenerateReport builds a single-group PDF report for the given links.
-func (g *GoFPDFGenerator) GenerateReport(links models.Links) (*bytes.Buffer, error) {
-	slog.Info("generating single PDF report",
+// GenerateReport builds a single-group PDF report for the given links and writes it to w.
+// Rendering stops with ctx.Err() at the next page once ctx is done. Nothing is written to w
+// when the options are invalid or rendering was stopped.
+func (g *GoFPDFGenerator) GenerateReport(ctx context.Context, w io.Writer, links models.Links, opts models.ReportOptions) error {
+	slog.InfoContext(ctx, "generating single PDF report",
 		slog.Int("links_num", links.LinksNum),
 		slog.Int("links_count", len(links.Links)),
 	)
 
-	pdf := gofpdf.New(orientationStr, unitStr, sizeStr, fontDirStr)
+	style, err := resolveStyle(opts, []models.Links{links})
+	if err != nil {
+		return err
+	}
+
+	pdf := newDocument(style)
+	g.setFooter(pdf, style)
 	pdf.AddPage()
 
 	// Добавляем заголовок
-	g.addHeaderWithGroup(pdf, links.LinksNum)
+	g.addHeaderWithGroup(pdf, style, links)
 
 	// Рассчитываем статистику
-	stats := g.calculateStatistic(links)
+	statistics := stats.Calculate(links.Links, g.percentiles...)
 
 	// Добавляем статистику в отчет
-	g.addStatistics(pdf, stats)
+	g.addStatistics(pdf, statistics)
+
+	// Добавляем статистику по хостам
+	g.addHostStatistics(pdf, stats.ByHost([]models.Links{links}, g.collapseWWW))
 
 	// Добавляем детальную информацию по ссылкам
-	g.addDetailedLinks(pdf, links)
+	if err := g.addDetailedLinks(ctx, pdf, style, links); err != nil {
+		slog.WarnContext(ctx, "single PDF report generation stopped", slog.Any("error", err))
+		return err
+	}
 
-	// Создаем буфер в памяти
-	var buf bytes.Buffer
-	err := pdf.Output(&buf)
-	if err != nil {
-		slog.Error("failed to generate single PDF report", slog.Any("error", err))
-		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	// Записываем готовый документ в w
+	if err := pdf.Output(w); err != nil {
+		slog.ErrorContext(ctx, "failed to generate single PDF report", slog.Any("error", err))
+		return fmt.Errorf("failed to generate PDF: %w", err)
 	}
 
-	slog.Debug("single PDF report generated",
-		slog.Int("links_num", links.LinksNum),
-		slog.Int("size_bytes", buf.Len()),
-	)
+	slog.DebugContext(ctx, "single PDF report generated", slog.Int("links_num", links.LinksNum))
 
-	return &buf, nil
+	return nil
 }
 
-// GenerateMultipleReports builds a multi-page PDF for several link groups.
-func (g *GoFPDFGenerator) GenerateMultipleReports(linksSlice []models.Links) (*bytes.Buffer, error) {
-	slog.Info("generating multi-group PDF report", slog.Int("groups", len(linksSlice)))
+// GenerateMultipleReports builds a multi-page PDF for several link groups. Reports of more
+// than one group open with a summary page listing every group, linked to its section.
+// The PDF is written to w. Rendering stops with ctx.Err() at the next group or page once ctx
+// is done; nothing is written when the options are invalid or rendering was stopped.
+func (g *GoFPDFGenerator) GenerateMultipleReports(ctx context.Context, w io.Writer, linksSlice []models.Links, opts models.ReportOptions) error {
+	slog.InfoContext(ctx, "generating multi-group PDF report", slog.Int("groups", len(linksSlice)))
 
-	pdf := gofpdf.New(orientationStr, unitStr, sizeStr, fontDirStr)
+	style, err := resolveStyle(opts, linksSlice)
+	if err != nil {
+		return err
+	}
+
+	pdf := newDocument(style)
+	g.setFooter(pdf, style)
+
+	// sections maps each group to the internal link of its first page, zero without a summary page
+	sections := make([]int, len(linksSlice))
+	if len(linksSlice) > 1 {
+		for i := range sections {
+			sections[i] = pdf.AddLink()
+		}
+		g.addSummaryPage(pdf, style, linksSlice, sections)
+	}
+
+	for i, links := range linksSlice {
+		if err := ctx.Err(); err != nil {
+			slog.WarnContext(ctx, "multi-group PDF report generation stopped", slog.Any("error", err))
+			return err
+		}
 
-	for _, links := range linksSlice {
 		pdf.AddPage()
+		if sections[i] != 0 {
+			pdf.SetLink(sections[i], 0, -1)
+		}
+
+		g.addHeaderWithGroup(pdf, style, links)
+
+		statistics := stats.Calculate(links.Links, g.percentiles...)
+
+		g.addStatistics(pdf, statistics)
+
+		if err := g.addDetailedLinks(ctx, pdf, style, links); err != nil {
+			slog.WarnContext(ctx, "multi-group PDF report generation stopped", slog.Any("error", err))
+			return err
+		}
+	}
+
+	// hosts are summarized across all groups, so that hosts failing in several groups stand out
+	pdf.AddPage()
+	g.addHostStatistics(pdf, stats.ByHost(linksSlice, g.collapseWWW))
+
+	if err := pdf.Output(w); err != nil {
+		slog.ErrorContext(ctx, "failed to generate multi-group PDF report", slog.Any("error", err))
+		return fmt.Errorf("failed to generate PDF: %w", err)
+	}
+
+	slog.DebugContext(ctx, "multi-group PDF report generated", slog.Int("groups", len(linksSlice)))
 
-		g.addHeaderWithGroup(pdf, links.LinksNum)
+	return nil
+}
+
+// newDocument creates an A4 document with fixed dates and sorted resource catalogs,
+// so that its output does not depend on when or where it is generated.
+func newDocument(style reportStyle) *gofpdf.Fpdf {
+	pdf := gofpdf.New(orientationStr, unitStr, sizeStr, fontDirStr)
+	pdf.SetCatalogSort(true)
+	pdf.SetCreationDate(style.asOf)
+	pdf.SetModificationDate(style.asOf)
+	return pdf
+}
+
+// latestCheck returns the latest CheckedAt of links in groups, or the Unix epoch if there is none.
+func latestCheck(groups []models.Links) time.Time {
+	latest := time.Unix(0, 0)
+	for _, g := range groups {
+		for _, link := range g.Links {
+			if link.CheckedAt.After(latest) {
+				latest = link.CheckedAt
+			}
+		}
+	}
+	return latest
+}
 
-		stats := g.calculateStatistic(links)
+// ValidateOptions reports whether opts can be applied to a report, so that they can be
+// rejected before any work the report depends on.
+func ValidateOptions(opts models.ReportOptions) error {
+	_, err := resolveStyle(opts, nil)
+	return err
+}
 
-		g.addStatistics(pdf, stats)
+// resolveStyle applies defaults to empty report options and validates the rest.
+// The report time is derived from the checks in groups.
+func resolveStyle(opts models.ReportOptions, groups []models.Links) (reportStyle, error) {
+	style := reportStyle{
+		title:        title,
+		accentColor:  defaultAccentColor,
+		footerText:   opts.FooterText,
+		location:     time.Local,
+		onlyFailures: opts.OnlyFailures,
+	}
 
-		g.addDetailedLinks(pdf, links)
+	if opts.Title != "" {
+		style.title = opts.Title
 	}
 
-	var buf bytes.Buffer
-	err := pdf.Output(&buf)
+	if opts.AccentColor != "" {
+		color, err := parseHexColor(opts.AccentColor)
+		if err != nil {
+			return reportStyle{}, fmt.Errorf("%w: accent_color: %w", models.ErrInvalidReportOptions, err)
+		}
+		style.accentColor = color
+	}
+
+	if opts.Timezone != "" {
+		loc, err := time.LoadLocation(opts.Timezone)
+		if err != nil {
+			return reportStyle{}, fmt.Errorf("%w: timezone: %w", models.ErrInvalidReportOptions, err)
+		}
+		style.location = loc
+	}
+	style.asOf = latestCheck(groups).In(style.location)
+
+	return style, nil
+}
+
+// parseHexColor converts "#RRGGBB" or "RRGGBB" into RGB components.
+func parseHexColor(hex string) ([3]int, error) {
+	hex = strings.TrimPrefix(hex, "#")
+	if len(hex) != 6 {
+		return [3]int{}, fmt.Errorf("expected 6 hex digits, got %q", hex)
+	}
+
+	value, err := strconv.ParseUint(hex, 16, 32)
 	if err != nil {
-		slog.Error("failed to generate multi-group PDF report", slog.Any("error", err))
-		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+		return [3]int{}, fmt.Errorf("invalid hex color %q", hex)
 	}
 
-	slog.Debug("multi-group PDF report generated",
-		slog.Int("groups", len(linksSlice)),
-		slog.Int("size_bytes", buf.Len()),
-	)
+	return [3]int{int(value >> 16 & 0xFF), int(value >> 8 & 0xFF), int(value & 0xFF)}, nil
+}
 
-	return &buf, nil
+func (g *GoFPDFGenerator) setFooter(pdf *gofpdf.Fpdf, style reportStyle) {
+	if style.footerText == "" {
+		return
+	}
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-footerHeight)
+		pdf.SetFont(familyStr, "", 8)
+		pdf.SetTextColor(128, 128, 128)
+		pdf.CellFormat(0, 10, style.footerText, "", 0, "C", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+	})
 }
 
-func (g *GoFPDFGenerator) addHeaderWithGroup(pdf *gofpdf.Fpdf, groupNum int) {
+// addSummaryPage draws the opening page of a multi-group report: a table with the number, name,
+// link count and availability of every group, each row linking to the group section, and a total row.
+func (g *GoFPDFGenerator) addSummaryPage(pdf *gofpdf.Fpdf, style reportStyle, groups []models.Links, sections []int) {
+	pdf.AddPage()
+
+	heading := summaryTitle
+	if style.title != title {
+		heading = style.title + " - SUMMARY"
+	}
 	pdf.SetFont(familyStr, styleStr, size)
-	pdf.SetTextColor(0, 0, 128)
-	pdf.CellFormat(0, 15, fmt.Sprintf("%s %d", title, groupNum), "", 0, "C", false, 0, "")
-	pdf.Ln(20)
-}
+	pdf.SetTextColor(style.accentColor[0], style.accentColor[1], style.accentColor[2])
+	pdf.CellFormat(0, 15, heading, "", 0, "C", false, 0, "")
+	pdf.Ln(12)
 
-func (g *GoFPDFGenerator) calculateStatistic(links models.Links) *pdfStatistic {
-	res := &pdfStatistic{}
-	res.total = len(links.Links)
+	pdf.SetFont(familyStr, "", 9)
+	pdf.SetTextColor(96, 96, 96)
+	pdf.CellFormat(0, 6, "Checked as of: "+style.asOf.Format(timeLayout), "", 0, "C", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+	pdf.Ln(14)
 
-	for _, link := range links.Links {
-		if link.Status == models.LinkStatusAvailable {
-			res.available++
-			res.averageAvailableSpeed += link.Duration
-		} else {
-			res.notAvailable++
-			res.averageNotAvailableSpeed += link.Duration
+	pdf.SetFont(familyStr, styleStr, 10)
+	pdf.SetFillColor(200, 200, 200)
+
+	widths := []float64{20, 80, 25, 30, 35}
+
+	addSummaryTableHeader(pdf, widths)
+
+	pdf.SetFont(familyStr, "", 9)
+	pdf.SetFillColor(255, 255, 255)
+
+	for i, group := range groups {
+		statistics := stats.Calculate(group.Links)
+
+		name := group.Name
+		if name == "" {
+			name = "-"
+		}
+		pdf.CellFormat(widths[0], 6, strconv.Itoa(group.LinksNum), "1", 0, "C", true, sections[i], "")
+		pdf.CellFormat(widths[1], 6, truncateString(name, 48), "1", 0, "L", true, sections[i], "")
+		pdf.CellFormat(widths[2], 6, strconv.Itoa(statistics.Total), "1", 0, "C", true, 0, "")
+		pdf.CellFormat(widths[3], 6, strconv.Itoa(statistics.Available), "1", 0, "C", true, 0, "")
+
+		if statistics.NotAvailable > 0 {
+			pdf.SetTextColor(255, 0, 0)
+		}
+		pdf.CellFormat(widths[4], 6, fmt.Sprintf("%.1f%%", stats.AvailabilityPercent(statistics)), "1", 0, "C", true, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+		pdf.Ln(6)
+
+		if needsPageBreak(pdf, 6) {
+			pdf.AddPage()
+			pdf.SetFont(familyStr, styleStr, 10)
+			pdf.SetFillColor(200, 200, 200)
+			addSummaryTableHeader(pdf, widths)
+			pdf.SetFont(familyStr, "", 9)
+			pdf.SetFillColor(255, 255, 255)
 		}
 	}
 
-	if res.available > 0 {
-		res.averageAvailableSpeed /= time.Duration(res.available)
+	total := stats.CalculateGroups(groups)
+	pdf.SetFont(familyStr, styleStr, 9)
+	pdf.CellFormat(widths[0]+widths[1], 6, fmt.Sprintf("TOTAL (%d groups)", total.Groups), "1", 0, "L", true, 0, "")
+	pdf.CellFormat(widths[2], 6, strconv.Itoa(total.Total), "1", 0, "C", true, 0, "")
+	pdf.CellFormat(widths[3], 6, strconv.Itoa(total.Available), "1", 0, "C", true, 0, "")
+	pdf.CellFormat(widths[4], 6, fmt.Sprintf("%.1f%%", stats.AvailabilityPercent(total)), "1", 0, "C", true, 0, "")
+	pdf.Ln(6)
+}
+
+// addSummaryTableHeader draws the header row of the groups table on the summary page.
+func addSummaryTableHeader(pdf *gofpdf.Fpdf, widths []float64) {
+	headers := []string{"Group", "Name", "Links", "Available", "Availability"}
+	for i, header := range headers {
+		pdf.CellFormat(widths[i], 8, header, "1", 0, "C", true, 0, "")
 	}
-	if res.notAvailable > 0 {
-		res.averageNotAvailableSpeed /= time.Duration(res.notAvailable)
+	pdf.Ln(8)
+}
+
+func (g *GoFPDFGenerator) addHeaderWithGroup(pdf *gofpdf.Fpdf, style reportStyle, links models.Links) {
+	pdf.SetFont(familyStr, styleStr, size)
+	pdf.SetTextColor(style.accentColor[0], style.accentColor[1], style.accentColor[2])
+	pdf.CellFormat(0, 15, fmt.Sprintf("%s %d", style.title, links.LinksNum), "", 0, "C", false, 0, "")
+	pdf.Ln(12)
+
+	if links.Name != "" {
+		pdf.SetFont(familyStr, styleStr, 12)
+		pdf.CellFormat(0, 8, links.Name, "", 0, "C", false, 0, "")
+		pdf.Ln(8)
 	}
 
-	return res
+	pdf.SetFont(familyStr, "", 9)
+	pdf.SetTextColor(96, 96, 96)
+	pdf.CellFormat(0, 6, "Checked as of: "+style.asOf.Format(timeLayout), "", 0, "C", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+	pdf.Ln(10)
 }
 
-func (g *GoFPDFGenerator) addStatistics(pdf *gofpdf.Fpdf, stats *pdfStatistic) {
+func (g *GoFPDFGenerator) addStatistics(pdf *gofpdf.Fpdf, statistics models.Statistics) {
 	pdf.SetFont(familyStr, styleStr, 16)
 	pdf.CellFormat(0, 10, "STATISTICS SUMMARY", "", 0, "L", false, 0, "")
 	pdf.Ln(12)
@@ -159,83 +414,263 @@ func (g *GoFPDFGenerator) addStatistics(pdf *gofpdf.Fpdf, stats *pdfStatistic) {
 	pdf.SetFillColor(255, 255, 255)
 
 	pdf.CellFormat(80, 8, "Available Links", "1", 0, "L", true, 0, "")
-	pdf.CellFormat(50, 8, fmt.Sprintf("%d", stats.available), "1", 0, "C", true, 0, "")
-	pdf.CellFormat(60, 8, stats.averageAvailableSpeed.Round(time.Millisecond).String(), "1", 0, "C", true, 0, "")
+	pdf.CellFormat(50, 8, fmt.Sprintf("%d", statistics.Available), "1", 0, "C", true, 0, "")
+	pdf.CellFormat(60, 8, statistics.AverageAvailableDuration.Round(time.Millisecond).String(), "1", 0, "C", true, 0, "")
 	pdf.Ln(8)
 
 	pdf.CellFormat(80, 8, "Not Available Links", "1", 0, "L", true, 0, "")
-	pdf.CellFormat(50, 8, fmt.Sprintf("%d", stats.notAvailable), "1", 0, "C", true, 0, "")
-	pdf.CellFormat(60, 8, stats.averageNotAvailableSpeed.Round(time.Millisecond).String(), "1", 0, "C", true, 0, "")
+	pdf.CellFormat(50, 8, fmt.Sprintf("%d", statistics.NotAvailable), "1", 0, "C", true, 0, "")
+	pdf.CellFormat(60, 8, statistics.AverageNotAvailableDuration.Round(time.Millisecond).String(), "1", 0, "C", true, 0, "")
 	pdf.Ln(8)
 
+	if statistics.Slow > 0 {
+		pdf.CellFormat(80, 8, "  of them Slow", "1", 0, "L", true, 0, "")
+		pdf.CellFormat(50, 8, fmt.Sprintf("%d", statistics.Slow), "1", 0, "C", true, 0, "")
+		pdf.CellFormat(60, 8, "-", "1", 0, "C", true, 0, "")
+		pdf.Ln(8)
+	}
+
+	if statistics.Skipped > 0 {
+		pdf.CellFormat(80, 8, "Skipped Links", "1", 0, "L", true, 0, "")
+		pdf.CellFormat(50, 8, fmt.Sprintf("%d", statistics.Skipped), "1", 0, "C", true, 0, "")
+		pdf.CellFormat(60, 8, "-", "1", 0, "C", true, 0, "")
+		pdf.Ln(8)
+	}
+
+	if statistics.Ignored > 0 {
+		pdf.CellFormat(80, 8, "Ignored Links", "1", 0, "L", true, 0, "")
+		pdf.CellFormat(50, 8, fmt.Sprintf("%d", statistics.Ignored), "1", 0, "C", true, 0, "")
+		pdf.CellFormat(60, 8, "-", "1", 0, "C", true, 0, "")
+		pdf.Ln(8)
+	}
+
 	pdf.SetFont(familyStr, styleStr, 12)
 	pdf.CellFormat(80, 8, "TOTAL", "1", 0, "L", true, 0, "")
-	pdf.CellFormat(50, 8, fmt.Sprintf("%d", stats.total), "1", 0, "C", true, 0, "")
+	pdf.CellFormat(50, 8, fmt.Sprintf("%d", statistics.Total), "1", 0, "C", true, 0, "")
 	pdf.CellFormat(60, 8, "-", "1", 0, "C", true, 0, "")
-	pdf.Ln(20)
+	pdf.Ln(8)
+
+	// percentiles expose tail latency the average hides
+	pdf.SetFont(familyStr, "", 12)
+	for _, p := range statistics.AvailableDurationPercentiles {
+		pdf.CellFormat(80, 8, fmt.Sprintf("Available p%g", p.Percentile), "1", 0, "L", true, 0, "")
+		pdf.CellFormat(50, 8, "-", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(60, 8, p.Duration.Round(time.Millisecond).String(), "1", 0, "C", true, 0, "")
+		pdf.Ln(8)
+	}
+	pdf.Ln(12)
 }
 
-func (g *GoFPDFGenerator) addDetailedLinks(pdf *gofpdf.Fpdf, links models.Links) {
+// addHostStatistics draws a table of link counts, availability and average check time per host.
+func (g *GoFPDFGenerator) addHostStatistics(pdf *gofpdf.Fpdf, hosts []models.HostStatistics) {
 	pdf.SetFont(familyStr, styleStr, 16)
 	pdf.SetTextColor(0, 0, 0)
-	pdf.CellFormat(0, 10, "DETAILED LINK REPORT", "", 0, "L", false, 0, "")
+	pdf.CellFormat(0, 10, "HOSTS SUMMARY", "", 0, "L", false, 0, "")
 	pdf.Ln(12)
 
 	pdf.SetFont(familyStr, styleStr, 10)
 	pdf.SetFillColor(200, 200, 200)
 
-	widths := []float64{60, 25, 25, 30, 40}
+	widths := []float64{75, 25, 30, 30, 30}
+
+	addHostsTableHeader(pdf, widths)
+
+	pdf.SetFont(familyStr, "", 9)
+	pdf.SetFillColor(255, 255, 255)
+
+	for _, host := range hosts {
+		name := host.Host
+		if name == "" {
+			name = "-"
+		}
+		pdf.CellFormat(widths[0], 6, truncateString(name, 45), "1", 0, "L", true, 0, "")
+		pdf.CellFormat(widths[1], 6, strconv.Itoa(host.Total), "1", 0, "C", true, 0, "")
+		pdf.CellFormat(widths[2], 6, strconv.Itoa(host.Available), "1", 0, "C", true, 0, "")
+
+		if host.NotAvailable > 0 {
+			pdf.SetTextColor(255, 0, 0)
+		}
+		pdf.CellFormat(widths[3], 6, fmt.Sprintf("%.1f%%", host.AvailabilityPercent), "1", 0, "C", true, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+
+		pdf.CellFormat(widths[4], 6, host.AverageDuration.Round(time.Millisecond).String(), "1", 0, "C", true, 0, "")
+		pdf.Ln(6)
 
-	pdf.CellFormat(widths[0], 8, "URL", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(widths[1], 8, "Status", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(widths[2], 8, "Duration", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(widths[3], 8, "Checked At", "1", 0, "C", true, 0, "")
+		if needsPageBreak(pdf, 6) {
+			pdf.AddPage()
+			pdf.SetFont(familyStr, styleStr, 10)
+			pdf.SetFillColor(200, 200, 200)
+			addHostsTableHeader(pdf, widths)
+			pdf.SetFont(familyStr, "", 9)
+			pdf.SetFillColor(255, 255, 255)
+		}
+	}
+	pdf.Ln(14)
+}
+
+// addHostsTableHeader draws the header row of the hosts table.
+func addHostsTableHeader(pdf *gofpdf.Fpdf, widths []float64) {
+	headers := []string{"Host", "Links", "Available", "Availability", "Average Time"}
+	for i, header := range headers {
+		pdf.CellFormat(widths[i], 8, header, "1", 0, "C", true, 0, "")
+	}
 	pdf.Ln(8)
+}
+
+func (g *GoFPDFGenerator) addDetailedLinks(ctx context.Context, pdf *gofpdf.Fpdf, style reportStyle, links models.Links) error {
+	heading := "DETAILED LINK REPORT"
+	rows := links.Links
+	if style.onlyFailures {
+		heading = "FAILED LINKS"
+		rows = failedLinks(links.Links)
+	}
+
+	pdf.SetFont(familyStr, styleStr, 16)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.CellFormat(0, 10, heading, "", 0, "L", false, 0, "")
+	pdf.Ln(12)
+
+	if len(rows) == 0 {
+		pdf.SetFont(familyStr, "", 10)
+		pdf.CellFormat(0, 6, "No failed links", "", 0, "L", false, 0, "")
+		pdf.Ln(8)
+		return nil
+	}
+
+	pdf.SetFont(familyStr, styleStr, 10)
+	pdf.SetFillColor(200, 200, 200)
+
+	widths := []float64{44, 22, 18, 38, 28, 18, 22}
+
+	addLinksTableHeader(pdf, widths)
 
 	pdf.SetFont(familyStr, "", 8)
 	fill := false
 
-	for _, link := range links.Links {
+	for _, link := range rows {
 		if fill {
 			pdf.SetFillColor(240, 240, 240)
 		} else {
 			pdf.SetFillColor(255, 255, 255)
 		}
 
-		pdf.CellFormat(widths[0], 6, truncateString(link.URL, 50), "1", 0, "L", fill, 0, "")
+		pdf.CellFormat(widths[0], 6, truncateString(link.URL, 30), "1", 0, "L", fill, 0, "")
 
-		statusColor := getStatusColor(link.Status)
+		statusColor := getLinkColor(link)
 		pdf.SetTextColor(statusColor[0], statusColor[1], statusColor[2])
 		pdf.CellFormat(widths[1], 6, string(link.Status), "1", 0, "C", fill, 0, "")
-		pdf.SetTextColor(0, 0, 0)
-
+		if !link.Slow {
+			pdf.SetTextColor(0, 0, 0)
+		}
 		pdf.CellFormat(widths[2], 6, link.Duration.Round(time.Millisecond).String(), "1", 0, "C", fill, 0, "")
+		pdf.SetTextColor(0, 0, 0)
 
-		checkedTime := link.CheckedAt.Format("15:04:05 02.01.2006")
+		checkedTime := link.CheckedAt.In(style.location).Format(timeLayout)
 		pdf.CellFormat(widths[3], 6, checkedTime, "1", 0, "C", fill, 0, "")
 
+		lastModified := "-"
+		if link.LastModified != nil {
+			lastModified = link.LastModified.In(style.location).Format(dateLayout)
+		}
+		pdf.CellFormat(widths[4], 6, lastModified, "1", 0, "C", fill, 0, "")
+
+		pdf.CellFormat(widths[5], 6, strconv.Itoa(link.RedirectCount), "1", 0, "C", fill, 0, "")
+		pdf.CellFormat(widths[6], 6, formatSize(link.ContentLength), "1", 0, "C", fill, 0, "")
+
 		pdf.Ln(6)
 		fill = !fill
 
-		if pdf.GetY() > 260 {
+		if needsPageBreak(pdf, 6) {
+			// large tables are where rendering time goes, so stop at a page break once ctx is done
+			if err := ctx.Err(); err != nil {
+				return err
+			}
 			pdf.AddPage()
 			pdf.SetFont(familyStr, styleStr, 10)
 			pdf.SetFillColor(200, 200, 200)
-			pdf.CellFormat(widths[0], 8, "URL", "1", 0, "C", true, 0, "")
-			pdf.CellFormat(widths[1], 8, "Status", "1", 0, "C", true, 0, "")
-			pdf.CellFormat(widths[2], 8, "Duration", "1", 0, "C", true, 0, "")
-			pdf.CellFormat(widths[3], 8, "Checked At", "1", 0, "C", true, 0, "")
-			pdf.Ln(8)
+			addLinksTableHeader(pdf, widths)
 			pdf.SetFont(familyStr, "", 8)
 		}
 	}
+
+	return nil
 }
 
+// failedLinks returns the not available links that are not ignored in their original order.
+func failedLinks(links []models.Link) []models.Link {
+	var failed []models.Link
+	for _, link := range links {
+		if link.Status == models.LinkStatusNotAvailable && !link.Ignored {
+			failed = append(failed, link)
+		}
+	}
+	return failed
+}
+
+// needsPageBreak reports whether a table row of rowHeight would no longer fit above the bottom
+// margin and footer of the current page, computed from the page size so that any page format works.
+func needsPageBreak(pdf *gofpdf.Fpdf, rowHeight float64) bool {
+	_, pageHeight := pdf.GetPageSize()
+	_, _, _, bottomMargin := pdf.GetMargins()
+	return pdf.GetY()+rowHeight > pageHeight-max(bottomMargin, footerHeight)
+}
+
+// addLinksTableHeader draws the header row of the detailed links table.
+func addLinksTableHeader(pdf *gofpdf.Fpdf, widths []float64) {
+	headers := []string{"URL", "Status", "Duration", "Checked At", "Last Modified", "Redirects", "Size"}
+	for i, header := range headers {
+		pdf.CellFormat(widths[i], 8, header, "1", 0, "C", true, 0, "")
+	}
+	pdf.Ln(8)
+}
+
+// formatSize formats a content size in bytes with a binary unit, "-" if the size is unknown.
+func formatSize(size int64) string {
+	if size <= 0 {
+		return "-"
+	}
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit && exp < 3; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGT"[exp])
+}
+
+// truncateString shortens s to at most maxLen runes, marking the cut with "...".
+// When maxLen is too small to fit the ellipsis, s is cut without it.
 func truncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
+	if maxLen <= 0 {
+		return ""
+	}
+
+	runes := []rune(s)
+	if len(runes) <= maxLen {
 		return s
 	}
-	return s[:maxLen-3] + "..."
+
+	const ellipsis = "..."
+	if maxLen <= len(ellipsis) {
+		return string(runes[:maxLen])
+	}
+
+	return string(runes[:maxLen-len(ellipsis)]) + ellipsis
+}
+
+// getLinkColor returns the color of the status of link, gray for ignored links and amber for
+// available links flagged as slow.
+func getLinkColor(link models.Link) [3]int {
+	if link.Ignored {
+		return [3]int{128, 128, 128} // Gray
+	}
+	if link.Slow {
+		return [3]int{204, 153, 0} // Amber
+	}
+	return getStatusColor(link.Status)
 }
 
 func getStatusColor(status models.LinkStatus) [3]int {
@@ -244,6 +679,10 @@ func getStatusColor(status models.LinkStatus) [3]int {
 		return [3]int{0, 128, 0} // Green
 	case models.LinkStatusNotAvailable:
 		return [3]int{255, 0, 0} // Red
+	case models.LinkStatusUnsupportedScheme, models.LinkStatusBlockedPrivateAddress:
+		return [3]int{128, 128, 128} // Gray
+	case models.LinkStatusSkipped, models.LinkStatusSkippedDenylist, models.LinkStatusRateLimited:
+		return [3]int{255, 140, 0} // Orange
 	default:
 		return [3]int{0, 0, 0} // Black
 	}
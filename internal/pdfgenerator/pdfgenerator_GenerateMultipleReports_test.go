@@ -0,0 +1,97 @@
+package pdfgenerator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestGoFPDFGenerator_GenerateMultipleReports(t *testing.T) {
+	checkedAt := time.Date(2024, time.January, 15, 10, 30, 0, 0, time.UTC)
+	groups := []models.Links{
+		{
+			LinksNum: 1,
+			Name:     "docs",
+			Links: []models.Link{
+				{URL: "https://example.com", Status: models.LinkStatusAvailable, CheckedAt: checkedAt},
+				{URL: "https://example.org", Status: models.LinkStatusNotAvailable, CheckedAt: checkedAt.Add(time.Second)},
+			},
+		},
+		{
+			LinksNum: 2,
+			Links: []models.Link{
+				{URL: "https://example.net", Status: models.LinkStatusAvailable, CheckedAt: checkedAt.Add(-time.Hour)},
+			},
+		},
+	}
+	opts := models.ReportOptions{Timezone: "UTC"}
+	ctx := context.Background()
+
+	g := NewGoFPDFGenerator()
+	var first, second bytes.Buffer
+	if err := g.GenerateMultipleReports(ctx, &first, groups, opts); err != nil {
+		t.Fatalf("GenerateMultipleReports() error = %v, want nil", err)
+	}
+	if err := g.GenerateMultipleReports(ctx, &second, groups, opts); err != nil {
+		t.Fatalf("GenerateMultipleReports() error = %v, want nil", err)
+	}
+
+	if !bytes.Equal(first.Bytes(), second.Bytes()) {
+		t.Error("GenerateMultipleReports() produced different PDFs for the same input")
+	}
+	if !bytes.Contains(first.Bytes(), []byte("D:20240115103001")) {
+		t.Error("GenerateMultipleReports() creation date is not the latest check time")
+	}
+
+	// summary page, one page per group and the hosts page
+	if pages := bytes.Count(first.Bytes(), []byte("/Type /Page\n")); pages != 4 {
+		t.Errorf("GenerateMultipleReports() pages = %d, want 4", pages)
+	}
+	if !bytes.Contains(first.Bytes(), []byte("/Annots")) {
+		t.Error("GenerateMultipleReports() summary page has no links to group sections")
+	}
+
+	var single bytes.Buffer
+	if err := g.GenerateMultipleReports(ctx, &single, groups[:1], opts); err != nil {
+		t.Fatalf("GenerateMultipleReports() error = %v, want nil", err)
+	}
+	if pages := bytes.Count(single.Bytes(), []byte("/Type /Page\n")); pages != 2 {
+		t.Errorf("GenerateMultipleReports() single group pages = %d, want 2 without summary page", pages)
+	}
+
+	// only failures keeps the table of a large, mostly available group on its first page
+	large := models.Links{LinksNum: 3, Links: []models.Link{
+		{URL: "https://example.org", Status: models.LinkStatusNotAvailable, CheckedAt: checkedAt},
+	}}
+	for i := 0; i < 100; i++ {
+		large.Links = append(large.Links, models.Link{URL: "https://example.com", Status: models.LinkStatusAvailable, CheckedAt: checkedAt})
+	}
+	var full, failures bytes.Buffer
+	if err := g.GenerateMultipleReports(ctx, &full, []models.Links{large}, opts); err != nil {
+		t.Fatalf("GenerateMultipleReports() error = %v, want nil", err)
+	}
+	opts.OnlyFailures = true
+	if err := g.GenerateMultipleReports(ctx, &failures, []models.Links{large}, opts); err != nil {
+		t.Fatalf("GenerateMultipleReports() error = %v, want nil", err)
+	}
+	if pages := bytes.Count(full.Bytes(), []byte("/Type /Page\n")); pages <= 2 {
+		t.Errorf("GenerateMultipleReports() full report pages = %d, want more than 2", pages)
+	}
+	if pages := bytes.Count(failures.Bytes(), []byte("/Type /Page\n")); pages != 2 {
+		t.Errorf("GenerateMultipleReports() only failures pages = %d, want 2", pages)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	var stopped bytes.Buffer
+	if err := g.GenerateMultipleReports(canceled, &stopped, []models.Links{large}, opts); !errors.Is(err, context.Canceled) {
+		t.Errorf("GenerateMultipleReports() error = %v, want context.Canceled", err)
+	}
+	if stopped.Len() != 0 {
+		t.Errorf("GenerateMultipleReports() wrote %d bytes after cancellation, want 0", stopped.Len())
+	}
+}
@@ -0,0 +1,36 @@
+package pdfgenerator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestValidateOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    models.ReportOptions
+		wantErr bool
+	}{
+		{name: "empty options", opts: models.ReportOptions{}},
+		{name: "valid options", opts: models.ReportOptions{Title: "Audit", AccentColor: "#336699", Timezone: "Europe/Moscow"}},
+		{name: "invalid accent color", opts: models.ReportOptions{AccentColor: "blue"}, wantErr: true},
+		{name: "unknown timezone", opts: models.ReportOptions{Timezone: "Nope/Zone"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateOptions(tt.opts)
+			if tt.wantErr {
+				if !errors.Is(err, models.ErrInvalidReportOptions) {
+					t.Errorf("ValidateOptions() error = %v, want ErrInvalidReportOptions", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("ValidateOptions() error = %v, want nil", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package pdfgenerator
+
+import (
+	"testing"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+func TestNeedsPageBreak(t *testing.T) {
+	tests := []struct {
+		name        string
+		orientation string
+		size        string
+		y           float64
+		want        bool
+	}{
+		{name: "a4 portrait with room", orientation: "P", size: "A4", y: 260, want: false},
+		{name: "a4 portrait at bottom margin", orientation: "P", size: "A4", y: 272, want: true},
+		{name: "a4 landscape with room", orientation: "L", size: "A4", y: 180, want: false},
+		{name: "a4 landscape at bottom margin", orientation: "L", size: "A4", y: 185, want: true},
+		{name: "a3 portrait below a4 height", orientation: "P", size: "A3", y: 350, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pdf := gofpdf.New(tt.orientation, unitStr, tt.size, fontDirStr)
+			pdf.AddPage()
+			pdf.SetY(tt.y)
+
+			if got := needsPageBreak(pdf, 6); got != tt.want {
+				t.Errorf("needsPageBreak() at y=%v = %v, want %v", tt.y, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("respects larger bottom margin", func(t *testing.T) {
+		pdf := gofpdf.New(orientationStr, unitStr, sizeStr, fontDirStr)
+		pdf.SetAutoPageBreak(true, 40)
+		pdf.AddPage()
+		pdf.SetY(255)
+
+		if !needsPageBreak(pdf, 6) {
+			t.Error("needsPageBreak() = false, want true inside a 40mm bottom margin")
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package pdfgenerator
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncateString(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		maxLen int
+		want   string
+	}{
+		{
+			name:   "short string is unchanged",
+			input:  "https://example.com",
+			maxLen: 50,
+			want:   "https://example.com",
+		},
+		{
+			name:   "string of exact length is unchanged",
+			input:  "abcdef",
+			maxLen: 6,
+			want:   "abcdef",
+		},
+		{
+			name:   "long ascii string is truncated with ellipsis",
+			input:  "https://example.com/very/long/path",
+			maxLen: 15,
+			want:   "https://exam...",
+		},
+		{
+			name:   "cyrillic url is cut on rune boundary",
+			input:  "https://пример.рф/страница",
+			maxLen: 12,
+			want:   "https://п...",
+		},
+		{
+			name:   "emoji url is cut on rune boundary",
+			input:  "https://😀😀😀😀😀.ws/path",
+			maxLen: 13,
+			want:   "https://😀😀...",
+		},
+		{
+			name:   "max length smaller than ellipsis",
+			input:  "https://example.com",
+			maxLen: 2,
+			want:   "ht",
+		},
+		{
+			name:   "max length equal to ellipsis",
+			input:  "привет мир",
+			maxLen: 3,
+			want:   "при",
+		},
+		{
+			name:   "zero max length",
+			input:  "https://example.com",
+			maxLen: 0,
+			want:   "",
+		},
+		{
+			name:   "negative max length",
+			input:  "https://example.com",
+			maxLen: -1,
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateString(tt.input, tt.maxLen)
+			if got != tt.want {
+				t.Errorf("truncateString(%q, %d) = %q, want %q", tt.input, tt.maxLen, got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("truncateString(%q, %d) returned invalid UTF-8: %q", tt.input, tt.maxLen, got)
+			}
+		})
+	}
+}
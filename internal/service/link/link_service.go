@@ -1,73 +1,338 @@
 package link
 
 import (
-	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/polonkoevv/linkchecker/internal/crawler"
+	"github.com/polonkoevv/linkchecker/internal/hostpacer"
+	"github.com/polonkoevv/linkchecker/internal/idempotency"
+	"github.com/polonkoevv/linkchecker/internal/jobs"
 	"github.com/polonkoevv/linkchecker/internal/models"
 	"github.com/polonkoevv/linkchecker/internal/pdfgenerator"
+	"github.com/polonkoevv/linkchecker/internal/sitemap"
+	"github.com/polonkoevv/linkchecker/internal/stats"
 	"github.com/polonkoevv/linkchecker/internal/urlchecker"
 )
 
 type linkRepository interface {
-	InsertMany(links []models.Link) (int, error)
+	InsertNamed(name string, links []models.Link) (int, error)
+	Replace(num int, links []models.Link) error
+	SetIgnored(num int, url string, ignored bool) error
 	GetByNums(linksNum []int) ([]models.Links, error)
+	GetByNum(num int) (models.Links, error)
 	GetAll() ([]models.Links, error)
+	Summaries() ([]models.GroupSummary, error)
+	GetBetween(from, to time.Time) ([]models.Links, error)
+	Search(q models.SearchQuery) ([]models.Link, error)
+	LatestByURLs(urls []string) (map[string]models.Link, error)
+	Import(groups []models.Links, mode models.ImportMode) ([]int, error)
 }
 
 type urlChecker interface {
 	CheckURLWithContext(ctx context.Context, rawURL string) models.Link
 }
 
-type pdfGenerator interface {
-	GenerateMultipleReports(linksSlice []models.Links) (*bytes.Buffer, error)
+type reportGenerator interface {
+	GenerateMultipleReports(ctx context.Context, w io.Writer, linksSlice []models.Links, opts models.ReportOptions) error
+}
+
+type jobStore interface {
+	Create(total int, cancel context.CancelFunc) (models.Job, error)
+	Get(id string) (models.Job, error)
+	Update(id string, fn func(job *models.Job)) error
+	Cancel(id string) error
+	Wait(ctx context.Context, id string) (models.Job, error)
+}
+
+type idempotencyStore interface {
+	Acquire(ctx context.Context, key string) (release func(), err error)
+	Get(key string) (int, bool)
+	Put(key string, linksNum int)
+}
+
+type hostPacer interface {
+	Wait(ctx context.Context, host string) error
+}
+
+type sitemapFetcher interface {
+	Fetch(ctx context.Context, sitemapURL string) ([]string, error)
+}
+
+type pageLinkExtractor interface {
+	ExtractLinks(ctx context.Context, pageURL string) ([]string, error)
 }
 
 // LinkService contains business logic for checking links and generating reports.
 type Service struct {
-	repository   linkRepository
-	urlChecker   urlChecker
-	pdfGenerator pdfGenerator
+	repository     linkRepository
+	urlChecker     urlChecker
+	pdfGenerator   reportGenerator
+	htmlGenerator  reportGenerator
+	sitemapFetcher sitemapFetcher
+	linkExtractor  pageLinkExtractor
+	jobs           jobStore
+	idempotency    idempotencyStore
+	// hostPacer spaces out checks of the same host, nil disables it.
+	hostPacer hostPacer
+	// skipHosts holds glob patterns of hosts that are never checked.
+	skipHosts []string
+	// slowThreshold flags available links checked slower than it, zero disables it.
+	slowThreshold time.Duration
+	// collapseWWW treats www and non-www hosts as the same for deduplication and host statistics.
+	collapseWWW bool
+	// percentiles of available check durations computed in statistics, nil for the stats defaults.
+	percentiles []float64
+
+	// monitorURLs are checked into one group by CheckMonitor, empty disables the monitor.
+	monitorURLs     []string
+	monitorName     string
+	monitorInterval time.Duration
+	// monitorMu guards the group the monitor checks into and when it was last checked.
+	monitorMu        sync.Mutex
+	monitorNum       int
+	monitorCheckedAt time.Time
+
+	workerCount    int
+	maxWorkerCount int
+	// channelBuffer sizes the jobs and results channels of a check, see Config.ChannelBuffer.
+	channelBuffer int
+
+	// pool feeds long-lived workers shared by all requests, nil starts workers per request.
+	pool chan poolTask
+	// poolWorkers is the number of workers reading from pool.
+	poolWorkers int
+	// closePool stops the shared workers once Shutdown drained all checks.
+	closePool sync.Once
+
+	// checkSlots bounds URL checks in flight across all requests, nil means unlimited.
+	checkSlots chan struct{}
+	// checkTimeout bounds a single URL check, zero means only the request context applies.
+	checkTimeout time.Duration
 
-	workerCount int
+	maxReportGroups int
+	maxReportLinks  int
+
+	// batchMu guards closing and batches.Add against the Wait in Shutdown.
+	batchMu sync.Mutex
+	closing bool
+	// batches tracks CheckMany calls and async jobs in progress.
+	batches sync.WaitGroup
+
+	// activeBatches, activeChecks and completedChecks count load for Status.
+	activeBatches   atomic.Int64
+	activeChecks    atomic.Int64
+	completedChecks atomic.Int64
 }
 
 const defaultWorkerCount = 4
 
-// New creates a LinkService with the given repository, PDF generator and worker pool size.
-func New(repo linkRepository, workerCount int) *Service {
+// defaultMonitorName names the monitor group unless Config.MonitorName is set.
+const defaultMonitorName = "monitor"
+
+// defaultBufferPerWorker sizes the jobs and results channels of a check per worker
+// when Config.ChannelBuffer is ProportionalChannelBuffer.
+const defaultBufferPerWorker = 2
+
+// ProportionalChannelBuffer makes Config.ChannelBuffer size the jobs and results channels of a
+// check to defaultBufferPerWorker times its worker count.
+const ProportionalChannelBuffer = -1
+
+// Config holds worker pool and load limits of the Service.
+type Config struct {
+	// WorkerCount is the default worker pool size of a single check.
+	WorkerCount int
+	// MaxWorkerCount is the upper bound for per-request worker overrides.
+	MaxWorkerCount int
+	// MaxConcurrentChecks bounds URL checks in flight across all requests, zero disables it.
+	MaxConcurrentChecks int
+	// CheckTimeout bounds a single URL check, zero disables it.
+	CheckTimeout time.Duration
+	// MaxReportGroups bounds the number of groups in a report over all groups, zero disables it.
+	MaxReportGroups int
+	// MaxReportLinks bounds the total number of links in a single report, zero disables it.
+	MaxReportLinks int
+	// IdempotencyKeyTTL is how long CheckMany remembers idempotency keys, zero disables them.
+	IdempotencyKeyTTL time.Duration
+	// PerHostDelay and PerHostJitter space out consecutive checks of the same host by the delay
+	// plus a random part of the jitter. Zero values disable pacing.
+	PerHostDelay  time.Duration
+	PerHostJitter time.Duration
+	// SkipHosts holds glob patterns ("*.internal.example.com") of hosts that are never checked,
+	// links to them are reported as skipped_denylist.
+	SkipHosts []string
+	// MaxBodyBytes caps response bodies read by page crawls, zero keeps the crawler default.
+	// Checks take the limit as a checker option.
+	MaxBodyBytes int64
+	// SlowThreshold flags available links whose check took longer than it as slow, zero disables it.
+	SlowThreshold time.Duration
+	// CollapseWWW treats "www.example.com" and "example.com" links as duplicates and counts them
+	// under one host in reports.
+	CollapseWWW bool
+	// Percentiles (0-100] of available check durations computed in statistics and reports,
+	// empty uses stats.DefaultPercentiles.
+	Percentiles []float64
+	// SharedWorkers starts that many long-lived workers that checks of all requests are fed into,
+	// instead of starting workers per request. Zero disables the shared pool.
+	SharedWorkers int
+	// ChannelBuffer is the buffer size of the jobs and results channels of a check, which lets
+	// workers hand off results while collecting is busy. Zero keeps them unbuffered,
+	// ProportionalChannelBuffer sizes them proportionally to the worker count.
+	ChannelBuffer int
+	// MonitorURLs are checked on every CheckMonitor round into one group named MonitorName,
+	// whose latest results Monitor returns. Empty disables the monitor.
+	MonitorURLs []string
+	MonitorName string
+	// MonitorInterval is how often the caller runs CheckMonitor, reported by Monitor.
+	MonitorInterval time.Duration
+}
+
+// New creates a LinkService with the given repository, limits and URL checker options.
+func New(repo linkRepository, cfg Config, checkerOpts ...urlchecker.Option) *Service {
+	workerCount := cfg.WorkerCount
 	if workerCount <= 0 {
 		workerCount = defaultWorkerCount
 	}
+	maxWorkerCount := cfg.MaxWorkerCount
+	if maxWorkerCount < workerCount {
+		maxWorkerCount = workerCount
+	}
+
+	var checkSlots chan struct{}
+	if cfg.MaxConcurrentChecks > 0 {
+		checkSlots = make(chan struct{}, cfg.MaxConcurrentChecks)
+	}
+
+	checker := urlchecker.NewChecker(checkerOpts...)
+
+	s := &Service{
+		repository:      repo,
+		urlChecker:      checker,
+		pdfGenerator:    pdfgenerator.NewGoFPDFGenerator(pdfgenerator.WithCollapseWWW(cfg.CollapseWWW), pdfgenerator.WithPercentiles(cfg.Percentiles)),
+		htmlGenerator:   pdfgenerator.NewHTMLGenerator(pdfgenerator.WithCollapseWWW(cfg.CollapseWWW), pdfgenerator.WithPercentiles(cfg.Percentiles)),
+		sitemapFetcher:  sitemap.NewFetcher(sitemap.WithTransport(checker.Transport())),
+		linkExtractor:   crawler.NewExtractor(crawler.WithTransport(checker.Transport()), crawler.WithMaxBodySize(cfg.MaxBodyBytes)),
+		jobs:            jobs.NewStore(),
+		idempotency:     idempotency.NewStore(cfg.IdempotencyKeyTTL),
+		workerCount:     workerCount,
+		maxWorkerCount:  maxWorkerCount,
+		channelBuffer:   cfg.ChannelBuffer,
+		checkSlots:      checkSlots,
+		checkTimeout:    cfg.CheckTimeout,
+		maxReportGroups: cfg.MaxReportGroups,
+		maxReportLinks:  cfg.MaxReportLinks,
+		skipHosts:       cfg.SkipHosts,
+		slowThreshold:   cfg.SlowThreshold,
+		collapseWWW:     cfg.CollapseWWW,
+		percentiles:     cfg.Percentiles,
+		monitorURLs:     deduplicateLinks(cfg.MonitorURLs, cfg.CollapseWWW),
+		monitorName:     cfg.MonitorName,
+		monitorInterval: cfg.MonitorInterval,
+	}
+	if s.monitorName == "" {
+		s.monitorName = defaultMonitorName
+	}
+	if pacer := hostpacer.New(cfg.PerHostDelay, cfg.PerHostJitter); pacer != nil {
+		s.hostPacer = pacer
+	}
+	if cfg.SharedWorkers > 0 {
+		s.startPool(cfg.SharedWorkers)
+	}
+	return s
+}
+
+// resolveWorkerCount picks the worker pool size for a single call,
+// clamping the requested override to the configured maximum.
+func (s *Service) resolveWorkerCount(requested int) int {
+	if requested <= 0 {
+		return s.workerCount
+	}
+	if s.maxWorkerCount > 0 && requested > s.maxWorkerCount {
+		return s.maxWorkerCount
+	}
+	return requested
+}
 
-	return &Service{
-		repository:   repo,
-		urlChecker:   urlchecker.NewChecker(),
-		pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
-		workerCount:  workerCount,
+// resolveChannelBuffer returns the buffer size of the jobs and results channels of a check
+// run by workerCount workers.
+func (s *Service) resolveChannelBuffer(workerCount int) int {
+	if s.channelBuffer < 0 {
+		return workerCount * defaultBufferPerWorker
 	}
+	return s.channelBuffer
 }
 
-// duplicateLinks removes duplicate links from the slice.
-func deduplicateLinks(links []string) []string {
+// duplicateLinks removes duplicate links from the slice, keeping the first occurrence.
+// With collapseWWW, links that differ only by a leading "www." of the host are duplicates.
+func deduplicateLinks(links []string, collapseWWW bool) []string {
 	seen := make(map[string]struct{}, len(links))
 	unique := make([]string, 0, len(links))
 
 	for _, raw := range links {
-		if _, ok := seen[raw]; ok {
+		key := raw
+		if collapseWWW {
+			key = collapseWWWKey(raw)
+		}
+		if _, ok := seen[key]; ok {
 			continue
 		}
-		seen[raw] = struct{}{}
+		seen[key] = struct{}{}
 		unique = append(unique, raw)
 	}
 
 	return unique
 }
 
+// collapseWWWKey returns raw with a leading "www." of its host removed, the rest of the link
+// is compared as is.
+func collapseWWWKey(raw string) string {
+	host := urlchecker.Host(raw)
+	collapsed := urlchecker.CollapseWWW(host)
+	if collapsed == host {
+		return raw
+	}
+	// Host lowercases the hostname, so look it up case-insensitively.
+	i := strings.Index(strings.ToLower(raw), host)
+	if i < 0 {
+		return raw
+	}
+	return raw[:i] + collapsed + raw[i+len(host):]
+}
+
+// checkJob is a single URL to check together with its position in the submitted list
+// and the validators from its previous check, if any.
+type checkJob struct {
+	index int
+	url   string
+	etag  string
+	// modifiedSince is sent as If-Modified-Since, zero if the URL was not available before.
+	modifiedSince time.Time
+	// expected is the required status range, zero for the default rule.
+	expected models.StatusRange
+	// method is the HTTP method of the check, empty for HEAD.
+	method string
+	// label is the client-supplied label stored with the link.
+	label string
+	// samples is how many times the URL is checked, at most one means a single check.
+	samples int
+}
+
+// checkResult is a checked link together with its position in the submitted list.
+type checkResult struct {
+	index int
+	link  models.Link
+}
+
 // startWorkers launches worker goroutines to check URLs.
-func (s *Service) startWorkers(ctx context.Context, jobs <-chan string, results chan<- models.Link, workerCount int) *sync.WaitGroup {
+func (s *Service) startWorkers(ctx context.Context, jobs <-chan checkJob, results chan<- checkResult, workerCount int) *sync.WaitGroup {
 	var wg sync.WaitGroup
 	wg.Add(workerCount)
 
@@ -82,114 +347,486 @@ func (s *Service) startWorkers(ctx context.Context, jobs <-chan string, results
 }
 
 // worker processes URLs from jobs channel and sends results.
-func (s *Service) worker(ctx context.Context, id int, jobs <-chan string, results chan<- models.Link) {
-	for raw := range jobs {
-		if ctx.Err() != nil {
-			slog.Warn("worker exiting due to context done", slog.Int("worker_id", id))
+func (s *Service) worker(ctx context.Context, id int, jobs <-chan checkJob, results chan<- checkResult) {
+	for job := range jobs {
+		link, ok := s.runJob(ctx, id, job)
+		if !ok {
 			return
 		}
 
-		link := s.urlChecker.CheckURLWithContext(ctx, raw)
-
 		select {
 		case <-ctx.Done():
-			slog.Warn("worker canceled while sending result", slog.Int("worker_id", id))
+			slog.WarnContext(ctx, "worker canceled while sending result", slog.Int("worker_id", id))
 			return
-		case results <- link:
+		case results <- checkResult{index: job.index, link: link}:
 		}
 	}
 }
 
-// startProducer sends links to jobs channel.
-func (s *Service) startProducer(ctx context.Context, jobs chan<- string, links []string) {
+// runJob checks the URL of job once its host may be checked and a check slot is free.
+// It returns false if ctx is done before the check starts.
+func (s *Service) runJob(ctx context.Context, id int, job checkJob) (models.Link, bool) {
+	if ctx.Err() != nil {
+		slog.WarnContext(ctx, "worker exiting due to context done", slog.Int("worker_id", id))
+		return models.Link{}, false
+	}
+
+	// pace before taking a check slot, so a worker waiting for its host holds no slot
+	if !s.waitForHost(ctx, job.url) {
+		slog.WarnContext(ctx, "worker canceled while waiting for host delay", slog.Int("worker_id", id))
+		return models.Link{}, false
+	}
+
+	if !s.acquireCheckSlot(ctx) {
+		slog.WarnContext(ctx, "worker canceled while waiting for check slot", slog.Int("worker_id", id))
+		return models.Link{}, false
+	}
+	checkCtx := ctx
+	if job.etag != "" {
+		checkCtx = urlchecker.ContextWithETag(checkCtx, job.etag)
+	}
+	if !job.modifiedSince.IsZero() {
+		checkCtx = urlchecker.ContextWithModifiedSince(checkCtx, job.modifiedSince)
+	}
+	if !job.expected.IsZero() {
+		checkCtx = urlchecker.ContextWithExpectedStatus(checkCtx, job.expected)
+	}
+	if job.method != "" {
+		checkCtx = urlchecker.ContextWithMethod(checkCtx, job.method)
+	}
+	s.activeChecks.Add(1)
+	link := s.sampleURL(ctx, checkCtx, job.url, job.samples)
+	s.activeChecks.Add(-1)
+	s.completedChecks.Add(1)
+	link.Label = job.label
+	s.releaseCheckSlot()
+
+	// a check cut short by the budget says nothing about the link
+	if link.Status != models.LinkStatusAvailable && ctx.Err() != nil {
+		link.Status = models.LinkStatusSkipped
+		link.Error = ""
+	}
+	link.Slow = s.isSlow(link)
+
+	return link, true
+}
+
+// isSlow reports whether link is available but its check, or the average of its samples,
+// took longer than the slow threshold.
+func (s *Service) isSlow(link models.Link) bool {
+	return s.slowThreshold > 0 && link.Status == models.LinkStatusAvailable && link.Duration > s.slowThreshold
+}
+
+// checkURL checks url with checkCtx bounded by the per-check timeout. A link whose check
+// ran out of time while ctx is still active is reported as not available with a timeout reason.
+func (s *Service) checkURL(ctx, checkCtx context.Context, url string) models.Link {
+	if s.checkTimeout <= 0 {
+		return s.urlChecker.CheckURLWithContext(checkCtx, url)
+	}
+
+	checkCtx, cancel := context.WithTimeout(checkCtx, s.checkTimeout)
+	defer cancel()
+
+	link := s.urlChecker.CheckURLWithContext(checkCtx, url)
+	if link.Status != models.LinkStatusAvailable && errors.Is(checkCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+		slog.DebugContext(ctx, "check timed out",
+			slog.String("url", url),
+			slog.Duration("timeout", s.checkTimeout),
+		)
+		link.Status = models.LinkStatusNotAvailable
+		link.Error = models.LinkErrorTimeout
+	}
+	return link
+}
+
+// sampleURL checks url samples times and returns the last result with Duration set to the
+// average and Latency holding the min, average and max durations. Samples respect the per-host delay,
+// no further samples are taken once ctx is done and the statistics then cover the completed ones.
+func (s *Service) sampleURL(ctx, checkCtx context.Context, url string, samples int) models.Link {
+	link := s.checkURL(ctx, checkCtx, url)
+	if samples <= 1 {
+		return link
+	}
+
+	checkedAt := link.CheckedAt
+	latency := models.LatencyStats{Samples: 1, Min: link.Duration, Max: link.Duration}
+	total := link.Duration
+	for i := 1; i < samples && s.waitForHost(ctx, url); i++ {
+		link = s.checkURL(ctx, checkCtx, url)
+		latency.Samples++
+		latency.Min = min(latency.Min, link.Duration)
+		latency.Max = max(latency.Max, link.Duration)
+		total += link.Duration
+	}
+	latency.Avg = total / time.Duration(latency.Samples)
+
+	if latency.Samples < samples {
+		slog.DebugContext(ctx, "sampling stopped early",
+			slog.String("url", url),
+			slog.Int("samples", latency.Samples),
+			slog.Int("requested_samples", samples),
+		)
+	}
+
+	link.CheckedAt = checkedAt
+	link.Duration = latency.Avg
+	link.Latency = &latency
+	return link
+}
+
+// waitForHost blocks until the host of url may be checked again under the per-host delay.
+// It returns false if ctx is done first.
+func (s *Service) waitForHost(ctx context.Context, url string) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if s.hostPacer == nil {
+		return true
+	}
+	return s.hostPacer.Wait(ctx, urlchecker.Host(url)) == nil
+}
+
+// acquireCheckSlot blocks until a global check slot is free.
+// It returns false if ctx is done first.
+func (s *Service) acquireCheckSlot(ctx context.Context) bool {
+	if s.checkSlots == nil {
+		return true
+	}
+
+	select {
+	case <-ctx.Done():
+		return false
+	case s.checkSlots <- struct{}{}:
+		return true
+	}
+}
+
+// releaseCheckSlot frees a slot taken by acquireCheckSlot.
+func (s *Service) releaseCheckSlot() {
+	if s.checkSlots != nil {
+		<-s.checkSlots
+	}
+}
+
+// previousChecks returns the latest stored check of each URL, so that re-checks can be made
+// conditional. Lookup errors only disable conditional checks.
+func (s *Service) previousChecks(ctx context.Context, links []string) map[string]models.Link {
+	latest, err := s.repository.LatestByURLs(links)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to load previous checks", slog.Any("error", err))
+		return nil
+	}
+	return latest
+}
+
+// modifiedSince returns the time a re-check of prev asks for changes since: its Last-Modified,
+// or its check time if the server sent none. It is zero unless prev was available, as a page
+// unchanged since a failed check says nothing about the page.
+func modifiedSince(prev models.Link) time.Time {
+	if prev.Status != models.LinkStatusAvailable {
+		return time.Time{}
+	}
+	if prev.LastModified != nil {
+		return *prev.LastModified
+	}
+	return prev.CheckedAt
+}
+
+// startProducer sends links with validators of their previous checks, expected statuses and labels
+// to jobs channel. Links whose slot in checkedLinks is already filled are not sent.
+func (s *Service) startProducer(ctx context.Context, jobs chan<- checkJob, links []string, checkedLinks []models.Link, previous map[string]models.Link, opts models.CheckOptions) {
+	filled := make([]bool, len(checkedLinks))
+	for i, l := range checkedLinks {
+		filled[i] = l.URL != ""
+	}
+
 	go func() {
 		defer close(jobs)
-		for _, raw := range links {
+		for i, raw := range links {
+			if filled[i] {
+				continue
+			}
 			select {
 			case <-ctx.Done():
-				slog.Warn("producer stopped due to context done")
+				slog.WarnContext(ctx, "producer stopped due to context done")
 				return
-			case jobs <- raw:
+			case jobs <- newCheckJob(i, raw, previous, opts):
 			}
 		}
 	}()
 }
 
-// buildResponse creates LinksResponse from checked links.
+// newCheckJob builds the job checking the link at index i of the submitted list.
+func newCheckJob(i int, raw string, previous map[string]models.Link, opts models.CheckOptions) checkJob {
+	return checkJob{
+		index:         i,
+		url:           raw,
+		etag:          previous[raw].ETag,
+		modifiedSince: modifiedSince(previous[raw]),
+		expected:      opts.ExpectedStatusFor(raw),
+		method:        opts.MethodFor(raw),
+		label:         opts.Labels[raw],
+		samples:       opts.Samples,
+	}
+}
+
+// buildResponse creates LinksResponse from checked links, keeping their order in Results and Checked.
+// The links are assigned to group linksNum.
 func (s *Service) buildResponse(checkedLinks []models.Link, linksNum int) models.LinksResponse {
 	res := models.LinksResponse{
 		Links:    make(map[string]models.LinkStatus, len(checkedLinks)),
+		Results:  make([]models.LinkResult, 0, len(checkedLinks)),
 		LinksNum: linksNum,
+		Checked:  checkedLinks,
 	}
-	for _, l := range checkedLinks {
+	for i, l := range checkedLinks {
+		checkedLinks[i].GroupNum = linksNum
 		res.Links[l.URL] = l.Status
+		res.Results = append(res.Results, models.LinkResult{URL: l.URL, Status: l.Status, Slow: l.Slow, Unchanged: l.Unchanged, Method: l.Method, Label: l.Label, Latency: l.Latency, Ignored: l.Ignored})
 	}
 	return res
 }
 
-// collectResults collects results from channel until it's closed.
-func (s *Service) collectResults(ctx context.Context, results <-chan models.Link) ([]models.Link, error) {
-	checkedLinks := make([]models.Link, 0)
+// collectResults collects results from channel into checkedLinks until it's closed.
+// Links are placed in submission order regardless of which worker finished first.
+// Slots filled before the check are reported through the callbacks first.
+// If workers stopped early while ctx is still active, slots of unchecked links are left empty.
+func (s *Service) collectResults(ctx context.Context, results <-chan checkResult, checkedLinks []models.Link, opts models.CheckOptions) ([]models.Link, error) {
+	total := len(checkedLinks)
+	received := 0
+	for _, l := range checkedLinks {
+		if l.URL == "" {
+			continue
+		}
+		received++
+		if opts.OnResult != nil {
+			opts.OnResult(l)
+		}
+		if opts.Progress != nil {
+			opts.Progress(received, total)
+		}
+	}
 
 	for {
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
 
-		case link, ok := <-results:
+		case res, ok := <-results:
 			if !ok {
+				if received < total {
+					if err := ctx.Err(); err != nil {
+						return nil, err
+					}
+				}
 				return checkedLinks, nil
 			}
-			checkedLinks = append(checkedLinks, link)
+			checkedLinks[res.index] = res.link
+			received++
+			if opts.OnResult != nil {
+				opts.OnResult(res.link)
+			}
+			if opts.Progress != nil {
+				opts.Progress(received, total)
+			}
+		}
+	}
+}
+
+// skipDeniedHosts fills the slots of links whose host matches skipHosts with skipped_denylist
+// results, so that they are never sent to a worker. It returns the number of such links.
+func (s *Service) skipDeniedHosts(checkedLinks []models.Link, links []string, opts models.CheckOptions) int {
+	if len(s.skipHosts) == 0 {
+		return 0
+	}
+
+	denied := 0
+	for i, raw := range links {
+		if !urlchecker.MatchHost(s.skipHosts, urlchecker.Host(raw)) {
+			continue
+		}
+		checkedLinks[i] = models.Link{
+			URL:       raw,
+			Status:    models.LinkStatusSkippedDenylist,
+			CheckedAt: time.Now(),
+			Label:     opts.Labels[raw],
 		}
+		denied++
 	}
+	return denied
+}
+
+// skipUnchecked stores links whose slots were never filled by a worker as skipped,
+// reporting them through opts.OnResult like checked links.
+func skipUnchecked(checkedLinks []models.Link, links []string, opts models.CheckOptions) int {
+	skipped := 0
+	for i, l := range checkedLinks {
+		if l.URL != "" {
+			continue
+		}
+		checkedLinks[i] = models.Link{
+			URL:       links[i],
+			Status:    models.LinkStatusSkipped,
+			CheckedAt: time.Now(),
+			Label:     opts.Labels[links[i]],
+		}
+		skipped++
+		if opts.OnResult != nil {
+			opts.OnResult(checkedLinks[i])
+		}
+	}
+	return skipped
 }
 
 // CheckMany validates and checks the given links concurrently using a worker pool.
-func (s *Service) CheckMany(ctx context.Context, links []string) (models.LinksResponse, error) {
-	unique := deduplicateLinks(links)
+// opts.Workers overrides the default pool size, clamped to the configured maximum.
+// opts.OnResult and opts.Progress are called from a single goroutine as results arrive.
+// With a positive opts.Budget, links not checked in time are stored and returned as skipped.
+// With opts.IdempotencyKey seen before, it returns the group stored for the key without checking again;
+// a concurrent call with the same key waits for the first one to finish.
+// After Shutdown it returns models.ErrShuttingDown.
+func (s *Service) CheckMany(ctx context.Context, links []string, opts models.CheckOptions) (models.LinksResponse, error) {
+	if !s.beginBatch() {
+		return models.LinksResponse{}, models.ErrShuttingDown
+	}
+	defer s.endBatch()
+
+	if opts.IdempotencyKey != "" {
+		release, err := s.idempotency.Acquire(ctx, opts.IdempotencyKey)
+		if err != nil {
+			return models.LinksResponse{}, fmt.Errorf("wait for check of idempotency key: %w", err)
+		}
+		defer release()
+
+		if res, ok := s.replayResponse(ctx, opts.IdempotencyKey); ok {
+			return res, nil
+		}
+	}
+
+	res, err := s.checkMany(ctx, ctx, links, opts)
+	if err != nil {
+		return models.LinksResponse{}, err
+	}
+
+	if opts.IdempotencyKey != "" && res.LinksNum > 0 {
+		s.idempotency.Put(opts.IdempotencyKey, res.LinksNum)
+	}
+
+	return res, nil
+}
+
+// replayResponse rebuilds the response of the group stored for an idempotency key.
+// It reports false if the key is unknown or expired, or its group can no longer be loaded.
+func (s *Service) replayResponse(ctx context.Context, key string) (models.LinksResponse, bool) {
+	linksNum, ok := s.idempotency.Get(key)
+	if !ok {
+		return models.LinksResponse{}, false
+	}
+
+	group, err := s.repository.GetByNum(linksNum)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to load group of idempotency key, checking again",
+			slog.Int("links_num", linksNum),
+			slog.Any("error", err),
+		)
+		return models.LinksResponse{}, false
+	}
+
+	res := s.buildResponse(group.Links, linksNum)
+	res.Name = group.Name
+
+	slog.InfoContext(ctx, "replayed check for idempotency key", slog.Int("links_num", linksNum))
+
+	return res, true
+}
+
+// checkMany runs a check registered with beginBatch by the caller. Checks stop once stop is done,
+// like at the budget, while storing the results is bound by ctx only.
+func (s *Service) checkMany(ctx, stop context.Context, links []string, opts models.CheckOptions) (models.LinksResponse, error) {
+	unique := deduplicateLinks(links, s.collapseWWW)
 	linksLen := len(unique)
 
 	if linksLen == 0 {
 		return models.LinksResponse{
 			Links:    map[string]models.LinkStatus{},
+			Results:  []models.LinkResult{},
 			LinksNum: 0,
 		}, nil
 	}
 
-	slog.Info("checking links with worker pool", slog.Int("count", linksLen))
+	slog.InfoContext(ctx, "checking links with worker pool", slog.Int("count", linksLen))
 
-	workerCount := s.workerCount
+	checkedLinks := make([]models.Link, linksLen)
+	if denied := s.skipDeniedHosts(checkedLinks, unique, opts); denied > 0 {
+		slog.InfoContext(ctx, "links on skipped hosts not checked", slog.Int("skipped", denied))
+	}
+
+	workerCount := s.resolveWorkerCount(opts.Workers)
 	if workerCount > linksLen {
 		workerCount = linksLen
 	}
 
-	jobs := make(chan string)
-	results := make(chan models.Link)
+	if !opts.ContentMatch.IsZero() {
+		stop = urlchecker.ContextWithContentMatch(stop, opts.ContentMatch)
+	}
+	if opts.Accept != "" {
+		stop = urlchecker.ContextWithAccept(stop, opts.Accept)
+	}
+	if !opts.CORS.IsZero() {
+		stop = urlchecker.ContextWithCORSPreflight(stop, opts.CORS)
+	}
+
+	// checks stop at the budget, while storing the results is still bound by ctx only
+	checkCtx := stop
+	if opts.Budget > 0 {
+		var cancel context.CancelFunc
+		checkCtx, cancel = context.WithTimeout(stop, opts.Budget)
+		defer cancel()
+	}
+
+	buffer := s.resolveChannelBuffer(workerCount)
+	results := make(chan checkResult, buffer)
 
-	wg := s.startWorkers(ctx, jobs, results, workerCount)
-	s.startProducer(ctx, jobs, unique)
+	var wg *sync.WaitGroup
+	if s.pool != nil {
+		wg = s.submitToPool(checkCtx, results, unique, checkedLinks, s.previousChecks(ctx, unique), opts, workerCount)
+	} else {
+		jobs := make(chan checkJob, buffer)
+		wg = s.startWorkers(checkCtx, jobs, results, workerCount)
+		s.startProducer(checkCtx, jobs, unique, checkedLinks, s.previousChecks(ctx, unique), opts)
+	}
 
 	go func() {
 		wg.Wait()
 		close(results)
 	}()
 
-	checkedLinks, err := s.collectResults(ctx, results)
+	checkedLinks, err := s.collectResults(ctx, results, checkedLinks, opts)
 	if err != nil {
-		slog.Warn("check many canceled by context")
+		slog.WarnContext(ctx, "check many canceled by context")
 		return models.LinksResponse{}, err
 	}
 
-	linksNum, err := s.repository.InsertMany(checkedLinks)
+	if skipped := skipUnchecked(checkedLinks, unique, opts); skipped > 0 {
+		slog.WarnContext(ctx, "checks stopped early, unchecked links skipped",
+			slog.Duration("budget", opts.Budget),
+			slog.Int("skipped", skipped),
+		)
+	}
+	for i, raw := range unique {
+		checkedLinks[i].Ignored = opts.Ignored[raw]
+	}
+
+	linksNum, err := s.storeLinks(ctx, checkedLinks, opts)
 	if err != nil {
-		slog.Error("failed to insert checked links", slog.Any("error", err))
+		slog.ErrorContext(ctx, "failed to insert checked links", slog.Any("error", err))
 		return models.LinksResponse{}, err
 	}
 
 	res := s.buildResponse(checkedLinks, linksNum)
+	res.Name = opts.Name
 
-	slog.Debug("links checked and stored with worker pool",
+	slog.DebugContext(ctx, "links checked and stored with worker pool",
 		slog.Int("links_num", linksNum),
 		slog.Int("links_count", len(checkedLinks)),
 		slog.Int("workers", workerCount),
@@ -198,39 +835,511 @@ func (s *Service) CheckMany(ctx context.Context, links []string) (models.LinksRe
 	return res, nil
 }
 
-// GenerateReport builds a PDF report for the specified link group numbers.
-func (s *Service) GenerateReport(ctx context.Context, linksNum []int) (*bytes.Buffer, error) {
+// storeLinks stores checked links as a new group, or replaces the links of group opts.GroupNum
+// if it still exists, and returns the group number.
+func (s *Service) storeLinks(ctx context.Context, checkedLinks []models.Link, opts models.CheckOptions) (int, error) {
+	if opts.GroupNum <= 0 {
+		return s.repository.InsertNamed(opts.Name, checkedLinks)
+	}
+
+	err := s.repository.Replace(opts.GroupNum, checkedLinks)
+	if errors.Is(err, models.ErrGroupNotFound) {
+		slog.WarnContext(ctx, "group to replace not found, storing a new one", slog.Int("links_num", opts.GroupNum))
+		return s.repository.InsertNamed(opts.Name, checkedLinks)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return opts.GroupNum, nil
+}
+
+// StartCheckJob registers an asynchronous job checking links and runs it in the background.
+// The job is not bound to ctx cancellation, so it outlives the HTTP request that started it.
+// Shutdown waits for the job, and new jobs are rejected with models.ErrShuttingDown.
+func (s *Service) StartCheckJob(ctx context.Context, links []string, opts models.CheckOptions) (models.Job, error) {
+	if !s.beginBatch() {
+		return models.Job{}, models.ErrShuttingDown
+	}
+
+	unique := deduplicateLinks(links, s.collapseWWW)
+
+	jobCtx := context.WithoutCancel(ctx)
+	stop, cancel := context.WithCancel(jobCtx)
+
+	job, err := s.jobs.Create(len(unique), cancel)
+	if err != nil {
+		cancel()
+		s.endBatch()
+		slog.ErrorContext(ctx, "failed to create job", slog.Any("error", err))
+		return models.Job{}, err
+	}
+
+	slog.InfoContext(ctx, "starting async check job",
+		slog.String("job_id", job.ID),
+		slog.Int("count", len(unique)),
+	)
+
+	go s.runCheckJob(jobCtx, stop, cancel, job.ID, unique, opts)
+
+	return job, nil
+}
+
+// runCheckJob performs the check for an async job and records progress and result.
+// Once stop is canceled by CancelJob, the links checked until then are stored and the job is canceled.
+func (s *Service) runCheckJob(ctx, stop context.Context, cancel context.CancelFunc, id string, links []string, opts models.CheckOptions) {
+	defer s.endBatch()
+	defer cancel()
+
+	s.updateJob(id, func(job *models.Job) {
+		job.Status = models.JobStatusRunning
+	})
+
+	opts.Progress = func(checked, _ int) {
+		s.updateJob(id, func(job *models.Job) {
+			job.Checked = checked
+		})
+	}
+
+	res, err := s.checkMany(ctx, stop, links, opts)
+	finishedAt := time.Now()
+	canceled := stop.Err() != nil
+
+	s.updateJob(id, func(job *models.Job) {
+		job.FinishedAt = &finishedAt
+		if err != nil {
+			job.Status = models.JobStatusFailed
+			job.Error = err.Error()
+			return
+		}
+		job.Status = models.JobStatusDone
+		if canceled {
+			job.Status = models.JobStatusCanceled
+		}
+		job.Result = &res
+	})
+
+	slog.InfoContext(ctx, "async check job finished",
+		slog.String("job_id", id),
+		slog.Bool("failed", err != nil),
+		slog.Bool("canceled", canceled),
+	)
+}
+
+// CancelJob stops the asynchronous job with the given id and waits until the links checked
+// until then are stored. It returns the canceled job with its partial result, or the job as
+// finished if it completed in the meantime.
+// It returns models.ErrJobFinished if the job had already finished.
+func (s *Service) CancelJob(ctx context.Context, id string) (models.Job, error) {
+	if err := s.jobs.Cancel(id); err != nil {
+		return models.Job{}, err
+	}
+
+	slog.InfoContext(ctx, "canceling async check job", slog.String("job_id", id))
+
+	return s.jobs.Wait(ctx, id)
+}
+
+// beginBatch registers a check in progress. It reports false once Shutdown has been called.
+func (s *Service) beginBatch() bool {
+	s.batchMu.Lock()
+	defer s.batchMu.Unlock()
+
+	if s.closing {
+		return false
+	}
+	s.batches.Add(1)
+	s.activeBatches.Add(1)
+	return true
+}
+
+// endBatch marks a check registered with beginBatch as finished.
+func (s *Service) endBatch() {
+	s.activeBatches.Add(-1)
+	s.batches.Done()
+}
+
+// Status reports checks in progress, checks completed since start and worker pool limits.
+func (s *Service) Status() models.ServiceStatus {
+	s.batchMu.Lock()
+	closing := s.closing
+	s.batchMu.Unlock()
+
+	return models.ServiceStatus{
+		ActiveBatches:       s.activeBatches.Load(),
+		ActiveChecks:        s.activeChecks.Load(),
+		CompletedChecks:     s.completedChecks.Load(),
+		Workers:             s.workerCount,
+		SharedWorkers:       s.poolWorkers,
+		MaxWorkers:          s.maxWorkerCount,
+		MaxConcurrentChecks: cap(s.checkSlots),
+		ShuttingDown:        closing,
+	}
+}
+
+// Shutdown stops accepting new checks and waits for checks and async jobs in progress
+// to finish and store their results. It returns ctx.Err() if ctx is done first;
+// the remaining checks keep running in the background.
+func (s *Service) Shutdown(ctx context.Context) error {
+	s.batchMu.Lock()
+	s.closing = true
+	s.batchMu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.batches.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		slog.InfoContext(ctx, "link checks drained")
+		s.stopPool()
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// updateJob applies fn to the job and logs store errors.
+func (s *Service) updateJob(id string, fn func(job *models.Job)) {
+	if err := s.jobs.Update(id, fn); err != nil {
+		slog.Error("failed to update job", slog.String("job_id", id), slog.Any("error", err))
+	}
+}
+
+// GetJob returns the current state of the asynchronous job with the given id.
+func (s *Service) GetJob(ctx context.Context, id string) (models.Job, error) {
+	select {
+	case <-ctx.Done():
+		return models.Job{}, ctx.Err()
+	default:
+	}
+
+	return s.jobs.Get(id)
+}
+
+// Recheck checks the links of stored group num again and stores the results as a new group. It keeps
+// the settings stored with the links, see recheckOptions; request-wide settings that are not stored,
+// like samples, content matching, Accept and CORS preflight, fall back to their defaults. The response
+// lists the changes against group num. It fails with models.ErrGroupNotFound if the group does not exist.
+func (s *Service) Recheck(ctx context.Context, num int) (models.LinksResponse, error) {
+	prev, err := s.repository.GetByNum(num)
+	if err != nil {
+		return models.LinksResponse{}, err
+	}
+
+	links, opts := recheckOptions(prev)
+	res, err := s.CheckMany(ctx, links, opts)
+	if err != nil {
+		return models.LinksResponse{}, err
+	}
+	res.Changes = statusChanges(prev.Links, res.Results)
+
+	slog.InfoContext(ctx, "re-checked link group",
+		slog.Int("previous_links_num", num),
+		slog.Int("links_num", res.LinksNum),
+		slog.Int("now_broken", len(res.Changes.NowBroken)),
+		slog.Int("now_fixed", len(res.Changes.NowFixed)),
+	)
+
+	return res, nil
+}
+
+// recheckOptions returns the URLs of group and the options stored with them: the group name and
+// the label, method, expected status and ignored mark of each link.
+func recheckOptions(group models.Links) ([]string, models.CheckOptions) {
+	links := make([]string, 0, len(group.Links))
+	opts := models.CheckOptions{
+		Name:                group.Name,
+		Labels:              make(map[string]string),
+		Ignored:             ignoredURLs(group.Links),
+		MethodByURL:         make(map[string]string),
+		ExpectedStatusByURL: make(map[string]models.StatusRange),
+	}
+	for _, link := range group.Links {
+		links = append(links, link.URL)
+		if link.Label != "" {
+			opts.Labels[link.URL] = link.Label
+		}
+		if link.Method != "" {
+			opts.MethodByURL[link.URL] = link.Method
+		}
+		if r, err := models.ParseStatusRange(link.ExpectedStatus); link.ExpectedStatus != "" && err == nil {
+			opts.ExpectedStatusByURL[link.URL] = r
+		}
+	}
+	return links, opts
+}
+
+// ignoredURLs returns the URLs of links marked as ignored, nil if there are none.
+func ignoredURLs(links []models.Link) map[string]bool {
+	var ignored map[string]bool
+	for _, link := range links {
+		if link.Ignored {
+			if ignored == nil {
+				ignored = make(map[string]bool)
+			}
+			ignored[link.URL] = true
+		}
+	}
+	return ignored
+}
+
+// statusChanges compares results of a re-check with the links of the previous check.
+// Ignored links are left out, so known broken links never show up as now broken.
+func statusChanges(prev []models.Link, results []models.LinkResult) *models.StatusChanges {
+	prevStatus := make(map[string]models.LinkStatus, len(prev))
+	for _, link := range prev {
+		prevStatus[link.URL] = link.Status
+	}
+
+	changes := &models.StatusChanges{
+		NowBroken: []string{},
+		NowFixed:  []string{},
+		Unchanged: []string{},
+	}
+	for _, result := range results {
+		before, ok := prevStatus[result.URL]
+		if !ok || result.Ignored || before.IsSkipped() || result.Status.IsSkipped() {
+			continue
+		}
+
+		wasAvailable := before == models.LinkStatusAvailable
+		isAvailable := result.Status == models.LinkStatusAvailable
+		switch {
+		case wasAvailable == isAvailable:
+			changes.Unchanged = append(changes.Unchanged, result.URL)
+		case isAvailable:
+			changes.NowFixed = append(changes.NowFixed, result.URL)
+		default:
+			changes.NowBroken = append(changes.NowBroken, result.URL)
+		}
+	}
+	return changes
+}
+
+// CheckSitemap downloads the sitemap at sitemapURL and checks every page listed in it.
+func (s *Service) CheckSitemap(ctx context.Context, sitemapURL string, opts models.CheckOptions) (models.LinksResponse, error) {
+	slog.InfoContext(ctx, "fetching sitemap", slog.String("url", sitemapURL))
+
+	links, err := s.sitemapFetcher.Fetch(ctx, sitemapURL)
+	if err != nil {
+		if ctx.Err() != nil {
+			return models.LinksResponse{}, ctx.Err()
+		}
+		slog.WarnContext(ctx, "failed to fetch sitemap",
+			slog.String("url", sitemapURL),
+			slog.Any("error", err),
+		)
+		return models.LinksResponse{}, fmt.Errorf("%w: %w", models.ErrSourceUnavailable, err)
+	}
+
+	if len(links) == 0 {
+		return models.LinksResponse{}, models.ErrNoLinksFound
+	}
+
+	slog.DebugContext(ctx, "sitemap fetched",
+		slog.String("url", sitemapURL),
+		slog.Int("links_count", len(links)),
+	)
+
+	return s.CheckMany(ctx, links, opts)
+}
+
+// CheckPage fetches the HTML page at pageURL and checks every link found in its anchors.
+func (s *Service) CheckPage(ctx context.Context, pageURL string, opts models.CheckOptions) (models.CrawlResponse, error) {
+	slog.InfoContext(ctx, "extracting links from page", slog.String("url", pageURL))
+
+	links, err := s.linkExtractor.ExtractLinks(ctx, pageURL)
+	if err != nil {
+		if ctx.Err() != nil {
+			return models.CrawlResponse{}, ctx.Err()
+		}
+		slog.WarnContext(ctx, "failed to extract links from page",
+			slog.String("url", pageURL),
+			slog.Any("error", err),
+		)
+		return models.CrawlResponse{}, fmt.Errorf("%w: %w", models.ErrSourceUnavailable, err)
+	}
+
+	if len(links) == 0 {
+		return models.CrawlResponse{}, models.ErrNoLinksFound
+	}
+
+	res, err := s.CheckMany(ctx, links, opts)
+	if err != nil {
+		return models.CrawlResponse{}, err
+	}
+
+	return models.CrawlResponse{
+		LinksResponse: res,
+		Source:        pageURL,
+	}, nil
+}
+
+// GenerateReport writes a report for the specified link group numbers using the given options
+// to w, a PDF unless opts.Format asks for HTML, and returns statistics of the reported groups.
+func (s *Service) GenerateReport(ctx context.Context, linksNum []int, opts models.ReportOptions, w io.Writer) (*models.Report, error) {
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	default:
 	}
 
-	slog.Info("generating report for links groups", slog.Int("groups", len(linksNum)))
+	slog.InfoContext(ctx, "generating report for links groups", slog.Int("groups", len(linksNum)))
 
 	checkedLinks, err := s.repository.GetByNums(linksNum)
 	if err != nil {
-		slog.Error("failed to get links by nums", slog.Any("error", err))
+		slog.ErrorContext(ctx, "failed to get links by nums", slog.Any("error", err))
 		return nil, err
 	}
 
+	return s.buildReport(ctx, checkedLinks, opts, w)
+}
+
+// GenerateFullReport writes a report over every stored link group, ordered by group number, to w.
+// It fails with ErrReportTooLarge when there are more groups than the configured limit.
+func (s *Service) GenerateFullReport(ctx context.Context, opts models.ReportOptions, w io.Writer) (*models.Report, error) {
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	default:
 	}
 
-	report, err := s.pdfGenerator.GenerateMultipleReports(checkedLinks)
+	allLinks, err := s.repository.GetAll()
 	if err != nil {
-		slog.Error("failed to generate PDF report", slog.Any("error", err))
+		slog.ErrorContext(ctx, "failed to get all links for report", slog.Any("error", err))
+		return nil, err
+	}
+
+	if len(allLinks) == 0 {
+		return nil, models.ErrGroupNotFound
+	}
+	if s.maxReportGroups > 0 && len(allLinks) > s.maxReportGroups {
+		return nil, fmt.Errorf("%w: %d groups exceed the limit of %d", models.ErrReportTooLarge, len(allLinks), s.maxReportGroups)
+	}
+
+	sort.Slice(allLinks, func(i, j int) bool {
+		return allLinks[i].LinksNum < allLinks[j].LinksNum
+	})
+
+	slog.InfoContext(ctx, "generating report for all links groups", slog.Int("groups", len(allLinks)))
+
+	return s.buildReport(ctx, allLinks, opts, w)
+}
+
+// buildReport renders the report for the given groups to w, a PDF unless opts.Format asks for
+// HTML, and calculates their statistics. It fails with ErrReportTooLarge before rendering when
+// the groups hold more links than the configured limit, as the whole document is assembled in memory.
+func (s *Service) buildReport(ctx context.Context, groups []models.Links, opts models.ReportOptions, w io.Writer) (*models.Report, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	if s.maxReportLinks > 0 {
+		total := 0
+		for _, g := range groups {
+			total += len(g.Links)
+		}
+		if total > s.maxReportLinks {
+			return nil, fmt.Errorf("%w: %d links exceed the limit of %d", models.ErrReportTooLarge, total, s.maxReportLinks)
+		}
+	}
+
+	generator := s.pdfGenerator
+	if opts.Format == models.ReportFormatHTML {
+		generator = s.htmlGenerator
+	}
+
+	cw := &countingWriter{w: w}
+	if err := generator.GenerateMultipleReports(ctx, cw, groups, opts); err != nil {
+		slog.ErrorContext(ctx, "failed to generate report", slog.String("format", string(opts.Format)), slog.Any("error", err))
 		return nil, err
 	}
 
-	slog.Debug("PDF report generated successfully",
-		slog.Int("groups", len(linksNum)),
+	slog.DebugContext(ctx, "report generated successfully",
+		slog.String("format", string(opts.Format)),
+		slog.Int("groups", len(groups)),
+		slog.Int("size_bytes", cw.n),
 	)
 
-	return report, nil
+	reported := make([]models.ReportGroup, 0, len(groups))
+	for _, g := range groups {
+		reported = append(reported, models.ReportGroup{LinksNum: g.LinksNum, Name: g.Name})
+	}
+
+	return &models.Report{
+		Size:       cw.n,
+		Statistics: stats.CalculateGroups(groups, s.percentiles...),
+		Hosts:      stats.ByHost(groups, s.collapseWWW),
+		Groups:     reported,
+	}, nil
+}
+
+// countingWriter counts the bytes written through it to w.
+type countingWriter struct {
+	w io.Writer
+	n int
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += n
+	return n, err
+}
+
+// GetByNum returns the stored link group with the given number.
+// It fails with ErrGroupNotFound if the group does not exist.
+func (s *Service) GetByNum(ctx context.Context, num int) (models.Links, error) {
+	select {
+	case <-ctx.Done():
+		return models.Links{}, ctx.Err()
+	default:
+	}
+
+	group, err := s.repository.GetByNum(num)
+	if err != nil {
+		if !errors.Is(err, models.ErrGroupNotFound) {
+			slog.ErrorContext(ctx, "failed to get links group", slog.Int("links_num", num), slog.Any("error", err))
+		}
+		return models.Links{}, err
+	}
+
+	slog.DebugContext(ctx, "fetched links group",
+		slog.Int("links_num", num),
+		slog.Int("links_count", len(group.Links)),
+	)
+
+	return group, nil
+}
+
+// SetIgnored marks the links of stored group num with the given URL as ignored or not and
+// returns the updated group. Ignored links are still checked and listed, but left out of
+// availability statistics and re-check changes. It fails with models.ErrGroupNotFound if
+// the group does not exist and with models.ErrLinkNotFound if it has no link with that URL.
+func (s *Service) SetIgnored(ctx context.Context, num int, url string, ignored bool) (models.Links, error) {
+	select {
+	case <-ctx.Done():
+		return models.Links{}, ctx.Err()
+	default:
+	}
+
+	if err := s.repository.SetIgnored(num, url, ignored); err != nil {
+		if !errors.Is(err, models.ErrGroupNotFound) && !errors.Is(err, models.ErrLinkNotFound) {
+			slog.ErrorContext(ctx, "failed to set link ignored", slog.Int("links_num", num), slog.Any("error", err))
+		}
+		return models.Links{}, err
+	}
+
+	slog.InfoContext(ctx, "set link ignored",
+		slog.Int("links_num", num),
+		slog.String("url", url),
+		slog.Bool("ignored", ignored),
+	)
+
+	return s.GetByNum(ctx, num)
 }
 
 // GetAll returns all stored link groups from the repository.
@@ -241,15 +1350,175 @@ func (s *Service) GetAll(ctx context.Context) ([]models.Links, error) {
 	default:
 	}
 
-	slog.Info("fetching all links groups")
+	slog.InfoContext(ctx, "fetching all links groups")
 
 	allLinks, err := s.repository.GetAll()
 	if err != nil {
-		slog.Error("failed to get all links", slog.Any("error", err))
+		slog.ErrorContext(ctx, "failed to get all links", slog.Any("error", err))
 		return nil, err
 	}
 
-	slog.Debug("fetched all links groups", slog.Int("groups_count", len(allLinks)))
+	slog.DebugContext(ctx, "fetched all links groups", slog.Int("groups_count", len(allLinks)))
 
 	return allLinks, nil
 }
+
+// Summaries returns an index of the stored groups: link counts, availability and check time
+// spans without the links themselves. Availability leaves ignored links out.
+func (s *Service) Summaries(ctx context.Context) ([]models.GroupSummary, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	summaries, err := s.repository.Summaries()
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get group summaries", slog.Any("error", err))
+		return nil, err
+	}
+	for i := range summaries {
+		if checked := summaries[i].Total - summaries[i].Ignored; checked > 0 {
+			summaries[i].AvailabilityPercent = float64(summaries[i].Available) * 100 / float64(checked)
+		}
+	}
+
+	slog.DebugContext(ctx, "fetched group summaries", slog.Int("groups_count", len(summaries)))
+
+	return summaries, nil
+}
+
+// GetBetween returns link groups with at least one link checked within [from, to].
+// A zero from or to leaves that side of the range open.
+func (s *Service) GetBetween(ctx context.Context, from, to time.Time) ([]models.Links, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	slog.InfoContext(ctx, "fetching links groups by check time",
+		slog.Time("from", from),
+		slog.Time("to", to),
+	)
+
+	groups, err := s.repository.GetBetween(from, to)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get links by check time", slog.Any("error", err))
+		return nil, err
+	}
+
+	slog.DebugContext(ctx, "fetched links groups by check time", slog.Int("groups_count", len(groups)))
+
+	return groups, nil
+}
+
+// Search returns stored links matching every condition of q, together with their group numbers.
+// It fails with models.ErrInvalidSearch if a pattern of q is invalid.
+func (s *Service) Search(ctx context.Context, q models.SearchQuery) (models.SearchResponse, error) {
+	select {
+	case <-ctx.Done():
+		return models.SearchResponse{}, ctx.Err()
+	default:
+	}
+
+	slog.InfoContext(ctx, "searching stored links", slog.Any("query", q))
+
+	found, err := s.repository.Search(q)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidSearch) {
+			slog.WarnContext(ctx, "invalid search query", slog.Any("error", err))
+		} else {
+			slog.ErrorContext(ctx, "failed to search links", slog.Any("error", err))
+		}
+		return models.SearchResponse{}, err
+	}
+
+	return models.SearchResponse{
+		Query:   q.Query,
+		Pattern: q.Pattern,
+		Regex:   q.Regex,
+		Host:    q.Host,
+		Status:  q.Status,
+		Links:   found,
+		Count:   len(found),
+	}, nil
+}
+
+// Export returns all stored link groups ordered by group number, as they are saved in snapshots.
+func (s *Service) Export(ctx context.Context) ([]models.Links, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	groups, err := s.repository.GetAll()
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get all links for export", slog.Any("error", err))
+		return nil, err
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].LinksNum < groups[j].LinksNum
+	})
+
+	slog.InfoContext(ctx, "exported links groups", slog.Int("groups_count", len(groups)))
+
+	return groups, nil
+}
+
+// Import stores link groups read from a snapshot. In merge mode groups are appended as new
+// groups with fresh numbers, in replace mode they replace all stored groups and keep their numbers.
+func (s *Service) Import(ctx context.Context, groups []models.Links, mode models.ImportMode) (models.ImportResponse, error) {
+	select {
+	case <-ctx.Done():
+		return models.ImportResponse{}, ctx.Err()
+	default:
+	}
+
+	nums, err := s.repository.Import(groups, mode)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to import links groups",
+			slog.String("mode", string(mode)),
+			slog.Any("error", err),
+		)
+		return models.ImportResponse{}, err
+	}
+
+	res := models.ImportResponse{Mode: mode, LinksNum: nums}
+	for _, g := range groups {
+		res.LinksCount += len(g.Links)
+	}
+
+	slog.InfoContext(ctx, "imported links groups",
+		slog.String("mode", string(mode)),
+		slog.Int("groups_count", len(groups)),
+		slog.Int("links_count", res.LinksCount),
+	)
+
+	return res, nil
+}
+
+// Stats returns availability statistics rolled up across all stored link groups.
+func (s *Service) Stats(ctx context.Context) (models.Statistics, error) {
+	select {
+	case <-ctx.Done():
+		return models.Statistics{}, ctx.Err()
+	default:
+	}
+
+	allLinks, err := s.repository.GetAll()
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get all links for stats", slog.Any("error", err))
+		return models.Statistics{}, err
+	}
+
+	res := stats.CalculateGroups(allLinks, s.percentiles...)
+
+	slog.DebugContext(ctx, "calculated statistics",
+		slog.Int("groups_count", res.Groups),
+		slog.Int("links_count", res.Total),
+	)
+
+	return res, nil
+}
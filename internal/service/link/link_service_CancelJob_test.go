@@ -0,0 +1,137 @@
+package link
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/jobs"
+	"github.com/polonkoevv/linkchecker/internal/models"
+	"github.com/polonkoevv/linkchecker/internal/pdfgenerator"
+)
+
+func TestService_CancelJob(t *testing.T) {
+	t.Run("stops the job and stores links checked so far", func(t *testing.T) {
+		checked := make(chan struct{})
+		var stored []models.Link
+		service := &Service{
+			repository: &mockRepository{
+				insertManyFunc: func(links []models.Link) (int, error) {
+					stored = links
+					return 7, nil
+				},
+			},
+			urlChecker: &mockURLChecker{
+				checkFunc: func(ctx context.Context, url string) models.Link {
+					if url == "https://example.com" {
+						return createTestLink(url, models.LinkStatusAvailable)
+					}
+					close(checked)
+					<-ctx.Done()
+					return createTestLink(url, models.LinkStatusNotAvailable)
+				},
+			},
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			jobs:         jobs.NewStore(),
+			workerCount:  1,
+		}
+
+		job, err := service.StartCheckJob(context.Background(), []string{
+			"https://example.com",
+			"https://hang.example.com",
+			"https://never.example.com",
+		}, models.CheckOptions{})
+		if err != nil {
+			t.Fatalf("StartCheckJob() error = %v, want nil", err)
+		}
+		<-checked
+
+		canceled, err := service.CancelJob(context.Background(), job.ID)
+
+		if err != nil {
+			t.Fatalf("CancelJob() error = %v, want nil", err)
+		}
+		if canceled.Status != models.JobStatusCanceled {
+			t.Fatalf("CancelJob() Status = %s, want %s (error: %s)", canceled.Status, models.JobStatusCanceled, canceled.Error)
+		}
+		if canceled.Result == nil || canceled.Result.LinksNum != 7 {
+			t.Fatalf("CancelJob() Result = %+v, want stored group 7", canceled.Result)
+		}
+		want := []models.LinkStatus{models.LinkStatusAvailable, models.LinkStatusSkipped, models.LinkStatusSkipped}
+		if len(stored) != len(want) {
+			t.Fatalf("stored %d links, want %d", len(stored), len(want))
+		}
+		for i, link := range stored {
+			if link.Status != want[i] {
+				t.Errorf("stored link %s Status = %s, want %s", link.URL, link.Status, want[i])
+			}
+		}
+	})
+
+	t.Run("stops a job with a budget", func(t *testing.T) {
+		checked := make(chan struct{})
+		service := &Service{
+			repository: &mockRepository{},
+			urlChecker: &mockURLChecker{
+				checkFunc: func(ctx context.Context, url string) models.Link {
+					close(checked)
+					<-ctx.Done()
+					return createTestLink(url, models.LinkStatusNotAvailable)
+				},
+			},
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			jobs:         jobs.NewStore(),
+			workerCount:  1,
+		}
+
+		job, err := service.StartCheckJob(context.Background(), []string{"https://hang.example.com"}, models.CheckOptions{Budget: time.Hour})
+		if err != nil {
+			t.Fatalf("StartCheckJob() error = %v, want nil", err)
+		}
+		<-checked
+
+		done := make(chan models.Job)
+		go func() {
+			canceled, _ := service.CancelJob(context.Background(), job.ID)
+			done <- canceled
+		}()
+
+		select {
+		case canceled := <-done:
+			if canceled.Status != models.JobStatusCanceled {
+				t.Errorf("CancelJob() Status = %s, want %s", canceled.Status, models.JobStatusCanceled)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("CancelJob() did not stop the job before its budget ran out")
+		}
+	})
+
+	t.Run("finished job returns ErrJobFinished", func(t *testing.T) {
+		service := &Service{
+			repository:   &mockRepository{},
+			urlChecker:   &mockURLChecker{},
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			jobs:         jobs.NewStore(),
+			workerCount:  2,
+		}
+
+		job, err := service.StartCheckJob(context.Background(), []string{"https://example.com"}, models.CheckOptions{})
+		if err != nil {
+			t.Fatalf("StartCheckJob() error = %v, want nil", err)
+		}
+		waitForJob(t, service, job.ID)
+
+		if _, err := service.CancelJob(context.Background(), job.ID); !errors.Is(err, models.ErrJobFinished) {
+			t.Errorf("CancelJob() error = %v, want ErrJobFinished", err)
+		}
+	})
+
+	t.Run("unknown job returns ErrJobNotFound", func(t *testing.T) {
+		service := &Service{jobs: jobs.NewStore()}
+
+		if _, err := service.CancelJob(context.Background(), "missing"); !errors.Is(err, models.ErrJobNotFound) {
+			t.Errorf("CancelJob() error = %v, want ErrJobNotFound", err)
+		}
+	})
+}
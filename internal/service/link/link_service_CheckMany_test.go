@@ -3,11 +3,17 @@ package link
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/polonkoevv/linkchecker/internal/idempotency"
 	"github.com/polonkoevv/linkchecker/internal/models"
 	"github.com/polonkoevv/linkchecker/internal/pdfgenerator"
+	"github.com/polonkoevv/linkchecker/internal/urlchecker"
 )
 
 func TestService_CheckMany(t *testing.T) {
@@ -35,7 +41,7 @@ func TestService_CheckMany(t *testing.T) {
 		}
 
 		ctx := context.Background()
-		result, err := service.CheckMany(ctx, []string{"https://example.com"})
+		result, err := service.CheckMany(ctx, []string{"https://example.com"}, models.CheckOptions{})
 
 		if err != nil {
 			t.Fatalf("CheckMany() error = %v, want nil", err)
@@ -79,7 +85,7 @@ func TestService_CheckMany(t *testing.T) {
 			"https://example.com",
 			"https://example.com", // duplicate
 			"https://google.com",
-		})
+		}, models.CheckOptions{})
 
 		if err != nil {
 			t.Fatalf("CheckMany() error = %v, want nil", err)
@@ -89,6 +95,597 @@ func TestService_CheckMany(t *testing.T) {
 		}
 	})
 
+	t.Run("clamps worker override to max worker count", func(t *testing.T) {
+		service := &Service{
+			repository:     &mockRepository{},
+			urlChecker:     &mockURLChecker{},
+			pdfGenerator:   pdfgenerator.NewGoFPDFGenerator(),
+			workerCount:    2,
+			maxWorkerCount: 4,
+		}
+
+		if got := service.resolveWorkerCount(0); got != 2 {
+			t.Errorf("resolveWorkerCount(0) = %d, want 2", got)
+		}
+		if got := service.resolveWorkerCount(3); got != 3 {
+			t.Errorf("resolveWorkerCount(3) = %d, want 3", got)
+		}
+		if got := service.resolveWorkerCount(100); got != 4 {
+			t.Errorf("resolveWorkerCount(100) = %d, want 4", got)
+		}
+
+		ctx := context.Background()
+		result, err := service.CheckMany(ctx, []string{"https://example.com", "https://google.com"}, models.CheckOptions{Workers: 100})
+		if err != nil {
+			t.Fatalf("CheckMany() error = %v, want nil", err)
+		}
+		if len(result.Links) != 2 {
+			t.Errorf("CheckMany() returned %d links, want 2", len(result.Links))
+		}
+	})
+
+	t.Run("preserves submission order", func(t *testing.T) {
+		submitted := []string{
+			"https://slow.example.com",
+			"https://fast.example.com",
+			"https://medium.example.com",
+			"https://fast.example.com", // duplicate
+			"https://instant.example.com",
+		}
+		delays := map[string]time.Duration{
+			"https://slow.example.com":    30 * time.Millisecond,
+			"https://medium.example.com":  15 * time.Millisecond,
+			"https://fast.example.com":    5 * time.Millisecond,
+			"https://instant.example.com": 0,
+		}
+		want := []string{
+			"https://slow.example.com",
+			"https://fast.example.com",
+			"https://medium.example.com",
+			"https://instant.example.com",
+		}
+
+		var stored []models.Link
+		repo := &mockRepository{
+			insertManyFunc: func(links []models.Link) (int, error) {
+				stored = links
+				return 1, nil
+			},
+		}
+
+		checker := &mockURLChecker{
+			checkFunc: func(ctx context.Context, url string) models.Link {
+				time.Sleep(delays[url])
+				return createTestLink(url, models.LinkStatusAvailable)
+			},
+		}
+
+		service := &Service{
+			repository:   repo,
+			urlChecker:   checker,
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			workerCount:  4,
+		}
+
+		result, err := service.CheckMany(context.Background(), submitted, models.CheckOptions{})
+		if err != nil {
+			t.Fatalf("CheckMany() error = %v, want nil", err)
+		}
+
+		if len(result.Results) != len(want) {
+			t.Fatalf("CheckMany() returned %d results, want %d", len(result.Results), len(want))
+		}
+		for i, url := range want {
+			if result.Results[i].URL != url {
+				t.Errorf("CheckMany() Results[%d].URL = %s, want %s", i, result.Results[i].URL, url)
+			}
+			if stored[i].URL != url {
+				t.Errorf("InsertMany() links[%d].URL = %s, want %s", i, stored[i].URL, url)
+			}
+		}
+	})
+
+	t.Run("limits concurrent checks across calls", func(t *testing.T) {
+		const limit = 2
+
+		var inFlight, peak atomic.Int32
+		checker := &mockURLChecker{
+			checkFunc: func(ctx context.Context, url string) models.Link {
+				n := inFlight.Add(1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				inFlight.Add(-1)
+				return createTestLink(url, models.LinkStatusAvailable)
+			},
+		}
+
+		service := &Service{
+			repository:   &mockRepository{},
+			urlChecker:   checker,
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			workerCount:  4,
+			checkSlots:   make(chan struct{}, limit),
+		}
+
+		links := []string{
+			"https://a.example.com", "https://b.example.com", "https://c.example.com",
+			"https://d.example.com", "https://e.example.com", "https://f.example.com",
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := service.CheckMany(context.Background(), links, models.CheckOptions{}); err != nil {
+					t.Errorf("CheckMany() error = %v, want nil", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if got := peak.Load(); got > limit {
+			t.Errorf("peak concurrent checks = %d, want at most %d", got, limit)
+		}
+	})
+
+	t.Run("marks hung checks as timed out without failing the batch", func(t *testing.T) {
+		var stored []models.Link
+		checker := &mockURLChecker{
+			checkFunc: func(ctx context.Context, url string) models.Link {
+				if url == "https://hung.example.com" {
+					<-ctx.Done()
+					return models.Link{URL: url, Status: models.LinkStatusNotAvailable}
+				}
+				return createTestLink(url, models.LinkStatusAvailable)
+			},
+		}
+
+		service := &Service{
+			repository: &mockRepository{
+				insertManyFunc: func(links []models.Link) (int, error) {
+					stored = links
+					return 1, nil
+				},
+			},
+			urlChecker:   checker,
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			workerCount:  2,
+			checkTimeout: 20 * time.Millisecond,
+		}
+
+		result, err := service.CheckMany(context.Background(), []string{
+			"https://hung.example.com",
+			"https://example.com",
+		}, models.CheckOptions{})
+
+		if err != nil {
+			t.Fatalf("CheckMany() error = %v, want nil", err)
+		}
+		if result.Links["https://example.com"] != models.LinkStatusAvailable {
+			t.Errorf("CheckMany() status of fast link = %s, want %s", result.Links["https://example.com"], models.LinkStatusAvailable)
+		}
+		if len(stored) != 2 || stored[0].Status != models.LinkStatusNotAvailable || stored[0].Error != models.LinkErrorTimeout {
+			t.Errorf("stored hung link = %+v, want not available with error %q", stored[0], models.LinkErrorTimeout)
+		}
+	})
+
+	t.Run("skips links not checked within the budget", func(t *testing.T) {
+		var stored []models.Link
+		checker := &mockURLChecker{
+			checkFunc: func(ctx context.Context, url string) models.Link {
+				if url != "https://fast.example.com" {
+					<-ctx.Done()
+					return models.Link{URL: url, Status: models.LinkStatusNotAvailable}
+				}
+				return createTestLink(url, models.LinkStatusAvailable)
+			},
+		}
+
+		service := &Service{
+			repository: &mockRepository{
+				insertManyFunc: func(links []models.Link) (int, error) {
+					stored = links
+					return 1, nil
+				},
+			},
+			urlChecker:   checker,
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			workerCount:  1,
+		}
+
+		result, err := service.CheckMany(context.Background(), []string{
+			"https://fast.example.com",
+			"https://hung.example.com",
+			"https://queued.example.com",
+		}, models.CheckOptions{Budget: 20 * time.Millisecond})
+
+		if err != nil {
+			t.Fatalf("CheckMany() error = %v, want nil", err)
+		}
+		want := []models.LinkStatus{models.LinkStatusAvailable, models.LinkStatusSkipped, models.LinkStatusSkipped}
+		if len(result.Results) != len(want) {
+			t.Fatalf("CheckMany() returned %d results, want %d", len(result.Results), len(want))
+		}
+		for i, status := range want {
+			if result.Results[i].Status != status {
+				t.Errorf("CheckMany() status of %s = %s, want %s", result.Results[i].URL, result.Results[i].Status, status)
+			}
+		}
+		if len(stored) != 3 || stored[2].URL != "https://queued.example.com" || stored[2].CheckedAt.IsZero() {
+			t.Errorf("stored links = %+v, want skipped queued link with check time", stored)
+		}
+	})
+
+	t.Run("stores group name", func(t *testing.T) {
+		var storedName string
+		service := &Service{
+			repository: &mockRepository{
+				insertNamedFunc: func(name string, links []models.Link) (int, error) {
+					storedName = name
+					return 3, nil
+				},
+			},
+			urlChecker:   &mockURLChecker{},
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			workerCount:  1,
+		}
+
+		result, err := service.CheckMany(context.Background(), []string{"https://example.com"}, models.CheckOptions{Name: "marketing-site"})
+
+		if err != nil {
+			t.Fatalf("CheckMany() error = %v, want nil", err)
+		}
+		if storedName != "marketing-site" {
+			t.Errorf("stored name = %q, want %q", storedName, "marketing-site")
+		}
+		if result.Name != "marketing-site" || result.LinksNum != 3 {
+			t.Errorf("CheckMany() = name %q, links_num %d, want marketing-site, 3", result.Name, result.LinksNum)
+		}
+	})
+
+	t.Run("keeps full checked links for verbose response", func(t *testing.T) {
+		service := &Service{
+			repository: &mockRepository{
+				insertNamedFunc: func(name string, links []models.Link) (int, error) {
+					return 5, nil
+				},
+			},
+			urlChecker: &mockURLChecker{
+				checkFunc: func(ctx context.Context, url string) models.Link {
+					link := createTestLink(url, models.LinkStatusAvailable)
+					link.StatusCode = 200
+					return link
+				},
+			},
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			workerCount:  2,
+		}
+		links := []string{"https://b.example.com", "https://a.example.com"}
+
+		result, err := service.CheckMany(context.Background(), links, models.CheckOptions{Name: "docs"})
+		if err != nil {
+			t.Fatalf("CheckMany() error = %v, want nil", err)
+		}
+
+		verbose := result.Verbose()
+		if verbose.LinksNum != 5 || verbose.Name != "docs" || len(verbose.Links) != len(links) {
+			t.Fatalf("Verbose() = %+v, want links_num 5, name docs and %d links", verbose, len(links))
+		}
+		for i, link := range verbose.Links {
+			if link.URL != links[i] || link.StatusCode != 200 || link.Duration == 0 || link.CheckedAt.IsZero() || link.GroupNum != 5 {
+				t.Errorf("Verbose().Links[%d] = %+v, want full check of %s in group 5", i, link, links[i])
+			}
+		}
+	})
+
+	t.Run("stores link labels", func(t *testing.T) {
+		var stored []models.Link
+		service := &Service{
+			repository: &mockRepository{
+				insertNamedFunc: func(name string, links []models.Link) (int, error) {
+					stored = links
+					return 1, nil
+				},
+			},
+			urlChecker:   &mockURLChecker{},
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			workerCount:  2,
+		}
+		opts := models.CheckOptions{Labels: map[string]string{"https://example.com": "home page"}}
+
+		result, err := service.CheckMany(context.Background(), []string{"https://example.com", "https://google.com"}, opts)
+
+		if err != nil {
+			t.Fatalf("CheckMany() error = %v, want nil", err)
+		}
+		if stored[0].Label != "home page" || stored[1].Label != "" {
+			t.Errorf("stored labels = %q, %q, want \"home page\", \"\"", stored[0].Label, stored[1].Label)
+		}
+		if result.Results[0].Label != "home page" || result.Results[1].Label != "" {
+			t.Errorf("CheckMany() labels = %q, %q, want \"home page\", \"\"", result.Results[0].Label, result.Results[1].Label)
+		}
+	})
+
+	t.Run("flags available links slower than the threshold", func(t *testing.T) {
+		var stored []models.Link
+		durations := map[string]time.Duration{
+			"https://fast.example.com": 100 * time.Millisecond,
+			"https://slow.example.com": 3 * time.Second,
+			"https://down.example.com": 5 * time.Second,
+		}
+		service := &Service{
+			repository: &mockRepository{
+				insertNamedFunc: func(name string, links []models.Link) (int, error) {
+					stored = links
+					return 1, nil
+				},
+			},
+			urlChecker: &mockURLChecker{
+				checkFunc: func(ctx context.Context, rawURL string) models.Link {
+					status := models.LinkStatusAvailable
+					if rawURL == "https://down.example.com" {
+						status = models.LinkStatusNotAvailable
+					}
+					link := createTestLink(rawURL, status)
+					link.Duration = durations[rawURL]
+					return link
+				},
+			},
+			workerCount:   2,
+			slowThreshold: time.Second,
+		}
+
+		result, err := service.CheckMany(context.Background(),
+			[]string{"https://fast.example.com", "https://slow.example.com", "https://down.example.com"}, models.CheckOptions{})
+
+		if err != nil {
+			t.Fatalf("CheckMany() error = %v, want nil", err)
+		}
+		if stored[0].Slow || !stored[1].Slow || stored[2].Slow {
+			t.Errorf("stored slow flags = %v, %v, %v, want only the available slow link flagged", stored[0].Slow, stored[1].Slow, stored[2].Slow)
+		}
+		if !result.Results[1].Slow || result.Results[1].Status != models.LinkStatusAvailable {
+			t.Errorf("CheckMany() result = %+v, want available and slow", result.Results[1])
+		}
+	})
+
+	t.Run("aggregates durations over samples", func(t *testing.T) {
+		var (
+			stored []models.Link
+			calls  atomic.Int64
+		)
+		checker := &mockURLChecker{
+			checkFunc: func(ctx context.Context, url string) models.Link {
+				n := calls.Add(1)
+				link := createTestLink(url, models.LinkStatusAvailable)
+				link.Duration = time.Duration(n) * 10 * time.Millisecond
+				return link
+			},
+		}
+		service := &Service{
+			repository: &mockRepository{
+				insertNamedFunc: func(name string, links []models.Link) (int, error) {
+					stored = links
+					return 1, nil
+				},
+			},
+			urlChecker:   checker,
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			workerCount:  1,
+		}
+
+		result, err := service.CheckMany(context.Background(), []string{"https://example.com"}, models.CheckOptions{Samples: 3})
+
+		if err != nil {
+			t.Fatalf("CheckMany() error = %v, want nil", err)
+		}
+		if calls.Load() != 3 {
+			t.Errorf("checker called %d times, want 3", calls.Load())
+		}
+		want := models.LatencyStats{Samples: 3, Min: 10 * time.Millisecond, Avg: 20 * time.Millisecond, Max: 30 * time.Millisecond}
+		if stored[0].Latency == nil || *stored[0].Latency != want {
+			t.Errorf("stored latency = %+v, want %+v", stored[0].Latency, want)
+		}
+		if stored[0].Duration != want.Avg {
+			t.Errorf("stored duration = %v, want average %v", stored[0].Duration, want.Avg)
+		}
+		if result.Results[0].Latency == nil || *result.Results[0].Latency != want {
+			t.Errorf("CheckMany() latency = %+v, want %+v", result.Results[0].Latency, want)
+		}
+	})
+
+	t.Run("skips links on denied hosts without checking them", func(t *testing.T) {
+		var checked []string
+		var mu sync.Mutex
+		checker := &mockURLChecker{
+			checkFunc: func(ctx context.Context, url string) models.Link {
+				mu.Lock()
+				checked = append(checked, url)
+				mu.Unlock()
+				return createTestLink(url, models.LinkStatusAvailable)
+			},
+		}
+		service := &Service{
+			repository:   &mockRepository{},
+			urlChecker:   checker,
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			workerCount:  2,
+			skipHosts:    []string{"*.internal.example.com", "billing.example.com"},
+		}
+
+		var reported int
+		result, err := service.CheckMany(context.Background(), []string{
+			"https://admin.internal.example.com/panel",
+			"https://example.com",
+			"BILLING.example.com/api",
+		}, models.CheckOptions{OnResult: func(models.Link) { reported++ }})
+
+		if err != nil {
+			t.Fatalf("CheckMany() error = %v, want nil", err)
+		}
+		want := []models.LinkStatus{models.LinkStatusSkippedDenylist, models.LinkStatusAvailable, models.LinkStatusSkippedDenylist}
+		for i, status := range want {
+			if result.Results[i].Status != status {
+				t.Errorf("CheckMany() status of %s = %s, want %s", result.Results[i].URL, result.Results[i].Status, status)
+			}
+		}
+		if len(checked) != 1 || checked[0] != "https://example.com" {
+			t.Errorf("checked URLs = %v, want only https://example.com", checked)
+		}
+		if reported != 3 {
+			t.Errorf("OnResult called %d times, want 3", reported)
+		}
+	})
+
+	t.Run("replays stored group for repeated idempotency key", func(t *testing.T) {
+		var inserts int
+		var stored []models.Link
+		checker := &mockURLChecker{}
+		service := &Service{
+			repository: &mockRepository{
+				insertNamedFunc: func(name string, links []models.Link) (int, error) {
+					inserts++
+					stored = links
+					return 5, nil
+				},
+				getByNumFunc: func(num int) (models.Links, error) {
+					return models.Links{LinksNum: num, Links: stored, Name: "retry"}, nil
+				},
+			},
+			urlChecker:   checker,
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			idempotency:  idempotency.NewStore(time.Hour),
+			workerCount:  1,
+		}
+		opts := models.CheckOptions{Name: "retry", IdempotencyKey: "key-1"}
+
+		first, err := service.CheckMany(context.Background(), []string{"https://example.com"}, opts)
+		if err != nil {
+			t.Fatalf("CheckMany() error = %v, want nil", err)
+		}
+		second, err := service.CheckMany(context.Background(), []string{"https://example.com"}, opts)
+		if err != nil {
+			t.Fatalf("CheckMany() retry error = %v, want nil", err)
+		}
+
+		if inserts != 1 {
+			t.Errorf("stored %d groups, want 1", inserts)
+		}
+		if second.LinksNum != first.LinksNum || second.Name != "retry" || len(second.Results) != 1 ||
+			second.Results[0] != first.Results[0] {
+			t.Errorf("CheckMany() retry = %+v, want %+v", second, first)
+		}
+
+		if _, err := service.CheckMany(context.Background(), []string{"https://example.com"}, models.CheckOptions{IdempotencyKey: "key-2"}); err != nil {
+			t.Fatalf("CheckMany() other key error = %v, want nil", err)
+		}
+		if inserts != 2 {
+			t.Errorf("stored %d groups after new key, want 2", inserts)
+		}
+	})
+
+	t.Run("concurrent calls with one idempotency key check once", func(t *testing.T) {
+		var inserts atomic.Int32
+		var stored []models.Link
+		started := make(chan struct{})
+		unblock := make(chan struct{})
+		checker := &mockURLChecker{
+			checkFunc: func(ctx context.Context, url string) models.Link {
+				close(started)
+				<-unblock
+				return models.Link{URL: url, Status: models.LinkStatusAvailable}
+			},
+		}
+		service := &Service{
+			repository: &mockRepository{
+				insertNamedFunc: func(name string, links []models.Link) (int, error) {
+					inserts.Add(1)
+					stored = links
+					return 5, nil
+				},
+				getByNumFunc: func(num int) (models.Links, error) {
+					return models.Links{LinksNum: num, Links: stored}, nil
+				},
+			},
+			urlChecker:   checker,
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			idempotency:  idempotency.NewStore(time.Hour),
+			workerCount:  1,
+		}
+		opts := models.CheckOptions{IdempotencyKey: "key-1"}
+
+		first := make(chan error, 1)
+		go func() {
+			_, err := service.CheckMany(context.Background(), []string{"https://example.com"}, opts)
+			first <- err
+		}()
+		<-started
+
+		second := make(chan models.LinksResponse, 1)
+		go func() {
+			res, err := service.CheckMany(context.Background(), []string{"https://example.com"}, opts)
+			if err != nil {
+				t.Errorf("CheckMany() retry error = %v, want nil", err)
+			}
+			second <- res
+		}()
+
+		time.Sleep(50 * time.Millisecond)
+		close(unblock)
+
+		if err := <-first; err != nil {
+			t.Fatalf("CheckMany() error = %v, want nil", err)
+		}
+		if res := <-second; res.LinksNum != 5 {
+			t.Errorf("CheckMany() retry LinksNum = %d, want 5", res.LinksNum)
+		}
+		if n := inserts.Load(); n != 1 {
+			t.Errorf("stored %d groups, want 1", n)
+		}
+	})
+
+	t.Run("reports each result and progress via callbacks", func(t *testing.T) {
+		service := &Service{
+			repository:   &mockRepository{},
+			urlChecker:   &mockURLChecker{},
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			workerCount:  2,
+		}
+
+		var streamed []models.Link
+		var lastChecked, lastTotal int
+		opts := models.CheckOptions{
+			OnResult: func(link models.Link) {
+				streamed = append(streamed, link)
+			},
+			Progress: func(checked, total int) {
+				lastChecked, lastTotal = checked, total
+			},
+		}
+
+		_, err := service.CheckMany(context.Background(), []string{
+			"https://example.com",
+			"https://google.com",
+			"https://github.com",
+		}, opts)
+		if err != nil {
+			t.Fatalf("CheckMany() error = %v, want nil", err)
+		}
+
+		if len(streamed) != 3 {
+			t.Errorf("OnResult called %d times, want 3", len(streamed))
+		}
+		if lastChecked != 3 || lastTotal != 3 {
+			t.Errorf("Progress last call = (%d, %d), want (3, 3)", lastChecked, lastTotal)
+		}
+	})
+
 	t.Run("returns empty response for empty links", func(t *testing.T) {
 		service := &Service{
 			repository:   &mockRepository{},
@@ -98,7 +695,7 @@ func TestService_CheckMany(t *testing.T) {
 		}
 
 		ctx := context.Background()
-		result, err := service.CheckMany(ctx, []string{})
+		result, err := service.CheckMany(ctx, []string{}, models.CheckOptions{})
 
 		if err != nil {
 			t.Fatalf("CheckMany() error = %v, want nil", err)
@@ -132,7 +729,7 @@ func TestService_CheckMany(t *testing.T) {
 		}
 
 		ctx := context.Background()
-		_, err := service.CheckMany(ctx, []string{"https://example.com"})
+		_, err := service.CheckMany(ctx, []string{"https://example.com"}, models.CheckOptions{})
 
 		if err == nil {
 			t.Error("CheckMany() error = nil, want error")
@@ -150,7 +747,7 @@ func TestService_CheckMany(t *testing.T) {
 		ctx, cancel := context.WithCancel(context.Background())
 		cancel() // Cancel immediately
 
-		_, err := service.CheckMany(ctx, []string{"https://example.com"})
+		_, err := service.CheckMany(ctx, []string{"https://example.com"}, models.CheckOptions{})
 
 		if err == nil {
 			t.Error("CheckMany() error = nil, want context.Canceled")
@@ -173,7 +770,7 @@ func TestService_CheckMany(t *testing.T) {
 
 		time.Sleep(10 * time.Millisecond) // Ensure timeout
 
-		_, err := service.CheckMany(ctx, []string{"https://example.com"})
+		_, err := service.CheckMany(ctx, []string{"https://example.com"}, models.CheckOptions{})
 
 		if err == nil {
 			t.Error("CheckMany() error = nil, want context.DeadlineExceeded")
@@ -182,4 +779,38 @@ func TestService_CheckMany(t *testing.T) {
 			t.Errorf("CheckMany() error = %v, want context.DeadlineExceeded", err)
 		}
 	})
+
+	t.Run("re-check of available link is conditional on its last modification", func(t *testing.T) {
+		modified := time.Date(2024, time.January, 15, 10, 30, 0, 0, time.UTC)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if since, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil && !modified.After(since) {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer srv.Close()
+
+		service := &Service{
+			repository: &mockRepository{
+				latestFunc: func(urls []string) (map[string]models.Link, error) {
+					return map[string]models.Link{
+						srv.URL: {URL: srv.URL, Status: models.LinkStatusAvailable, CheckedAt: modified, LastModified: &modified},
+					}, nil
+				},
+			},
+			urlChecker:   urlchecker.NewChecker(),
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			workerCount:  1,
+		}
+
+		result, err := service.CheckMany(context.Background(), []string{srv.URL}, models.CheckOptions{})
+
+		if err != nil {
+			t.Fatalf("CheckMany() error = %v, want nil", err)
+		}
+		if got := result.Results[0]; got.Status != models.LinkStatusAvailable || !got.Unchanged {
+			t.Errorf("CheckMany() result = %+v, want available and unchanged", got)
+		}
+	})
 }
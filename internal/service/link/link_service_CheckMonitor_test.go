@@ -0,0 +1,177 @@
+package link
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestService_CheckMonitor(t *testing.T) {
+	newService := func(repo *mockRepository, status map[string]models.LinkStatus) *Service {
+		return &Service{
+			repository: repo,
+			urlChecker: &mockURLChecker{
+				checkFunc: func(ctx context.Context, url string) models.Link {
+					return createTestLink(url, status[url])
+				},
+			},
+			pdfGenerator:    &mockPDFGenerator{},
+			workerCount:     2,
+			monitorURLs:     []string{"https://a.test", "https://b.test"},
+			monitorName:     "monitor",
+			monitorInterval: time.Minute,
+		}
+	}
+
+	t.Run("disabled without URLs", func(t *testing.T) {
+		service := &Service{repository: &mockRepository{}, urlChecker: &mockURLChecker{}, workerCount: 1}
+
+		if _, err := service.CheckMonitor(context.Background()); !errors.Is(err, models.ErrMonitorDisabled) {
+			t.Errorf("CheckMonitor() error = %v, want ErrMonitorDisabled", err)
+		}
+		if _, err := service.Monitor(context.Background()); !errors.Is(err, models.ErrMonitorDisabled) {
+			t.Errorf("Monitor() error = %v, want ErrMonitorDisabled", err)
+		}
+	})
+
+	t.Run("stores the first round and replaces it on the next ones", func(t *testing.T) {
+		stored := map[int]models.Links{}
+		inserts := 0
+		repo := &mockRepository{
+			insertNamedFunc: func(name string, links []models.Link) (int, error) {
+				inserts++
+				stored[7] = models.Links{LinksNum: 7, Name: name, Links: links}
+				return 7, nil
+			},
+			replaceFunc: func(num int, links []models.Link) error {
+				group, ok := stored[num]
+				if !ok {
+					return &models.GroupNotFoundError{Nums: []int{num}}
+				}
+				group.Links = links
+				stored[num] = group
+				return nil
+			},
+			getByNumFunc: func(num int) (models.Links, error) {
+				return stored[num], nil
+			},
+		}
+		status := map[string]models.LinkStatus{
+			"https://a.test": models.LinkStatusAvailable,
+			"https://b.test": models.LinkStatusAvailable,
+		}
+		service := newService(repo, status)
+
+		if _, err := service.Monitor(context.Background()); !errors.Is(err, models.ErrMonitorPending) {
+			t.Fatalf("Monitor() before the first round error = %v, want ErrMonitorPending", err)
+		}
+
+		if _, err := service.CheckMonitor(context.Background()); err != nil {
+			t.Fatalf("CheckMonitor() error = %v, want nil", err)
+		}
+		stored[7].Links[1].Ignored = true
+		status["https://b.test"] = models.LinkStatusNotAvailable
+		res, err := service.CheckMonitor(context.Background())
+		if err != nil {
+			t.Fatalf("second CheckMonitor() error = %v, want nil", err)
+		}
+		if res.LinksNum != 7 || inserts != 1 {
+			t.Errorf("second round stored as group %d with %d inserts, want group 7 with 1 insert", res.LinksNum, inserts)
+		}
+
+		monitor, err := service.Monitor(context.Background())
+		if err != nil {
+			t.Fatalf("Monitor() error = %v, want nil", err)
+		}
+		if monitor.LinksNum != 7 || monitor.Name != "monitor" || monitor.IntervalSeconds != 60 {
+			t.Errorf("Monitor() = group %d %q every %ds, want group 7 \"monitor\" every 60s",
+				monitor.LinksNum, monitor.Name, monitor.IntervalSeconds)
+		}
+		if monitor.Total != 2 || monitor.Available != 1 || monitor.CheckedAt.IsZero() {
+			t.Errorf("Monitor() = %d/%d available checked at %v, want 1/2 with a check time",
+				monitor.Available, monitor.Total, monitor.CheckedAt)
+		}
+		if monitor.Ignored != 1 || !monitor.Links[1].Ignored {
+			t.Errorf("Monitor() = %d ignored, want b.test kept ignored across rounds", monitor.Ignored)
+		}
+	})
+
+	t.Run("continues the latest stored monitor group", func(t *testing.T) {
+		var replaced int
+		lastChecked := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+		repo := &mockRepository{
+			summariesFunc: func() ([]models.GroupSummary, error) {
+				return []models.GroupSummary{
+					{LinksNum: 2, Name: "monitor"},
+					{LinksNum: 5, Name: "monitor", LastCheckedAt: lastChecked},
+					{LinksNum: 6, Name: "docs"},
+				}, nil
+			},
+			replaceFunc: func(num int, links []models.Link) error {
+				replaced = num
+				return nil
+			},
+			insertNamedFunc: func(name string, links []models.Link) (int, error) {
+				t.Error("InsertNamed() called, want the stored group replaced")
+				return 8, nil
+			},
+			getByNumFunc: func(num int) (models.Links, error) {
+				return models.Links{LinksNum: num, Name: "monitor", Links: []models.Link{
+					createTestLink("https://a.test", models.LinkStatusAvailable),
+				}}, nil
+			},
+		}
+		service := newService(repo, nil)
+
+		monitor, err := service.Monitor(context.Background())
+		if err != nil {
+			t.Fatalf("Monitor() error = %v, want nil", err)
+		}
+		if monitor.LinksNum != 5 || !monitor.CheckedAt.Equal(lastChecked) {
+			t.Errorf("Monitor() = group %d checked at %v, want group 5 checked at %v", monitor.LinksNum, monitor.CheckedAt, lastChecked)
+		}
+
+		if _, err := service.CheckMonitor(context.Background()); err != nil {
+			t.Fatalf("CheckMonitor() error = %v, want nil", err)
+		}
+		if replaced != 5 {
+			t.Errorf("CheckMonitor() replaced group %d, want 5", replaced)
+		}
+	})
+
+	t.Run("stores a new group once the monitor group is gone", func(t *testing.T) {
+		repo := &mockRepository{
+			insertNamedFunc: func(name string, links []models.Link) (int, error) {
+				return 9, nil
+			},
+		}
+		service := newService(repo, nil)
+		service.monitorNum = 4
+
+		if _, err := service.Monitor(context.Background()); !errors.Is(err, models.ErrMonitorPending) {
+			t.Errorf("Monitor() of a deleted group error = %v, want ErrMonitorPending", err)
+		}
+
+		res, err := service.CheckMonitor(context.Background())
+		if err != nil {
+			t.Fatalf("CheckMonitor() error = %v, want nil", err)
+		}
+		if res.LinksNum != 9 || service.monitorNum != 9 {
+			t.Errorf("CheckMonitor() stored group %d, monitor group %d, want 9", res.LinksNum, service.monitorNum)
+		}
+	})
+
+	t.Run("rejected after shutdown", func(t *testing.T) {
+		service := newService(&mockRepository{}, nil)
+		if err := service.Shutdown(context.Background()); err != nil {
+			t.Fatalf("Shutdown() error = %v, want nil", err)
+		}
+
+		if _, err := service.CheckMonitor(context.Background()); !errors.Is(err, models.ErrShuttingDown) {
+			t.Errorf("CheckMonitor() error = %v, want ErrShuttingDown", err)
+		}
+	})
+}
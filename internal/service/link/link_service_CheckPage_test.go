@@ -0,0 +1,93 @@
+package link
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+	"github.com/polonkoevv/linkchecker/internal/pdfgenerator"
+)
+
+// mockLinkExtractor is a mock implementation of pageLinkExtractor interface.
+type mockLinkExtractor struct {
+	extractFunc func(ctx context.Context, pageURL string) ([]string, error)
+}
+
+func (m *mockLinkExtractor) ExtractLinks(ctx context.Context, pageURL string) ([]string, error) {
+	if m.extractFunc != nil {
+		return m.extractFunc(ctx, pageURL)
+	}
+	return []string{}, nil
+}
+
+func TestService_CheckPage(t *testing.T) {
+	t.Run("checks links found on page and returns source", func(t *testing.T) {
+		extractor := &mockLinkExtractor{
+			extractFunc: func(ctx context.Context, pageURL string) ([]string, error) {
+				return []string{"https://example.com/a", "https://other.com"}, nil
+			},
+		}
+
+		service := &Service{
+			repository:    &mockRepository{},
+			urlChecker:    &mockURLChecker{},
+			pdfGenerator:  pdfgenerator.NewGoFPDFGenerator(),
+			linkExtractor: extractor,
+			workerCount:   2,
+		}
+
+		ctx := context.Background()
+		result, err := service.CheckPage(ctx, "https://example.com", models.CheckOptions{})
+
+		if err != nil {
+			t.Fatalf("CheckPage() error = %v, want nil", err)
+		}
+		if result.Source != "https://example.com" {
+			t.Errorf("CheckPage() Source = %s, want https://example.com", result.Source)
+		}
+		if len(result.Links) != 2 {
+			t.Errorf("CheckPage() returned %d links, want 2", len(result.Links))
+		}
+	})
+
+	t.Run("returns ErrSourceUnavailable on extract error", func(t *testing.T) {
+		extractor := &mockLinkExtractor{
+			extractFunc: func(ctx context.Context, pageURL string) ([]string, error) {
+				return nil, errors.New("fetch page: unexpected status code 404")
+			},
+		}
+
+		service := &Service{
+			repository:    &mockRepository{},
+			urlChecker:    &mockURLChecker{},
+			pdfGenerator:  pdfgenerator.NewGoFPDFGenerator(),
+			linkExtractor: extractor,
+			workerCount:   2,
+		}
+
+		ctx := context.Background()
+		_, err := service.CheckPage(ctx, "https://example.com", models.CheckOptions{})
+
+		if !errors.Is(err, models.ErrSourceUnavailable) {
+			t.Errorf("CheckPage() error = %v, want ErrSourceUnavailable", err)
+		}
+	})
+
+	t.Run("returns ErrNoLinksFound for page without links", func(t *testing.T) {
+		service := &Service{
+			repository:    &mockRepository{},
+			urlChecker:    &mockURLChecker{},
+			pdfGenerator:  pdfgenerator.NewGoFPDFGenerator(),
+			linkExtractor: &mockLinkExtractor{},
+			workerCount:   2,
+		}
+
+		ctx := context.Background()
+		_, err := service.CheckPage(ctx, "https://example.com", models.CheckOptions{})
+
+		if !errors.Is(err, models.ErrNoLinksFound) {
+			t.Errorf("CheckPage() error = %v, want ErrNoLinksFound", err)
+		}
+	})
+}
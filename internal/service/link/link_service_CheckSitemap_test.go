@@ -0,0 +1,93 @@
+package link
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+	"github.com/polonkoevv/linkchecker/internal/pdfgenerator"
+)
+
+// mockSitemapFetcher is a mock implementation of sitemapFetcher interface.
+type mockSitemapFetcher struct {
+	fetchFunc func(ctx context.Context, sitemapURL string) ([]string, error)
+}
+
+func (m *mockSitemapFetcher) Fetch(ctx context.Context, sitemapURL string) ([]string, error) {
+	if m.fetchFunc != nil {
+		return m.fetchFunc(ctx, sitemapURL)
+	}
+	return []string{}, nil
+}
+
+func TestService_CheckSitemap(t *testing.T) {
+	t.Run("checks links listed in sitemap", func(t *testing.T) {
+		fetcher := &mockSitemapFetcher{
+			fetchFunc: func(ctx context.Context, sitemapURL string) ([]string, error) {
+				if sitemapURL != "https://example.com/sitemap.xml" {
+					t.Errorf("Fetch() called with %s, want https://example.com/sitemap.xml", sitemapURL)
+				}
+				return []string{"https://example.com/a", "https://example.com/b"}, nil
+			},
+		}
+
+		service := &Service{
+			repository:     &mockRepository{},
+			urlChecker:     &mockURLChecker{},
+			pdfGenerator:   pdfgenerator.NewGoFPDFGenerator(),
+			sitemapFetcher: fetcher,
+			workerCount:    2,
+		}
+
+		ctx := context.Background()
+		result, err := service.CheckSitemap(ctx, "https://example.com/sitemap.xml", models.CheckOptions{})
+
+		if err != nil {
+			t.Fatalf("CheckSitemap() error = %v, want nil", err)
+		}
+		if len(result.Links) != 2 {
+			t.Errorf("CheckSitemap() returned %d links, want 2", len(result.Links))
+		}
+	})
+
+	t.Run("returns ErrSourceUnavailable on fetch error", func(t *testing.T) {
+		fetcher := &mockSitemapFetcher{
+			fetchFunc: func(ctx context.Context, sitemapURL string) ([]string, error) {
+				return nil, errors.New("connection refused")
+			},
+		}
+
+		service := &Service{
+			repository:     &mockRepository{},
+			urlChecker:     &mockURLChecker{},
+			pdfGenerator:   pdfgenerator.NewGoFPDFGenerator(),
+			sitemapFetcher: fetcher,
+			workerCount:    2,
+		}
+
+		ctx := context.Background()
+		_, err := service.CheckSitemap(ctx, "https://example.com/sitemap.xml", models.CheckOptions{})
+
+		if !errors.Is(err, models.ErrSourceUnavailable) {
+			t.Errorf("CheckSitemap() error = %v, want ErrSourceUnavailable", err)
+		}
+	})
+
+	t.Run("returns ErrNoLinksFound for empty sitemap", func(t *testing.T) {
+		service := &Service{
+			repository:     &mockRepository{},
+			urlChecker:     &mockURLChecker{},
+			pdfGenerator:   pdfgenerator.NewGoFPDFGenerator(),
+			sitemapFetcher: &mockSitemapFetcher{},
+			workerCount:    2,
+		}
+
+		ctx := context.Background()
+		_, err := service.CheckSitemap(ctx, "https://example.com/sitemap.xml", models.CheckOptions{})
+
+		if !errors.Is(err, models.ErrNoLinksFound) {
+			t.Errorf("CheckSitemap() error = %v, want ErrNoLinksFound", err)
+		}
+	})
+}
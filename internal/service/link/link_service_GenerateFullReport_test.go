@@ -0,0 +1,121 @@
+package link
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestService_GenerateFullReport(t *testing.T) {
+	groups := func() []models.Links {
+		return []models.Links{
+			{LinksNum: 3, Links: []models.Link{createTestLink("https://github.com", models.LinkStatusNotAvailable)}},
+			{LinksNum: 1, Links: []models.Link{createTestLink("https://example.com", models.LinkStatusAvailable)}},
+			{LinksNum: 2, Links: []models.Link{createTestLink("https://google.com", models.LinkStatusAvailable)}},
+		}
+	}
+
+	t.Run("reports all groups in order", func(t *testing.T) {
+		var reported []models.Links
+		service := &Service{
+			repository: &mockRepository{
+				getAllFunc: func() ([]models.Links, error) { return groups(), nil },
+			},
+			urlChecker: &mockURLChecker{},
+			pdfGenerator: &mockPDFGenerator{
+				generateFunc: func(ctx context.Context, w io.Writer, linksSlice []models.Links, opts models.ReportOptions) error {
+					reported = linksSlice
+					_, err := io.WriteString(w, "%PDF")
+					return err
+				},
+			},
+			workerCount: 2,
+		}
+
+		var buf bytes.Buffer
+		result, err := service.GenerateFullReport(context.Background(), models.ReportOptions{}, &buf)
+
+		if err != nil {
+			t.Fatalf("GenerateFullReport() error = %v, want nil", err)
+		}
+		if buf.String() != "%PDF" || result.Size != buf.Len() {
+			t.Errorf("GenerateFullReport() wrote %q with Size = %d, want %%PDF with its size", buf.String(), result.Size)
+		}
+		if len(reported) != 3 {
+			t.Fatalf("GenerateFullReport() reported %d groups, want 3", len(reported))
+		}
+		for i, g := range reported {
+			if g.LinksNum != i+1 {
+				t.Errorf("GenerateFullReport() group[%d] = %d, want %d", i, g.LinksNum, i+1)
+			}
+		}
+		if result.Statistics.Groups != 3 || result.Statistics.Total != 3 {
+			t.Errorf("GenerateFullReport() Statistics = %+v, want 3 groups and 3 links", result.Statistics)
+		}
+	})
+
+	t.Run("rejects more groups than the limit", func(t *testing.T) {
+		service := &Service{
+			repository: &mockRepository{
+				getAllFunc: func() ([]models.Links, error) { return groups(), nil },
+			},
+			urlChecker:      &mockURLChecker{},
+			pdfGenerator:    &mockPDFGenerator{},
+			workerCount:     2,
+			maxReportGroups: 2,
+		}
+
+		_, err := service.GenerateFullReport(context.Background(), models.ReportOptions{}, io.Discard)
+
+		if !errors.Is(err, models.ErrReportTooLarge) {
+			t.Errorf("GenerateFullReport() error = %v, want ErrReportTooLarge", err)
+		}
+	})
+
+	t.Run("rejects more links than the limit before rendering", func(t *testing.T) {
+		rendered := false
+		service := &Service{
+			repository: &mockRepository{
+				getAllFunc: func() ([]models.Links, error) { return groups(), nil },
+			},
+			urlChecker: &mockURLChecker{},
+			pdfGenerator: &mockPDFGenerator{
+				generateFunc: func(ctx context.Context, w io.Writer, linksSlice []models.Links, opts models.ReportOptions) error {
+					rendered = true
+					return nil
+				},
+			},
+			workerCount:    2,
+			maxReportLinks: 2,
+		}
+
+		var buf bytes.Buffer
+		_, err := service.GenerateFullReport(context.Background(), models.ReportOptions{}, &buf)
+
+		if !errors.Is(err, models.ErrReportTooLarge) {
+			t.Errorf("GenerateFullReport() error = %v, want ErrReportTooLarge", err)
+		}
+		if rendered || buf.Len() != 0 {
+			t.Error("GenerateFullReport() rendered a report over the links limit")
+		}
+	})
+
+	t.Run("returns group not found when storage is empty", func(t *testing.T) {
+		service := &Service{
+			repository:   &mockRepository{},
+			urlChecker:   &mockURLChecker{},
+			pdfGenerator: &mockPDFGenerator{},
+			workerCount:  2,
+		}
+
+		_, err := service.GenerateFullReport(context.Background(), models.ReportOptions{}, io.Discard)
+
+		if !errors.Is(err, models.ErrGroupNotFound) {
+			t.Errorf("GenerateFullReport() error = %v, want ErrGroupNotFound", err)
+		}
+	})
+}
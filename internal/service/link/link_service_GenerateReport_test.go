@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"io"
 	"testing"
 
 	"github.com/polonkoevv/linkchecker/internal/models"
@@ -35,16 +36,48 @@ func TestService_GenerateReport(t *testing.T) {
 		}
 
 		ctx := context.Background()
-		result, err := service.GenerateReport(ctx, []int{1})
+		var buf bytes.Buffer
+		result, err := service.GenerateReport(ctx, []int{1}, models.ReportOptions{}, &buf)
 
 		if err != nil {
 			t.Fatalf("GenerateReport() error = %v, want nil", err)
 		}
 		if result == nil {
-			t.Error("GenerateReport() result = nil, want buffer")
+			t.Fatal("GenerateReport() result = nil, want report")
 		}
-		if result.Len() == 0 {
-			t.Error("GenerateReport() returned empty buffer")
+		if buf.Len() == 0 || result.Size != buf.Len() {
+			t.Errorf("GenerateReport() wrote %d bytes with Size = %d, want a non-empty PDF of that size", buf.Len(), result.Size)
+		}
+		if result.Statistics.Total != 1 || result.Statistics.Available != 1 {
+			t.Errorf("GenerateReport() Statistics = %+v, want 1 available of 1", result.Statistics)
+		}
+	})
+
+	t.Run("renders HTML with the HTML generator", func(t *testing.T) {
+		repo := &mockRepository{
+			getByNumsFunc: func(linksNum []int) ([]models.Links, error) {
+				return []models.Links{{LinksNum: 1, Links: []models.Link{
+					createTestLink("https://example.com", models.LinkStatusAvailable),
+				}}}, nil
+			},
+		}
+
+		service := &Service{
+			repository:    repo,
+			urlChecker:    &mockURLChecker{},
+			pdfGenerator:  pdfgenerator.NewGoFPDFGenerator(),
+			htmlGenerator: pdfgenerator.NewHTMLGenerator(),
+			workerCount:   2,
+		}
+
+		var buf bytes.Buffer
+		result, err := service.GenerateReport(context.Background(), []int{1}, models.ReportOptions{Format: models.ReportFormatHTML}, &buf)
+
+		if err != nil {
+			t.Fatalf("GenerateReport() error = %v, want nil", err)
+		}
+		if !bytes.HasPrefix(buf.Bytes(), []byte("<!DOCTYPE html>")) || result.Size != buf.Len() {
+			t.Errorf("GenerateReport() wrote %d bytes starting with %.20q, Size = %d, want an HTML page of that size", buf.Len(), buf.String(), result.Size)
 		}
 	})
 
@@ -63,13 +96,39 @@ func TestService_GenerateReport(t *testing.T) {
 		}
 
 		ctx := context.Background()
-		_, err := service.GenerateReport(ctx, []int{1})
+		_, err := service.GenerateReport(ctx, []int{1}, models.ReportOptions{}, io.Discard)
 
 		if err == nil {
 			t.Error("GenerateReport() error = nil, want error")
 		}
 	})
 
+	t.Run("propagates group not found error", func(t *testing.T) {
+		repo := &mockRepository{
+			getByNumsFunc: func(linksNum []int) ([]models.Links, error) {
+				return nil, &models.GroupNotFoundError{Nums: linksNum}
+			},
+		}
+
+		service := &Service{
+			repository:   repo,
+			urlChecker:   &mockURLChecker{},
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			workerCount:  2,
+		}
+
+		ctx := context.Background()
+		_, err := service.GenerateReport(ctx, []int{7, 8}, models.ReportOptions{}, io.Discard)
+
+		var notFound *models.GroupNotFoundError
+		if !errors.As(err, &notFound) {
+			t.Fatalf("GenerateReport() error = %v, want GroupNotFoundError", err)
+		}
+		if len(notFound.Nums) != 2 {
+			t.Errorf("GenerateReport() missing nums = %v, want [7 8]", notFound.Nums)
+		}
+	})
+
 	t.Run("handles PDF generator error", func(t *testing.T) {
 		links := []models.Links{
 			{
@@ -87,8 +146,8 @@ func TestService_GenerateReport(t *testing.T) {
 		}
 
 		pdfGen := &mockPDFGenerator{
-			generateFunc: func(linksSlice []models.Links) (*bytes.Buffer, error) {
-				return nil, errors.New("PDF generation error")
+			generateFunc: func(ctx context.Context, w io.Writer, linksSlice []models.Links, opts models.ReportOptions) error {
+				return errors.New("PDF generation error")
 			},
 		}
 
@@ -100,13 +159,58 @@ func TestService_GenerateReport(t *testing.T) {
 		}
 
 		ctx := context.Background()
-		_, err := service.GenerateReport(ctx, []int{1})
+		_, err := service.GenerateReport(ctx, []int{1}, models.ReportOptions{}, io.Discard)
 
 		if err == nil {
 			t.Error("GenerateReport() error = nil, want error")
 		}
 	})
 
+	t.Run("applies custom report options", func(t *testing.T) {
+		repo := &mockRepository{
+			getByNumsFunc: func(linksNum []int) ([]models.Links, error) {
+				return []models.Links{{
+					LinksNum: 1,
+					Links: []models.Link{
+						createTestLink("https://example.com", models.LinkStatusAvailable),
+					},
+				}}, nil
+			},
+		}
+
+		service := &Service{
+			repository:   repo,
+			urlChecker:   &mockURLChecker{},
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			workerCount:  2,
+		}
+
+		ctx := context.Background()
+		result, err := service.GenerateReport(ctx, []int{1}, models.ReportOptions{
+			Title:       "ACME LINK AUDIT",
+			AccentColor: "#FF6600",
+			FooterText:  "Confidential",
+			Timezone:    "UTC",
+		}, io.Discard)
+
+		if err != nil {
+			t.Fatalf("GenerateReport() error = %v, want nil", err)
+		}
+		if result.Size == 0 {
+			t.Error("GenerateReport() wrote an empty PDF")
+		}
+
+		_, err = service.GenerateReport(ctx, []int{1}, models.ReportOptions{AccentColor: "orange"}, io.Discard)
+		if !errors.Is(err, models.ErrInvalidReportOptions) {
+			t.Errorf("GenerateReport() error = %v, want ErrInvalidReportOptions", err)
+		}
+
+		_, err = service.GenerateReport(ctx, []int{1}, models.ReportOptions{Timezone: "Mars/Olympus"}, io.Discard)
+		if !errors.Is(err, models.ErrInvalidReportOptions) {
+			t.Errorf("GenerateReport() error = %v, want ErrInvalidReportOptions", err)
+		}
+	})
+
 	t.Run("handles context cancellation", func(t *testing.T) {
 		service := &Service{
 			repository:   &mockRepository{},
@@ -118,7 +222,7 @@ func TestService_GenerateReport(t *testing.T) {
 		ctx, cancel := context.WithCancel(context.Background())
 		cancel()
 
-		_, err := service.GenerateReport(ctx, []int{1})
+		_, err := service.GenerateReport(ctx, []int{1}, models.ReportOptions{}, io.Discard)
 
 		if err == nil {
 			t.Error("GenerateReport() error = nil, want context.Canceled")
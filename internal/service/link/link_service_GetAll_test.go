@@ -1,9 +1,9 @@
 package link
 
 import (
-	"bytes"
 	"context"
 	"errors"
+	"io"
 	"testing"
 	"time"
 
@@ -13,9 +13,18 @@ import (
 
 // mockRepository is a mock implementation of linkRepository interface.
 type mockRepository struct {
-	insertManyFunc func(links []models.Link) (int, error)
-	getByNumsFunc  func(linksNum []int) ([]models.Links, error)
-	getAllFunc     func() ([]models.Links, error)
+	insertManyFunc  func(links []models.Link) (int, error)
+	insertNamedFunc func(name string, links []models.Link) (int, error)
+	replaceFunc     func(num int, links []models.Link) error
+	setIgnoredFunc  func(num int, url string, ignored bool) error
+	getByNumsFunc   func(linksNum []int) ([]models.Links, error)
+	getByNumFunc    func(num int) (models.Links, error)
+	getAllFunc      func() ([]models.Links, error)
+	summariesFunc   func() ([]models.GroupSummary, error)
+	getBetweenFunc  func(from, to time.Time) ([]models.Links, error)
+	searchFunc      func(q models.SearchQuery) ([]models.Link, error)
+	latestFunc      func(urls []string) (map[string]models.Link, error)
+	importFunc      func(groups []models.Links, mode models.ImportMode) ([]int, error)
 }
 
 func (m *mockRepository) InsertMany(links []models.Link) (int, error) {
@@ -25,6 +34,27 @@ func (m *mockRepository) InsertMany(links []models.Link) (int, error) {
 	return 1, nil
 }
 
+func (m *mockRepository) InsertNamed(name string, links []models.Link) (int, error) {
+	if m.insertNamedFunc != nil {
+		return m.insertNamedFunc(name, links)
+	}
+	return m.InsertMany(links)
+}
+
+func (m *mockRepository) Replace(num int, links []models.Link) error {
+	if m.replaceFunc != nil {
+		return m.replaceFunc(num, links)
+	}
+	return &models.GroupNotFoundError{Nums: []int{num}}
+}
+
+func (m *mockRepository) SetIgnored(num int, url string, ignored bool) error {
+	if m.setIgnoredFunc != nil {
+		return m.setIgnoredFunc(num, url, ignored)
+	}
+	return &models.GroupNotFoundError{Nums: []int{num}}
+}
+
 func (m *mockRepository) GetByNums(linksNum []int) ([]models.Links, error) {
 	if m.getByNumsFunc != nil {
 		return m.getByNumsFunc(linksNum)
@@ -32,6 +62,13 @@ func (m *mockRepository) GetByNums(linksNum []int) ([]models.Links, error) {
 	return []models.Links{}, nil
 }
 
+func (m *mockRepository) GetByNum(num int) (models.Links, error) {
+	if m.getByNumFunc != nil {
+		return m.getByNumFunc(num)
+	}
+	return models.Links{}, &models.GroupNotFoundError{Nums: []int{num}}
+}
+
 func (m *mockRepository) GetAll() ([]models.Links, error) {
 	if m.getAllFunc != nil {
 		return m.getAllFunc()
@@ -39,6 +76,45 @@ func (m *mockRepository) GetAll() ([]models.Links, error) {
 	return []models.Links{}, nil
 }
 
+func (m *mockRepository) Summaries() ([]models.GroupSummary, error) {
+	if m.summariesFunc != nil {
+		return m.summariesFunc()
+	}
+	return []models.GroupSummary{}, nil
+}
+
+func (m *mockRepository) GetBetween(from, to time.Time) ([]models.Links, error) {
+	if m.getBetweenFunc != nil {
+		return m.getBetweenFunc(from, to)
+	}
+	return []models.Links{}, nil
+}
+
+func (m *mockRepository) Search(q models.SearchQuery) ([]models.Link, error) {
+	if m.searchFunc != nil {
+		return m.searchFunc(q)
+	}
+	return []models.Link{}, nil
+}
+
+func (m *mockRepository) LatestByURLs(urls []string) (map[string]models.Link, error) {
+	if m.latestFunc != nil {
+		return m.latestFunc(urls)
+	}
+	return map[string]models.Link{}, nil
+}
+
+func (m *mockRepository) Import(groups []models.Links, mode models.ImportMode) ([]int, error) {
+	if m.importFunc != nil {
+		return m.importFunc(groups, mode)
+	}
+	nums := make([]int, len(groups))
+	for i, g := range groups {
+		nums[i] = g.LinksNum
+	}
+	return nums, nil
+}
+
 // mockURLChecker is a mock implementation of urlChecker interface.
 type mockURLChecker struct {
 	checkFunc func(ctx context.Context, url string) models.Link
@@ -58,14 +134,15 @@ func (m *mockURLChecker) CheckURLWithContext(ctx context.Context, url string) mo
 
 // mockPDFGenerator is a mock implementation of PDF generator.
 type mockPDFGenerator struct {
-	generateFunc func(linksSlice []models.Links) (*bytes.Buffer, error)
+	generateFunc func(ctx context.Context, w io.Writer, linksSlice []models.Links, opts models.ReportOptions) error
 }
 
-func (m *mockPDFGenerator) GenerateMultipleReports(linksSlice []models.Links) (*bytes.Buffer, error) {
+func (m *mockPDFGenerator) GenerateMultipleReports(ctx context.Context, w io.Writer, linksSlice []models.Links, opts models.ReportOptions) error {
 	if m.generateFunc != nil {
-		return m.generateFunc(linksSlice)
+		return m.generateFunc(ctx, w, linksSlice, opts)
 	}
-	return bytes.NewBufferString("mock pdf content"), nil
+	_, err := io.WriteString(w, "mock pdf content")
+	return err
 }
 
 // createTestLink creates a test link for convenience.
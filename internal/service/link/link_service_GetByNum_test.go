@@ -0,0 +1,65 @@
+package link
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestService_GetByNum(t *testing.T) {
+	t.Run("returns stored group", func(t *testing.T) {
+		service := &Service{
+			repository: &mockRepository{
+				getByNumFunc: func(num int) (models.Links, error) {
+					return models.Links{LinksNum: num, Links: []models.Link{createTestLink("https://example.com", models.LinkStatusAvailable)}}, nil
+				},
+			},
+			urlChecker:   &mockURLChecker{},
+			pdfGenerator: &mockPDFGenerator{},
+			workerCount:  2,
+		}
+
+		group, err := service.GetByNum(context.Background(), 3)
+
+		if err != nil {
+			t.Fatalf("GetByNum() error = %v, want nil", err)
+		}
+		if group.LinksNum != 3 || len(group.Links) != 1 {
+			t.Errorf("GetByNum() = %+v, want group 3 with 1 link", group)
+		}
+	})
+
+	t.Run("returns group not found", func(t *testing.T) {
+		service := &Service{
+			repository:   &mockRepository{},
+			urlChecker:   &mockURLChecker{},
+			pdfGenerator: &mockPDFGenerator{},
+			workerCount:  2,
+		}
+
+		_, err := service.GetByNum(context.Background(), 3)
+
+		if !errors.Is(err, models.ErrGroupNotFound) {
+			t.Errorf("GetByNum() error = %v, want ErrGroupNotFound", err)
+		}
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		service := &Service{
+			repository:   &mockRepository{},
+			urlChecker:   &mockURLChecker{},
+			pdfGenerator: &mockPDFGenerator{},
+			workerCount:  2,
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := service.GetByNum(ctx, 3)
+
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("GetByNum() error = %v, want context.Canceled", err)
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package link
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestService_Import(t *testing.T) {
+	groups := []models.Links{
+		{LinksNum: 4, Name: "nightly", Links: []models.Link{
+			createTestLink("https://example.com", models.LinkStatusAvailable),
+			createTestLink("https://google.com", models.LinkStatusNotAvailable),
+		}},
+		{LinksNum: 7, Links: []models.Link{createTestLink("https://go.dev", models.LinkStatusAvailable)}},
+	}
+
+	t.Run("returns numbers assigned by storage", func(t *testing.T) {
+		repo := &mockRepository{
+			importFunc: func(g []models.Links, mode models.ImportMode) ([]int, error) {
+				if len(g) != 2 || mode != models.ImportModeMerge {
+					t.Errorf("Import() got %d groups in %s mode, want 2 in merge", len(g), mode)
+				}
+				return []int{11, 12}, nil
+			},
+		}
+		service := &Service{repository: repo}
+
+		result, err := service.Import(context.Background(), groups, models.ImportModeMerge)
+
+		if err != nil {
+			t.Fatalf("Import() error = %v, want nil", err)
+		}
+		if len(result.LinksNum) != 2 || result.LinksNum[0] != 11 || result.LinksNum[1] != 12 {
+			t.Errorf("Import() LinksNum = %v, want [11 12]", result.LinksNum)
+		}
+		if result.LinksCount != 3 || result.Mode != models.ImportModeMerge {
+			t.Errorf("Import() = %+v, want 3 links in merge mode", result)
+		}
+	})
+
+	t.Run("storage failure is returned", func(t *testing.T) {
+		repoErr := errors.New("disk full")
+		repo := &mockRepository{
+			importFunc: func([]models.Links, models.ImportMode) ([]int, error) { return nil, repoErr },
+		}
+		service := &Service{repository: repo}
+
+		_, err := service.Import(context.Background(), groups, models.ImportModeReplace)
+
+		if !errors.Is(err, repoErr) {
+			t.Errorf("Import() error = %v, want %v", err, repoErr)
+		}
+	})
+}
+
+func TestService_Export(t *testing.T) {
+	repo := &mockRepository{
+		getAllFunc: func() ([]models.Links, error) {
+			return []models.Links{
+				{LinksNum: 3, Links: []models.Link{createTestLink("https://go.dev", models.LinkStatusAvailable)}},
+				{LinksNum: 1, Links: []models.Link{createTestLink("https://example.com", models.LinkStatusAvailable)}},
+			}, nil
+		},
+	}
+	service := &Service{repository: repo}
+
+	groups, err := service.Export(context.Background())
+
+	if err != nil {
+		t.Fatalf("Export() error = %v, want nil", err)
+	}
+	if len(groups) != 2 || groups[0].LinksNum != 1 || groups[1].LinksNum != 3 {
+		t.Errorf("Export() = %+v, want groups ordered by number", groups)
+	}
+}
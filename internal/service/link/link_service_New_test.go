@@ -5,7 +5,7 @@ import "testing"
 func TestService_New(t *testing.T) {
 	t.Run("creates service with valid worker count", func(t *testing.T) {
 		repo := &mockRepository{}
-		service := New(repo, 5)
+		service := New(repo, Config{WorkerCount: 5, MaxWorkerCount: 10, MaxConcurrentChecks: 20, MaxReportGroups: 50})
 
 		if service == nil {
 			t.Fatal("New() returned nil")
@@ -13,22 +13,41 @@ func TestService_New(t *testing.T) {
 		if service.workerCount != 5 {
 			t.Errorf("New() workerCount = %d, want 5", service.workerCount)
 		}
+		if service.maxWorkerCount != 10 {
+			t.Errorf("New() maxWorkerCount = %d, want 10", service.maxWorkerCount)
+		}
 		if service.repository != repo {
 			t.Error("New() repository not set correctly")
 		}
+		if cap(service.checkSlots) != 20 {
+			t.Errorf("New() checkSlots capacity = %d, want 20", cap(service.checkSlots))
+		}
+		if service.maxReportGroups != 50 {
+			t.Errorf("New() maxReportGroups = %d, want 50", service.maxReportGroups)
+		}
 	})
 
 	t.Run("uses default worker count for zero or negative", func(t *testing.T) {
 		repo := &mockRepository{}
 
-		service1 := New(repo, 0)
+		service1 := New(repo, Config{})
 		if service1.workerCount != defaultWorkerCount {
 			t.Errorf("New(0) workerCount = %d, want %d", service1.workerCount, defaultWorkerCount)
 		}
 
-		service2 := New(repo, -1)
+		service2 := New(repo, Config{WorkerCount: -1})
 		if service2.workerCount != defaultWorkerCount {
 			t.Errorf("New(-1) workerCount = %d, want %d", service2.workerCount, defaultWorkerCount)
 		}
+		if service2.checkSlots != nil {
+			t.Error("New() with zero concurrency limit should not create checkSlots")
+		}
+	})
+
+	t.Run("raises max worker count to at least worker count", func(t *testing.T) {
+		service := New(&mockRepository{}, Config{WorkerCount: 8, MaxWorkerCount: 2})
+		if service.maxWorkerCount != 8 {
+			t.Errorf("New(8, 2) maxWorkerCount = %d, want 8", service.maxWorkerCount)
+		}
 	})
 }
@@ -0,0 +1,159 @@
+package link
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestService_Recheck(t *testing.T) {
+	t.Run("reports status changes since the group", func(t *testing.T) {
+		prev := models.Links{LinksNum: 3, Name: "docs", Links: []models.Link{
+			createTestLink("https://broken.test", models.LinkStatusAvailable),
+			createTestLink("https://fixed.test", models.LinkStatusNotAvailable),
+			createTestLink("https://stable.test", models.LinkStatusAvailable),
+			createTestLink("https://skipped.test", models.LinkStatusSkipped),
+		}}
+		prev.Links[0].Label = "home"
+
+		current := map[string]models.LinkStatus{
+			"https://broken.test":  models.LinkStatusNotAvailable,
+			"https://fixed.test":   models.LinkStatusAvailable,
+			"https://stable.test":  models.LinkStatusAvailable,
+			"https://skipped.test": models.LinkStatusAvailable,
+		}
+
+		var (
+			storedName  string
+			storedLinks []models.Link
+		)
+		service := &Service{
+			repository: &mockRepository{
+				getByNumFunc: func(num int) (models.Links, error) {
+					return prev, nil
+				},
+				insertNamedFunc: func(name string, links []models.Link) (int, error) {
+					storedName, storedLinks = name, links
+					return 4, nil
+				},
+			},
+			urlChecker: &mockURLChecker{
+				checkFunc: func(ctx context.Context, url string) models.Link {
+					return createTestLink(url, current[url])
+				},
+			},
+			pdfGenerator: &mockPDFGenerator{},
+			workerCount:  2,
+		}
+
+		res, err := service.Recheck(context.Background(), 3)
+
+		if err != nil {
+			t.Fatalf("Recheck() error = %v, want nil", err)
+		}
+		if res.LinksNum != 4 || storedName != "docs" || len(storedLinks) != 4 {
+			t.Errorf("Recheck() stored %d links named %q as group %d, want 4 links named docs as group 4", len(storedLinks), storedName, res.LinksNum)
+		}
+		if storedLinks[0].Label != "home" {
+			t.Errorf("Recheck() stored label %q, want the label of the group", storedLinks[0].Label)
+		}
+		if res.Changes == nil {
+			t.Fatal("Recheck() Changes = nil, want changes")
+		}
+		if want := []string{"https://broken.test"}; !slices.Equal(res.Changes.NowBroken, want) {
+			t.Errorf("NowBroken = %v, want %v", res.Changes.NowBroken, want)
+		}
+		if want := []string{"https://fixed.test"}; !slices.Equal(res.Changes.NowFixed, want) {
+			t.Errorf("NowFixed = %v, want %v", res.Changes.NowFixed, want)
+		}
+		if want := []string{"https://stable.test"}; !slices.Equal(res.Changes.Unchanged, want) {
+			t.Errorf("Unchanged = %v, want %v", res.Changes.Unchanged, want)
+		}
+	})
+
+	t.Run("keeps ignored links ignored and out of changes", func(t *testing.T) {
+		prev := models.Links{LinksNum: 3, Links: []models.Link{
+			createTestLink("https://known.test/404", models.LinkStatusAvailable),
+			createTestLink("https://stable.test", models.LinkStatusAvailable),
+		}}
+		prev.Links[0].Ignored = true
+
+		var storedLinks []models.Link
+		service := &Service{
+			repository: &mockRepository{
+				getByNumFunc: func(num int) (models.Links, error) {
+					return prev, nil
+				},
+				insertNamedFunc: func(name string, links []models.Link) (int, error) {
+					storedLinks = links
+					return 4, nil
+				},
+			},
+			urlChecker: &mockURLChecker{
+				checkFunc: func(ctx context.Context, url string) models.Link {
+					return createTestLink(url, models.LinkStatusNotAvailable)
+				},
+			},
+			pdfGenerator: &mockPDFGenerator{},
+			workerCount:  2,
+		}
+
+		res, err := service.Recheck(context.Background(), 3)
+
+		if err != nil {
+			t.Fatalf("Recheck() error = %v, want nil", err)
+		}
+		if len(storedLinks) != 2 || !storedLinks[0].Ignored || storedLinks[1].Ignored {
+			t.Errorf("Recheck() stored %+v, want only known.test/404 ignored", storedLinks)
+		}
+		if !res.Results[0].Ignored {
+			t.Error("Recheck() result of the ignored link is not marked ignored")
+		}
+		if want := []string{"https://stable.test"}; !slices.Equal(res.Changes.NowBroken, want) {
+			t.Errorf("NowBroken = %v, want %v", res.Changes.NowBroken, want)
+		}
+	})
+
+	t.Run("group not found", func(t *testing.T) {
+		service := &Service{
+			repository:   &mockRepository{},
+			urlChecker:   &mockURLChecker{},
+			pdfGenerator: &mockPDFGenerator{},
+			workerCount:  2,
+		}
+
+		_, err := service.Recheck(context.Background(), 3)
+
+		if !errors.Is(err, models.ErrGroupNotFound) {
+			t.Errorf("Recheck() error = %v, want ErrGroupNotFound", err)
+		}
+	})
+}
+
+func TestRecheckOptions(t *testing.T) {
+	group := models.Links{Name: "api", Links: []models.Link{
+		{URL: "https://example.com/a", Method: "GET", ExpectedStatus: "2xx", Label: "a"},
+		{URL: "https://example.com/b", Ignored: true},
+	}}
+
+	links, opts := recheckOptions(group)
+
+	if want := []string{"https://example.com/a", "https://example.com/b"}; !slices.Equal(links, want) {
+		t.Errorf("recheckOptions() links = %v, want %v", links, want)
+	}
+	if opts.Name != "api" || opts.Labels["https://example.com/a"] != "a" || opts.MethodFor("https://example.com/a") != "GET" {
+		t.Errorf("recheckOptions() opts = %+v, want name, label and method of the group", opts)
+	}
+	if r := opts.ExpectedStatusByURL["https://example.com/a"]; r != (models.StatusRange{Min: 200, Max: 299}) {
+		t.Errorf("recheckOptions() expected status = %+v, want 2xx", r)
+	}
+	if _, ok := opts.ExpectedStatusByURL["https://example.com/b"]; ok {
+		t.Error("recheckOptions() set an expected status for a link checked without one")
+	}
+	if !opts.Ignored["https://example.com/b"] || opts.Ignored["https://example.com/a"] {
+		t.Errorf("recheckOptions() ignored = %v, want only https://example.com/b", opts.Ignored)
+	}
+}
@@ -0,0 +1,83 @@
+package link
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+	"github.com/polonkoevv/linkchecker/internal/pdfgenerator"
+)
+
+func TestService_Search(t *testing.T) {
+	t.Run("returns matches with count", func(t *testing.T) {
+		repo := &mockRepository{
+			searchFunc: func(q models.SearchQuery) ([]models.Link, error) {
+				if q.Query != "example.com" {
+					t.Errorf("Search() query = %s, want example.com", q.Query)
+				}
+				link := createTestLink("https://example.com", models.LinkStatusAvailable)
+				link.GroupNum = 3
+				return []models.Link{link}, nil
+			},
+		}
+
+		service := &Service{
+			repository:   repo,
+			urlChecker:   &mockURLChecker{},
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			workerCount:  2,
+		}
+
+		result, err := service.Search(context.Background(), models.SearchQuery{Query: "example.com"})
+
+		if err != nil {
+			t.Fatalf("Search() error = %v, want nil", err)
+		}
+		if result.Count != 1 || len(result.Links) != 1 {
+			t.Fatalf("Search() Count = %d, links = %d, want 1", result.Count, len(result.Links))
+		}
+		if result.Links[0].GroupNum != 3 {
+			t.Errorf("Search() GroupNum = %d, want 3", result.Links[0].GroupNum)
+		}
+		if result.Query != "example.com" {
+			t.Errorf("Search() Query = %s, want example.com", result.Query)
+		}
+	})
+
+	t.Run("handles repository error", func(t *testing.T) {
+		repo := &mockRepository{
+			searchFunc: func(q models.SearchQuery) ([]models.Link, error) {
+				return nil, errors.New("repository error")
+			},
+		}
+
+		service := &Service{
+			repository:   repo,
+			urlChecker:   &mockURLChecker{},
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			workerCount:  2,
+		}
+
+		if _, err := service.Search(context.Background(), models.SearchQuery{Query: "example.com"}); err == nil {
+			t.Error("Search() error = nil, want error")
+		}
+	})
+
+	t.Run("handles context cancellation", func(t *testing.T) {
+		service := &Service{
+			repository:   &mockRepository{},
+			urlChecker:   &mockURLChecker{},
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			workerCount:  2,
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := service.Search(ctx, models.SearchQuery{Query: "example.com"})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Search() error = %v, want context.Canceled", err)
+		}
+	})
+}
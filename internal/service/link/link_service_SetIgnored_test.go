@@ -0,0 +1,66 @@
+package link
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestService_SetIgnored(t *testing.T) {
+	t.Run("marks the link and returns the group", func(t *testing.T) {
+		group := models.Links{LinksNum: 2, Links: []models.Link{
+			createTestLink("https://example.com", models.LinkStatusAvailable),
+			createTestLink("https://example.com/404", models.LinkStatusNotAvailable),
+		}}
+		repo := &mockRepository{
+			setIgnoredFunc: func(num int, url string, ignored bool) error {
+				for i := range group.Links {
+					if group.Links[i].URL == url {
+						group.Links[i].Ignored = ignored
+					}
+				}
+				return nil
+			},
+			getByNumFunc: func(num int) (models.Links, error) {
+				return group, nil
+			},
+		}
+		service := &Service{repository: repo, urlChecker: &mockURLChecker{}, workerCount: 1}
+
+		got, err := service.SetIgnored(context.Background(), 2, "https://example.com/404", true)
+
+		if err != nil {
+			t.Fatalf("SetIgnored() error = %v, want nil", err)
+		}
+		if got.LinksNum != 2 || got.Links[0].Ignored || !got.Links[1].Ignored {
+			t.Errorf("SetIgnored() = %+v, want group 2 with only example.com/404 ignored", got)
+		}
+	})
+
+	t.Run("passes through not found errors", func(t *testing.T) {
+		for _, want := range []error{&models.GroupNotFoundError{Nums: []int{2}}, models.ErrLinkNotFound} {
+			repo := &mockRepository{
+				setIgnoredFunc: func(num int, url string, ignored bool) error {
+					return want
+				},
+			}
+			service := &Service{repository: repo, urlChecker: &mockURLChecker{}, workerCount: 1}
+
+			if _, err := service.SetIgnored(context.Background(), 2, "https://example.com", true); !errors.Is(err, want) {
+				t.Errorf("SetIgnored() error = %v, want %v", err, want)
+			}
+		}
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		service := &Service{repository: &mockRepository{}, urlChecker: &mockURLChecker{}, workerCount: 1}
+
+		if _, err := service.SetIgnored(ctx, 2, "https://example.com", true); !errors.Is(err, context.Canceled) {
+			t.Errorf("SetIgnored() error = %v, want context.Canceled", err)
+		}
+	})
+}
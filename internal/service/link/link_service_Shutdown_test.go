@@ -0,0 +1,108 @@
+package link
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+	"github.com/polonkoevv/linkchecker/internal/pdfgenerator"
+)
+
+func TestService_Shutdown(t *testing.T) {
+	t.Run("waits for checks in progress to be stored", func(t *testing.T) {
+		started := make(chan struct{})
+		release := make(chan struct{})
+		var inserted atomic.Bool
+
+		service := &Service{
+			repository: &mockRepository{
+				insertManyFunc: func(links []models.Link) (int, error) {
+					inserted.Store(true)
+					return 1, nil
+				},
+			},
+			urlChecker: &mockURLChecker{
+				checkFunc: func(ctx context.Context, url string) models.Link {
+					close(started)
+					<-release
+					return createTestLink(url, models.LinkStatusAvailable)
+				},
+			},
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			workerCount:  1,
+		}
+
+		go func() {
+			_, _ = service.CheckMany(context.Background(), []string{"https://example.com"}, models.CheckOptions{})
+		}()
+		<-started
+
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			close(release)
+		}()
+
+		if err := service.Shutdown(context.Background()); err != nil {
+			t.Fatalf("Shutdown() error = %v, want nil", err)
+		}
+		if !inserted.Load() {
+			t.Error("Shutdown() returned before the check was stored")
+		}
+	})
+
+	t.Run("rejects new checks", func(t *testing.T) {
+		service := &Service{
+			repository:   &mockRepository{},
+			urlChecker:   &mockURLChecker{},
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			workerCount:  1,
+		}
+
+		if err := service.Shutdown(context.Background()); err != nil {
+			t.Fatalf("Shutdown() error = %v, want nil", err)
+		}
+
+		_, err := service.CheckMany(context.Background(), []string{"https://example.com"}, models.CheckOptions{})
+		if !errors.Is(err, models.ErrShuttingDown) {
+			t.Errorf("CheckMany() error = %v, want %v", err, models.ErrShuttingDown)
+		}
+		_, err = service.StartCheckJob(context.Background(), []string{"https://example.com"}, models.CheckOptions{})
+		if !errors.Is(err, models.ErrShuttingDown) {
+			t.Errorf("StartCheckJob() error = %v, want %v", err, models.ErrShuttingDown)
+		}
+	})
+
+	t.Run("returns context error when checks do not finish in time", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		started := make(chan struct{})
+
+		service := &Service{
+			repository: &mockRepository{},
+			urlChecker: &mockURLChecker{
+				checkFunc: func(ctx context.Context, url string) models.Link {
+					close(started)
+					<-release
+					return createTestLink(url, models.LinkStatusAvailable)
+				},
+			},
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			workerCount:  1,
+		}
+
+		go func() {
+			_, _ = service.CheckMany(context.Background(), []string{"https://example.com"}, models.CheckOptions{})
+		}()
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		if err := service.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Shutdown() error = %v, want %v", err, context.DeadlineExceeded)
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package link
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/jobs"
+	"github.com/polonkoevv/linkchecker/internal/models"
+	"github.com/polonkoevv/linkchecker/internal/pdfgenerator"
+)
+
+// waitForJob polls the service until the job leaves pending/running state or the timeout expires.
+func waitForJob(t *testing.T, service *Service, id string) models.Job {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := service.GetJob(context.Background(), id)
+		if err != nil {
+			t.Fatalf("GetJob() error = %v, want nil", err)
+		}
+		if job.Status.IsFinal() {
+			return job
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	t.Fatalf("job %s did not finish in time", id)
+	return models.Job{}
+}
+
+func TestService_StartCheckJob(t *testing.T) {
+	t.Run("runs job in background and stores result", func(t *testing.T) {
+		service := &Service{
+			repository:   &mockRepository{},
+			urlChecker:   &mockURLChecker{},
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			jobs:         jobs.NewStore(),
+			workerCount:  2,
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		job, err := service.StartCheckJob(ctx, []string{
+			"https://example.com",
+			"https://google.com",
+			"https://example.com", // duplicate
+		}, models.CheckOptions{})
+		// Canceling the request context must not abort the job
+		cancel()
+
+		if err != nil {
+			t.Fatalf("StartCheckJob() error = %v, want nil", err)
+		}
+		if job.ID == "" {
+			t.Fatal("StartCheckJob() returned empty job id")
+		}
+		if job.Total != 2 {
+			t.Errorf("StartCheckJob() Total = %d, want 2", job.Total)
+		}
+
+		finished := waitForJob(t, service, job.ID)
+
+		if finished.Status != models.JobStatusDone {
+			t.Fatalf("job Status = %s, want %s (error: %s)", finished.Status, models.JobStatusDone, finished.Error)
+		}
+		if finished.Checked != 2 {
+			t.Errorf("job Checked = %d, want 2", finished.Checked)
+		}
+		if finished.Result == nil || len(finished.Result.Links) != 2 {
+			t.Errorf("job Result = %+v, want 2 links", finished.Result)
+		}
+		if finished.FinishedAt == nil {
+			t.Error("job FinishedAt = nil, want time")
+		}
+	})
+
+	t.Run("marks job failed on repository error", func(t *testing.T) {
+		repo := &mockRepository{
+			insertManyFunc: func(links []models.Link) (int, error) {
+				return 0, errors.New("repository error")
+			},
+		}
+
+		service := &Service{
+			repository:   repo,
+			urlChecker:   &mockURLChecker{},
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			jobs:         jobs.NewStore(),
+			workerCount:  2,
+		}
+
+		job, err := service.StartCheckJob(context.Background(), []string{"https://example.com"}, models.CheckOptions{})
+		if err != nil {
+			t.Fatalf("StartCheckJob() error = %v, want nil", err)
+		}
+
+		finished := waitForJob(t, service, job.ID)
+
+		if finished.Status != models.JobStatusFailed {
+			t.Errorf("job Status = %s, want %s", finished.Status, models.JobStatusFailed)
+		}
+		if finished.Error == "" {
+			t.Error("job Error is empty, want message")
+		}
+	})
+
+	t.Run("unknown job returns ErrJobNotFound", func(t *testing.T) {
+		service := &Service{
+			repository: &mockRepository{},
+			jobs:       jobs.NewStore(),
+		}
+
+		_, err := service.GetJob(context.Background(), "missing")
+
+		if !errors.Is(err, models.ErrJobNotFound) {
+			t.Errorf("GetJob() error = %v, want ErrJobNotFound", err)
+		}
+	})
+}
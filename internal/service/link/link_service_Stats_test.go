@@ -0,0 +1,100 @@
+package link
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+	"github.com/polonkoevv/linkchecker/internal/pdfgenerator"
+)
+
+func TestService_Stats(t *testing.T) {
+	t.Run("aggregates statistics across groups", func(t *testing.T) {
+		repo := &mockRepository{
+			getAllFunc: func() ([]models.Links, error) {
+				return []models.Links{
+					{
+						LinksNum: 1,
+						Links: []models.Link{
+							createTestLink("https://example.com", models.LinkStatusAvailable),
+						},
+					},
+					{
+						LinksNum: 2,
+						Links: []models.Link{
+							createTestLink("https://google.com", models.LinkStatusAvailable),
+							createTestLink("https://invalid.invalid", models.LinkStatusNotAvailable),
+						},
+					},
+				}, nil
+			},
+		}
+
+		service := &Service{
+			repository:   repo,
+			urlChecker:   &mockURLChecker{},
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			workerCount:  2,
+		}
+
+		ctx := context.Background()
+		result, err := service.Stats(ctx)
+
+		if err != nil {
+			t.Fatalf("Stats() error = %v, want nil", err)
+		}
+		if result.Groups != 2 {
+			t.Errorf("Stats() Groups = %d, want 2", result.Groups)
+		}
+		if result.Total != 3 {
+			t.Errorf("Stats() Total = %d, want 3", result.Total)
+		}
+		if result.Available != 2 {
+			t.Errorf("Stats() Available = %d, want 2", result.Available)
+		}
+		if result.NotAvailable != 1 {
+			t.Errorf("Stats() NotAvailable = %d, want 1", result.NotAvailable)
+		}
+	})
+
+	t.Run("handles repository error", func(t *testing.T) {
+		repo := &mockRepository{
+			getAllFunc: func() ([]models.Links, error) {
+				return nil, errors.New("repository error")
+			},
+		}
+
+		service := &Service{
+			repository:   repo,
+			urlChecker:   &mockURLChecker{},
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			workerCount:  2,
+		}
+
+		ctx := context.Background()
+		_, err := service.Stats(ctx)
+
+		if err == nil {
+			t.Error("Stats() error = nil, want error")
+		}
+	})
+
+	t.Run("handles context cancellation", func(t *testing.T) {
+		service := &Service{
+			repository:   &mockRepository{},
+			urlChecker:   &mockURLChecker{},
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			workerCount:  2,
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := service.Stats(ctx)
+
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Stats() error = %v, want context.Canceled", err)
+		}
+	})
+}
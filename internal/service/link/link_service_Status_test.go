@@ -0,0 +1,59 @@
+package link
+
+import (
+	"context"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+	"github.com/polonkoevv/linkchecker/internal/pdfgenerator"
+)
+
+func TestService_Status(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan struct{})
+
+	service := &Service{
+		repository: &mockRepository{},
+		urlChecker: &mockURLChecker{
+			checkFunc: func(ctx context.Context, url string) models.Link {
+				if url == "https://slow.example.com" {
+					close(started)
+					<-release
+				}
+				return createTestLink(url, models.LinkStatusAvailable)
+			},
+		},
+		pdfGenerator:   pdfgenerator.NewGoFPDFGenerator(),
+		workerCount:    1,
+		maxWorkerCount: 8,
+		checkSlots:     make(chan struct{}, 16),
+	}
+
+	go func() {
+		defer close(done)
+		_, _ = service.CheckMany(context.Background(), []string{"https://example.com", "https://slow.example.com"}, models.CheckOptions{})
+	}()
+	<-started
+
+	got := service.Status()
+	want := models.ServiceStatus{
+		ActiveBatches:       1,
+		ActiveChecks:        1,
+		CompletedChecks:     1,
+		Workers:             1,
+		MaxWorkers:          8,
+		MaxConcurrentChecks: 16,
+	}
+	if got != want {
+		t.Errorf("Status() during check = %+v, want %+v", got, want)
+	}
+
+	close(release)
+	<-done
+
+	got = service.Status()
+	if got.ActiveBatches != 0 || got.ActiveChecks != 0 || got.CompletedChecks != 2 {
+		t.Errorf("Status() after check = %+v, want no active checks and 2 completed", got)
+	}
+}
@@ -0,0 +1,84 @@
+package link
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestService_Summaries(t *testing.T) {
+	t.Run("computes availability", func(t *testing.T) {
+		service := &Service{
+			repository: &mockRepository{
+				summariesFunc: func() ([]models.GroupSummary, error) {
+					return []models.GroupSummary{
+						{LinksNum: 1, Total: 4, Available: 3},
+						{LinksNum: 2},
+						{LinksNum: 3, Total: 5, Available: 3, Ignored: 1},
+					}, nil
+				},
+			},
+			urlChecker:   &mockURLChecker{},
+			pdfGenerator: &mockPDFGenerator{},
+			workerCount:  2,
+		}
+
+		summaries, err := service.Summaries(context.Background())
+
+		if err != nil {
+			t.Fatalf("Summaries() error = %v, want nil", err)
+		}
+		if len(summaries) != 3 {
+			t.Fatalf("Summaries() returned %d groups, want 3", len(summaries))
+		}
+		if summaries[0].AvailabilityPercent != 75 {
+			t.Errorf("Summaries()[0].AvailabilityPercent = %v, want 75", summaries[0].AvailabilityPercent)
+		}
+		if summaries[1].AvailabilityPercent != 0 {
+			t.Errorf("Summaries()[1].AvailabilityPercent = %v, want 0 for an empty group", summaries[1].AvailabilityPercent)
+		}
+		if summaries[2].AvailabilityPercent != 75 {
+			t.Errorf("Summaries()[2].AvailabilityPercent = %v, want 75 without the ignored link", summaries[2].AvailabilityPercent)
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repoErr := errors.New("database unavailable")
+		service := &Service{
+			repository: &mockRepository{
+				summariesFunc: func() ([]models.GroupSummary, error) {
+					return nil, repoErr
+				},
+			},
+			urlChecker:   &mockURLChecker{},
+			pdfGenerator: &mockPDFGenerator{},
+			workerCount:  2,
+		}
+
+		_, err := service.Summaries(context.Background())
+
+		if !errors.Is(err, repoErr) {
+			t.Errorf("Summaries() error = %v, want %v", err, repoErr)
+		}
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		service := &Service{
+			repository:   &mockRepository{},
+			urlChecker:   &mockURLChecker{},
+			pdfGenerator: &mockPDFGenerator{},
+			workerCount:  2,
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := service.Summaries(ctx)
+
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Summaries() error = %v, want context.Canceled", err)
+		}
+	})
+}
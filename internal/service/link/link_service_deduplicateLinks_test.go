@@ -0,0 +1,56 @@
+package link
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDeduplicateLinks(t *testing.T) {
+	links := []string{
+		"https://example.com/a",
+		"https://WWW.Example.com/a",
+		"https://example.com/a",
+		"https://www.example.com/b",
+		"example.com/b",
+		"https://www.com",
+		"https://com",
+	}
+
+	tests := []struct {
+		name        string
+		collapseWWW bool
+		want        []string
+	}{
+		{
+			name: "exact duplicates only",
+			want: []string{
+				"https://example.com/a",
+				"https://WWW.Example.com/a",
+				"https://www.example.com/b",
+				"example.com/b",
+				"https://www.com",
+				"https://com",
+			},
+		},
+		{
+			name:        "collapse www keeps the first link",
+			collapseWWW: true,
+			want: []string{
+				"https://example.com/a",
+				"https://www.example.com/b",
+				"example.com/b",
+				"https://www.com",
+				"https://com",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := deduplicateLinks(links, tt.collapseWWW)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("deduplicateLinks() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
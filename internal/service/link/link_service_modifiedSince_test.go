@@ -0,0 +1,46 @@
+package link
+
+import (
+	"testing"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestModifiedSince(t *testing.T) {
+	checkedAt := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+	lastModified := checkedAt.Add(-24 * time.Hour)
+
+	tests := []struct {
+		name string
+		prev models.Link
+		want time.Time
+	}{
+		{
+			name: "no previous check",
+			prev: models.Link{},
+		},
+		{
+			name: "last modified of available link",
+			prev: models.Link{Status: models.LinkStatusAvailable, CheckedAt: checkedAt, LastModified: &lastModified},
+			want: lastModified,
+		},
+		{
+			name: "check time without last modified",
+			prev: models.Link{Status: models.LinkStatusAvailable, CheckedAt: checkedAt},
+			want: checkedAt,
+		},
+		{
+			name: "not available link is checked unconditionally",
+			prev: models.Link{Status: models.LinkStatusNotAvailable, CheckedAt: checkedAt, LastModified: &lastModified},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := modifiedSince(tt.prev); !got.Equal(tt.want) {
+				t.Errorf("modifiedSince() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package link
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+	"github.com/polonkoevv/linkchecker/internal/pdfgenerator"
+)
+
+func TestService_resolveChannelBuffer(t *testing.T) {
+	tests := []struct {
+		name          string
+		channelBuffer int
+		workers       int
+		want          int
+	}{
+		{name: "proportional to workers", channelBuffer: ProportionalChannelBuffer, workers: 8, want: 8 * defaultBufferPerWorker},
+		{name: "configured size", channelBuffer: 100, workers: 8, want: 100},
+		{name: "zero is unbuffered", channelBuffer: 0, workers: 8, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{channelBuffer: tt.channelBuffer}
+
+			if got := s.resolveChannelBuffer(tt.workers); got != tt.want {
+				t.Errorf("resolveChannelBuffer(%d) = %d, want %d", tt.workers, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_CheckMany_ChannelBuffer(t *testing.T) {
+	links := make([]string, 100)
+	for i := range links {
+		links[i] = fmt.Sprintf("https://example.com/%d", i)
+	}
+
+	for _, buffer := range []int{ProportionalChannelBuffer, 0, 1, 1000} {
+		t.Run(fmt.Sprintf("buffer %d", buffer), func(t *testing.T) {
+			s := &Service{
+				repository: &mockRepository{},
+				urlChecker: &mockURLChecker{
+					checkFunc: func(ctx context.Context, url string) models.Link {
+						return createTestLink(url, models.LinkStatusAvailable)
+					},
+				},
+				pdfGenerator:  pdfgenerator.NewGoFPDFGenerator(),
+				workerCount:   4,
+				channelBuffer: buffer,
+			}
+
+			res, err := s.CheckMany(context.Background(), links, models.CheckOptions{})
+			if err != nil {
+				t.Fatalf("CheckMany() error = %v, want nil", err)
+			}
+			if len(res.Results) != len(links) {
+				t.Fatalf("CheckMany() returned %d results, want %d", len(res.Results), len(links))
+			}
+			for i, link := range res.Results {
+				if link.URL != links[i] {
+					t.Fatalf("Results[%d].URL = %s, want %s", i, link.URL, links[i])
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkService_CheckMany_ChannelBuffer checks a large batch with checks of varying
+// latency and a collector that is busy now and then, the case buffering smooths out.
+func BenchmarkService_CheckMany_ChannelBuffer(b *testing.B) {
+	checker := &mockURLChecker{
+		checkFunc: func(ctx context.Context, url string) models.Link {
+			if len(url)%3 == 0 {
+				time.Sleep(20 * time.Microsecond)
+			}
+			return createTestLink(url, models.LinkStatusAvailable)
+		},
+	}
+	links := make([]string, 1000)
+	for i := range links {
+		links[i] = fmt.Sprintf("https://example.com/%d", i)
+	}
+	collected := 0
+	opts := models.CheckOptions{
+		OnResult: func(models.Link) {
+			collected++
+			if collected%50 == 0 {
+				time.Sleep(50 * time.Microsecond)
+			}
+		},
+	}
+
+	for _, buffer := range []int{0, ProportionalChannelBuffer, 256} {
+		name := fmt.Sprintf("buffer %d", buffer)
+		switch buffer {
+		case 0:
+			name = "unbuffered"
+		case ProportionalChannelBuffer:
+			name = "default"
+		}
+		s := &Service{
+			repository:    &mockRepository{},
+			urlChecker:    checker,
+			pdfGenerator:  pdfgenerator.NewGoFPDFGenerator(),
+			workerCount:   16,
+			channelBuffer: buffer,
+		}
+
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := s.CheckMany(context.Background(), links, opts); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package link
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+	"github.com/polonkoevv/linkchecker/internal/pdfgenerator"
+)
+
+// newPooledService returns a service whose checks run on a shared pool of workers.
+func newPooledService(checker urlChecker, workers int) *Service {
+	s := &Service{
+		repository:   &mockRepository{},
+		urlChecker:   checker,
+		pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+		workerCount:  workers,
+	}
+	s.startPool(workers)
+	return s
+}
+
+func TestService_submitToPool(t *testing.T) {
+	t.Run("checks links in submission order", func(t *testing.T) {
+		service := newPooledService(&mockURLChecker{
+			checkFunc: func(ctx context.Context, url string) models.Link {
+				return createTestLink(url, models.LinkStatusAvailable)
+			},
+		}, 3)
+		defer service.stopPool()
+
+		links := make([]string, 20)
+		for i := range links {
+			links[i] = fmt.Sprintf("https://example.com/%d", i)
+		}
+
+		result, err := service.CheckMany(context.Background(), links, models.CheckOptions{})
+
+		if err != nil {
+			t.Fatalf("CheckMany() error = %v, want nil", err)
+		}
+		if len(result.Results) != len(links) {
+			t.Fatalf("CheckMany() returned %d results, want %d", len(result.Results), len(links))
+		}
+		for i, res := range result.Results {
+			if res.URL != links[i] || res.Status != models.LinkStatusAvailable {
+				t.Errorf("CheckMany() result[%d] = %s %s, want %s available", i, res.URL, res.Status, links[i])
+			}
+		}
+	})
+
+	t.Run("canceling a request does not affect others", func(t *testing.T) {
+		started := make(chan struct{}, 1)
+		service := newPooledService(&mockURLChecker{
+			checkFunc: func(ctx context.Context, url string) models.Link {
+				if strings.Contains(url, "hang") {
+					started <- struct{}{}
+					<-ctx.Done()
+					return createTestLink(url, models.LinkStatusNotAvailable)
+				}
+				return createTestLink(url, models.LinkStatusAvailable)
+			},
+		}, 2)
+		defer service.stopPool()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		canceled := make(chan error, 1)
+		go func() {
+			_, err := service.CheckMany(ctx, []string{"https://hang.example.com"}, models.CheckOptions{})
+			canceled <- err
+		}()
+		<-started
+
+		result, err := service.CheckMany(context.Background(), []string{"https://a.example.com", "https://b.example.com"}, models.CheckOptions{})
+		cancel()
+
+		if err != nil {
+			t.Fatalf("CheckMany() error = %v, want nil", err)
+		}
+		for _, res := range result.Results {
+			if res.Status != models.LinkStatusAvailable {
+				t.Errorf("CheckMany() %s = %s, want available", res.URL, res.Status)
+			}
+		}
+		if err := <-canceled; !errors.Is(err, context.Canceled) {
+			t.Errorf("canceled CheckMany() error = %v, want context.Canceled", err)
+		}
+	})
+
+	t.Run("bounds checks in flight per request", func(t *testing.T) {
+		var active, peak atomic.Int64
+		service := newPooledService(&mockURLChecker{
+			checkFunc: func(ctx context.Context, url string) models.Link {
+				n := active.Add(1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				time.Sleep(2 * time.Millisecond)
+				active.Add(-1)
+				return createTestLink(url, models.LinkStatusAvailable)
+			},
+		}, 4)
+		service.maxWorkerCount = 4
+		defer service.stopPool()
+
+		links := make([]string, 12)
+		for i := range links {
+			links[i] = fmt.Sprintf("https://example.com/%d", i)
+		}
+
+		if _, err := service.CheckMany(context.Background(), links, models.CheckOptions{Workers: 2}); err != nil {
+			t.Fatalf("CheckMany() error = %v, want nil", err)
+		}
+		if got := peak.Load(); got > 2 {
+			t.Errorf("CheckMany() ran %d checks at once, want at most 2", got)
+		}
+	})
+
+	t.Run("shutdown stops the pool", func(t *testing.T) {
+		service := newPooledService(&mockURLChecker{}, 2)
+
+		if err := service.Shutdown(context.Background()); err != nil {
+			t.Fatalf("Shutdown() error = %v, want nil", err)
+		}
+		if _, ok := <-service.pool; ok {
+			t.Error("Shutdown() left the pool open")
+		}
+		if got := service.Status().SharedWorkers; got != 2 {
+			t.Errorf("Status().SharedWorkers = %d, want 2", got)
+		}
+	})
+}
+
+func BenchmarkService_CheckMany(b *testing.B) {
+	checker := &mockURLChecker{
+		checkFunc: func(ctx context.Context, url string) models.Link {
+			return createTestLink(url, models.LinkStatusAvailable)
+		},
+	}
+	links := make([]string, 50)
+	for i := range links {
+		links[i] = fmt.Sprintf("https://example.com/%d", i)
+	}
+
+	modes := []struct {
+		name    string
+		service *Service
+	}{
+		{name: "workers per request", service: &Service{
+			repository:   &mockRepository{},
+			urlChecker:   checker,
+			pdfGenerator: pdfgenerator.NewGoFPDFGenerator(),
+			workerCount:  8,
+		}},
+		{name: "shared pool", service: newPooledService(checker, 8)},
+	}
+
+	for _, mode := range modes {
+		b.Run(mode.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if _, err := mode.service.CheckMany(context.Background(), links, models.CheckOptions{}); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		})
+		mode.service.stopPool()
+	}
+}
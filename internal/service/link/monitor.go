@@ -0,0 +1,124 @@
+package link
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+// CheckMonitor runs one monitor round: it checks the monitored URLs and replaces the links of
+// the monitor group with the results, so the group always holds the latest round. The first
+// round picks up the latest stored group named after the monitor, or stores a new one.
+// Links marked as ignored in the monitor group stay ignored.
+// It returns models.ErrMonitorDisabled if no URLs are monitored.
+func (s *Service) CheckMonitor(ctx context.Context) (models.LinksResponse, error) {
+	if len(s.monitorURLs) == 0 {
+		return models.LinksResponse{}, models.ErrMonitorDisabled
+	}
+	if !s.beginBatch() {
+		return models.LinksResponse{}, models.ErrShuttingDown
+	}
+	defer s.endBatch()
+
+	opts := models.CheckOptions{Name: s.monitorName}
+	if num, _ := s.monitorGroup(ctx); num > 0 {
+		opts.GroupNum = num
+		if group, err := s.repository.GetByNum(num); err == nil {
+			opts.Ignored = ignoredURLs(group.Links)
+		}
+	}
+	res, err := s.checkMany(ctx, ctx, s.monitorURLs, opts)
+	if err != nil {
+		return models.LinksResponse{}, err
+	}
+
+	s.monitorMu.Lock()
+	s.monitorNum = res.LinksNum
+	s.monitorCheckedAt = time.Now()
+	s.monitorMu.Unlock()
+
+	return res, nil
+}
+
+// Monitor returns the latest stored results of the monitored URLs.
+// It returns models.ErrMonitorDisabled if no URLs are monitored, and models.ErrMonitorPending
+// if no round has been stored yet.
+func (s *Service) Monitor(ctx context.Context) (models.MonitorResponse, error) {
+	if len(s.monitorURLs) == 0 {
+		return models.MonitorResponse{}, models.ErrMonitorDisabled
+	}
+
+	num, checkedAt := s.monitorGroup(ctx)
+	if num == 0 {
+		return models.MonitorResponse{}, models.ErrMonitorPending
+	}
+
+	group, err := s.repository.GetByNum(num)
+	if errors.Is(err, models.ErrGroupNotFound) {
+		// the group was deleted or expired, the next round stores a new one
+		s.forgetMonitorGroup(num)
+		return models.MonitorResponse{}, models.ErrMonitorPending
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to load monitor group", slog.Int("links_num", num), slog.Any("error", err))
+		return models.MonitorResponse{}, err
+	}
+
+	res := models.MonitorResponse{
+		LinksNum:        num,
+		Name:            s.monitorName,
+		IntervalSeconds: int(s.monitorInterval / time.Second),
+		CheckedAt:       checkedAt,
+		Total:           len(group.Links),
+		Links:           group.Links,
+	}
+	for _, link := range group.Links {
+		switch {
+		case link.Ignored:
+			res.Ignored++
+		case link.Status == models.LinkStatusAvailable:
+			res.Available++
+		}
+	}
+
+	return res, nil
+}
+
+// monitorGroup returns the group the monitor checks into and when it was last checked. Until the
+// first round it looks up the latest stored group named after the monitor, so a restarted service
+// keeps checking into the same group. It returns zero if there is none.
+func (s *Service) monitorGroup(ctx context.Context) (int, time.Time) {
+	s.monitorMu.Lock()
+	defer s.monitorMu.Unlock()
+
+	if s.monitorNum > 0 {
+		return s.monitorNum, s.monitorCheckedAt
+	}
+
+	summaries, err := s.repository.Summaries()
+	if err != nil {
+		slog.WarnContext(ctx, "failed to look up stored monitor group", slog.Any("error", err))
+		return 0, time.Time{}
+	}
+	for _, summary := range summaries {
+		if summary.Name == s.monitorName && summary.LinksNum > s.monitorNum {
+			s.monitorNum = summary.LinksNum
+			s.monitorCheckedAt = summary.LastCheckedAt
+		}
+	}
+	return s.monitorNum, s.monitorCheckedAt
+}
+
+// forgetMonitorGroup drops group num as the monitor group unless a round replaced it meanwhile.
+func (s *Service) forgetMonitorGroup(num int) {
+	s.monitorMu.Lock()
+	defer s.monitorMu.Unlock()
+
+	if s.monitorNum == num {
+		s.monitorNum = 0
+		s.monitorCheckedAt = time.Time{}
+	}
+}
@@ -0,0 +1,108 @@
+package link
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+// poolTask is a check job submitted to the shared worker pool by a single request.
+// It carries the request's context and results channel, so that canceling one request
+// only drops its own tasks.
+type poolTask struct {
+	ctx     context.Context
+	job     checkJob
+	results chan<- checkResult
+	// done is called once the task is finished, whether or not its result was sent.
+	done func()
+}
+
+// startPool launches workerCount long-lived workers that serve checks of all requests.
+func (s *Service) startPool(workerCount int) {
+	s.pool = make(chan poolTask)
+	s.poolWorkers = workerCount
+	for i := 0; i < workerCount; i++ {
+		go s.poolWorker(i)
+	}
+}
+
+// stopPool stops the shared workers. It must only be called once no request can submit tasks.
+func (s *Service) stopPool() {
+	if s.pool == nil {
+		return
+	}
+	s.closePool.Do(func() {
+		close(s.pool)
+	})
+}
+
+// poolWorker runs tasks from the shared pool until it is stopped.
+func (s *Service) poolWorker(id int) {
+	for task := range s.pool {
+		s.runPoolTask(id, task)
+	}
+}
+
+// runPoolTask checks the URL of task and sends the result to the submitting request.
+func (s *Service) runPoolTask(id int, task poolTask) {
+	defer task.done()
+
+	link, ok := s.runJob(task.ctx, id, task.job)
+	if !ok {
+		return
+	}
+
+	select {
+	case <-task.ctx.Done():
+		slog.WarnContext(task.ctx, "worker canceled while sending result", slog.Int("worker_id", id))
+	case task.results <- checkResult{index: task.job.index, link: link}:
+	}
+}
+
+// submitToPool feeds links whose slot in checkedLinks is not filled yet to the shared pool,
+// keeping at most workerCount of them in flight so that a single request cannot take over the pool.
+// The returned WaitGroup is done once every submitted task finished.
+func (s *Service) submitToPool(ctx context.Context, results chan<- checkResult, links []string, checkedLinks []models.Link, previous map[string]models.Link, opts models.CheckOptions, workerCount int) *sync.WaitGroup {
+	filled := make([]bool, len(checkedLinks))
+	for i, l := range checkedLinks {
+		filled[i] = l.URL != ""
+	}
+
+	var wg sync.WaitGroup
+	inFlight := make(chan struct{}, workerCount)
+	done := func() {
+		<-inFlight
+		wg.Done()
+	}
+
+	// the feeder holds the group until all tasks are submitted
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i, raw := range links {
+			if filled[i] {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				slog.WarnContext(ctx, "producer stopped due to context done")
+				return
+			case inFlight <- struct{}{}:
+			}
+
+			wg.Add(1)
+			select {
+			case <-ctx.Done():
+				done()
+				slog.WarnContext(ctx, "producer stopped due to context done")
+				return
+			case s.pool <- poolTask{ctx: ctx, job: newCheckJob(i, raw, previous, opts), results: results, done: done}:
+			}
+		}
+	}()
+
+	return &wg
+}
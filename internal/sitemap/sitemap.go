@@ -0,0 +1,127 @@
+package sitemap
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// maxSitemapSize limits the size of a single downloaded sitemap document.
+const maxSitemapSize = 10 << 20 // 10 MB
+
+// Fetcher downloads sitemap.xml documents and extracts page URLs from them.
+type Fetcher struct {
+	client *http.Client
+}
+
+// sitemapEntry is a single <url> or <sitemap> element with its location.
+type sitemapEntry struct {
+	Loc string `xml:"loc"`
+}
+
+// document covers both <urlset> and <sitemapindex> root elements.
+type document struct {
+	XMLName  xml.Name
+	URLs     []sitemapEntry `xml:"url"`
+	Sitemaps []sitemapEntry `xml:"sitemap"`
+}
+
+// Option configures a Fetcher.
+type Option func(*Fetcher)
+
+// WithTransport makes the Fetcher download sitemaps through rt, e.g. the transport of URL checks
+// so that the same TLS and address restrictions apply.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(f *Fetcher) {
+		f.client.Transport = rt
+	}
+}
+
+// NewFetcher creates a new Fetcher with a default HTTP client and the given options.
+func NewFetcher(opts ...Option) *Fetcher {
+	f := &Fetcher{
+		client: &http.Client{},
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// Fetch downloads the sitemap at sitemapURL and returns all <loc> entries.
+// Sitemap index files are resolved one level deep.
+func (f *Fetcher) Fetch(ctx context.Context, sitemapURL string) ([]string, error) {
+	doc, err := f.fetchDocument(ctx, sitemapURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if doc.XMLName.Local != "sitemapindex" {
+		return collectLocs(doc.URLs), nil
+	}
+
+	slog.DebugContext(ctx, "resolving sitemap index",
+		slog.String("url", sitemapURL),
+		slog.Int("sitemaps", len(doc.Sitemaps)),
+	)
+
+	links := make([]string, 0)
+	for _, nested := range collectLocs(doc.Sitemaps) {
+		nestedDoc, err := f.fetchDocument(ctx, nested)
+		if err != nil {
+			return nil, fmt.Errorf("nested sitemap %s: %w", nested, err)
+		}
+		// Only one level of nesting is followed
+		links = append(links, collectLocs(nestedDoc.URLs)...)
+	}
+
+	return links, nil
+}
+
+// fetchDocument downloads and decodes a single sitemap document.
+func (f *Fetcher) fetchDocument(ctx context.Context, sitemapURL string) (*document, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sitemapURL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("create sitemap request: %w", err)
+	}
+	req.Header.Set("User-Agent", "WebStatusChecker/1.0")
+	req.Header.Set("Accept", "application/xml, text/xml, */*")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch sitemap: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch sitemap: unexpected status code %d", resp.StatusCode)
+	}
+
+	var doc document
+	if err := xml.NewDecoder(io.LimitReader(resp.Body, maxSitemapSize)).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decode sitemap: %w", err)
+	}
+
+	if doc.XMLName.Local != "urlset" && doc.XMLName.Local != "sitemapindex" {
+		return nil, fmt.Errorf("unexpected sitemap root element: %s", doc.XMLName.Local)
+	}
+
+	return &doc, nil
+}
+
+// collectLocs returns non-empty trimmed locations from sitemap entries.
+func collectLocs(entries []sitemapEntry) []string {
+	locs := make([]string, 0, len(entries))
+	for _, e := range entries {
+		loc := strings.TrimSpace(e.Loc)
+		if loc == "" {
+			continue
+		}
+		locs = append(locs, loc)
+	}
+	return locs
+}
@@ -0,0 +1,173 @@
+package stats
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+	"github.com/polonkoevv/linkchecker/internal/urlchecker"
+)
+
+// DefaultPercentiles are the percentiles of available check durations computed when none are given.
+var DefaultPercentiles = []float64{50, 90, 99}
+
+// Calculate computes statistics for a single set of links.
+func Calculate(links []models.Link, percentiles ...float64) models.Statistics {
+	return CalculateGroups([]models.Links{{Links: links}}, percentiles...)
+}
+
+// CalculateGroups computes statistics rolled up across all given link groups.
+// Skipped links are counted in Total and Skipped but in neither availability count,
+// slow links are counted in both Available and Slow. Ignored links are counted in Total
+// and Ignored only, whatever their status.
+// Percentiles (0-100] of available check durations default to DefaultPercentiles.
+func CalculateGroups(groups []models.Links, percentiles ...float64) models.Statistics {
+	res := models.Statistics{Groups: len(groups)}
+
+	var availableSum, notAvailableSum time.Duration
+	var available []time.Duration
+	for _, group := range groups {
+		for _, link := range group.Links {
+			res.Total++
+			switch {
+			case link.Ignored:
+				res.Ignored++
+			case link.Status == models.LinkStatusAvailable:
+				res.Available++
+				availableSum += link.Duration
+				available = append(available, link.Duration)
+				if link.Slow {
+					res.Slow++
+				}
+			case link.Status.IsSkipped():
+				res.Skipped++
+			default:
+				res.NotAvailable++
+				notAvailableSum += link.Duration
+			}
+		}
+	}
+
+	if res.Available > 0 {
+		res.AverageAvailableDuration = availableSum / time.Duration(res.Available)
+	}
+	if res.NotAvailable > 0 {
+		res.AverageNotAvailableDuration = notAvailableSum / time.Duration(res.NotAvailable)
+	}
+
+	if len(percentiles) == 0 {
+		percentiles = DefaultPercentiles
+	}
+	res.AvailableDurationPercentiles = durationPercentiles(available, percentiles)
+
+	return res
+}
+
+// durationPercentiles returns the given percentiles of durations by the nearest-rank method,
+// computed over a sorted copy. It returns nil for no durations.
+func durationPercentiles(durations []time.Duration, percentiles []float64) []models.DurationPercentile {
+	if len(durations) == 0 {
+		return nil
+	}
+
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+
+	res := make([]models.DurationPercentile, 0, len(percentiles))
+	for _, p := range percentiles {
+		rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+		rank = min(max(rank, 1), len(sorted))
+		res = append(res, models.DurationPercentile{Percentile: p, Duration: sorted[rank-1]})
+	}
+	return res
+}
+
+// Slowest returns up to n links across all groups with the longest check duration, slowest first.
+func Slowest(groups []models.Links, n int) []models.Link {
+	var links []models.Link
+	for _, group := range groups {
+		links = append(links, group.Links...)
+	}
+
+	sort.SliceStable(links, func(i, j int) bool {
+		return links[i].Duration > links[j].Duration
+	})
+
+	if len(links) > n {
+		links = links[:n]
+	}
+	return links
+}
+
+// AvailabilityPercent returns the share of available links among the links not ignored
+// in percent, zero if every link is ignored or there are none.
+func AvailabilityPercent(s models.Statistics) float64 {
+	return percentOf(s.Available, s.Total-s.Ignored)
+}
+
+// percentOf returns part of total in percent, zero for no total.
+func percentOf(part, total int) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return float64(part) * 100 / float64(total)
+}
+
+// ByHost computes statistics of links across all groups per host, least available hosts first,
+// then hosts with more links. Links without a host (e.g. mailto:) are counted under an empty host,
+// skipped links only count toward the host total and ignored links are left out of its availability. With collapseWWW, "www.example.com" is counted
+// under "example.com".
+func ByHost(groups []models.Links, collapseWWW bool) []models.HostStatistics {
+	index := make(map[string]int)
+	var (
+		res  []models.HostStatistics
+		sums []time.Duration
+	)
+
+	for _, group := range groups {
+		for _, link := range group.Links {
+			host := urlchecker.Host(link.URL)
+			if collapseWWW {
+				host = urlchecker.CollapseWWW(host)
+			}
+			i, ok := index[host]
+			if !ok {
+				i = len(res)
+				index[host] = i
+				res = append(res, models.HostStatistics{Host: host})
+				sums = append(sums, 0)
+			}
+
+			res[i].Total++
+			switch {
+			case link.Ignored:
+				res[i].Ignored++
+			case link.Status == models.LinkStatusAvailable:
+				res[i].Available++
+			case link.Status.IsSkipped():
+			default:
+				res[i].NotAvailable++
+			}
+			sums[i] += link.Duration
+		}
+	}
+
+	for i := range res {
+		res[i].AverageDuration = sums[i] / time.Duration(res[i].Total)
+		res[i].AvailabilityPercent = percentOf(res[i].Available, res[i].Total-res[i].Ignored)
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].AvailabilityPercent != res[j].AvailabilityPercent {
+			return res[i].AvailabilityPercent < res[j].AvailabilityPercent
+		}
+		if res[i].Total != res[j].Total {
+			return res[i].Total > res[j].Total
+		}
+		return res[i].Host < res[j].Host
+	})
+
+	return res
+}
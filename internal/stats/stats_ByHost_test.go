@@ -0,0 +1,72 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestByHost(t *testing.T) {
+	groups := []models.Links{
+		{LinksNum: 1, Links: []models.Link{
+			{URL: "https://example.com/a", Status: models.LinkStatusAvailable, Duration: 100 * time.Millisecond},
+			{URL: "example.com/b", Status: models.LinkStatusNotAvailable, Duration: 300 * time.Millisecond},
+			{URL: "https://go.dev", Status: models.LinkStatusAvailable, Duration: 50 * time.Millisecond},
+		}},
+		{LinksNum: 2, Links: []models.Link{
+			{URL: "HTTP://Example.com:8080/c", Status: models.LinkStatusAvailable, Duration: 200 * time.Millisecond},
+			{URL: "https://broken.test", Status: models.LinkStatusNotAvailable, Duration: time.Second},
+			{URL: "https://go.dev/404", Status: models.LinkStatusNotAvailable, Duration: 150 * time.Millisecond, Ignored: true},
+		}},
+	}
+
+	got := ByHost(groups, false)
+
+	want := []models.HostStatistics{
+		{Host: "broken.test", Total: 1, NotAvailable: 1, AvailabilityPercent: 0, AverageDuration: time.Second},
+		{Host: "example.com", Total: 3, Available: 2, NotAvailable: 1, AvailabilityPercent: 200.0 / 3, AverageDuration: 200 * time.Millisecond},
+		{Host: "go.dev", Total: 2, Available: 1, Ignored: 1, AvailabilityPercent: 100, AverageDuration: 100 * time.Millisecond},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ByHost() returned %d hosts, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ByHost()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if empty := ByHost(nil, false); len(empty) != 0 {
+		t.Errorf("ByHost(nil, false) = %+v, want no hosts", empty)
+	}
+}
+
+func TestByHost_CollapseWWW(t *testing.T) {
+	groups := []models.Links{
+		{LinksNum: 1, Links: []models.Link{
+			{URL: "https://www.example.com/a", Status: models.LinkStatusAvailable, Duration: 100 * time.Millisecond},
+			{URL: "https://example.com/b", Status: models.LinkStatusNotAvailable, Duration: 300 * time.Millisecond},
+			{URL: "https://www.com", Status: models.LinkStatusAvailable, Duration: 50 * time.Millisecond},
+		}},
+	}
+
+	if got := ByHost(groups, false); len(got) != 3 {
+		t.Errorf("ByHost(groups, false) returned %d hosts, want 3: %+v", len(got), got)
+	}
+
+	got := ByHost(groups, true)
+
+	want := []models.HostStatistics{
+		{Host: "example.com", Total: 2, Available: 1, NotAvailable: 1, AvailabilityPercent: 50, AverageDuration: 200 * time.Millisecond},
+		{Host: "www.com", Total: 1, Available: 1, AvailabilityPercent: 100, AverageDuration: 50 * time.Millisecond},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ByHost() returned %d hosts, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ByHost()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func createTestLink(status models.LinkStatus, duration time.Duration) models.Link {
+	return models.Link{
+		URL:       "https://example.com",
+		Status:    status,
+		Duration:  duration,
+		CheckedAt: time.Now(),
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	t.Run("empty links", func(t *testing.T) {
+		got := Calculate(nil)
+
+		if got.Total != 0 || got.Available != 0 || got.NotAvailable != 0 {
+			t.Errorf("Calculate(nil) = %+v, want zero counts", got)
+		}
+		if got.AverageAvailableDuration != 0 || got.AverageNotAvailableDuration != 0 {
+			t.Errorf("Calculate(nil) = %+v, want zero averages", got)
+		}
+	})
+
+	t.Run("counts and averages by status", func(t *testing.T) {
+		links := []models.Link{
+			createTestLink(models.LinkStatusAvailable, 100*time.Millisecond),
+			createTestLink(models.LinkStatusAvailable, 300*time.Millisecond),
+			createTestLink(models.LinkStatusNotAvailable, 1*time.Second),
+		}
+
+		got := Calculate(links)
+
+		if got.Total != 3 {
+			t.Errorf("Calculate() Total = %d, want 3", got.Total)
+		}
+		if got.Available != 2 {
+			t.Errorf("Calculate() Available = %d, want 2", got.Available)
+		}
+		if got.NotAvailable != 1 {
+			t.Errorf("Calculate() NotAvailable = %d, want 1", got.NotAvailable)
+		}
+		if got.AverageAvailableDuration != 200*time.Millisecond {
+			t.Errorf("Calculate() AverageAvailableDuration = %v, want 200ms", got.AverageAvailableDuration)
+		}
+		if got.AverageNotAvailableDuration != time.Second {
+			t.Errorf("Calculate() AverageNotAvailableDuration = %v, want 1s", got.AverageNotAvailableDuration)
+		}
+	})
+
+	t.Run("counts slow links among available", func(t *testing.T) {
+		slow := createTestLink(models.LinkStatusAvailable, 3*time.Second)
+		slow.Slow = true
+
+		got := Calculate([]models.Link{slow, createTestLink(models.LinkStatusAvailable, 100*time.Millisecond)})
+
+		if got.Available != 2 || got.Slow != 1 {
+			t.Errorf("Calculate() Available = %d, Slow = %d, want 2 and 1", got.Available, got.Slow)
+		}
+	})
+
+	t.Run("counts ignored links outside availability", func(t *testing.T) {
+		ignored := createTestLink(models.LinkStatusNotAvailable, time.Second)
+		ignored.Ignored = true
+
+		got := Calculate([]models.Link{ignored, createTestLink(models.LinkStatusAvailable, 100*time.Millisecond)})
+
+		if got.Total != 2 || got.Ignored != 1 || got.Available != 1 || got.NotAvailable != 0 {
+			t.Errorf("Calculate() = %+v, want 2 total, 1 ignored, 1 available and none not available", got)
+		}
+		if got.AverageNotAvailableDuration != 0 {
+			t.Errorf("Calculate() AverageNotAvailableDuration = %v, want 0", got.AverageNotAvailableDuration)
+		}
+	})
+}
+
+func TestCalculateGroups(t *testing.T) {
+	groups := []models.Links{
+		{
+			LinksNum: 1,
+			Links: []models.Link{
+				createTestLink(models.LinkStatusAvailable, 100*time.Millisecond),
+			},
+		},
+		{
+			LinksNum: 2,
+			Links: []models.Link{
+				createTestLink(models.LinkStatusAvailable, 300*time.Millisecond),
+				createTestLink(models.LinkStatusNotAvailable, 2*time.Second),
+			},
+		},
+	}
+
+	got := CalculateGroups(groups)
+
+	if got.Groups != 2 {
+		t.Errorf("CalculateGroups() Groups = %d, want 2", got.Groups)
+	}
+	if got.Total != 3 {
+		t.Errorf("CalculateGroups() Total = %d, want 3", got.Total)
+	}
+	// Average is weighted by links, not by groups
+	if got.AverageAvailableDuration != 200*time.Millisecond {
+		t.Errorf("CalculateGroups() AverageAvailableDuration = %v, want 200ms", got.AverageAvailableDuration)
+	}
+	if got.AverageNotAvailableDuration != 2*time.Second {
+		t.Errorf("CalculateGroups() AverageNotAvailableDuration = %v, want 2s", got.AverageNotAvailableDuration)
+	}
+}
+
+func TestCalculate_Percentiles(t *testing.T) {
+	t.Run("default percentiles of available durations", func(t *testing.T) {
+		var links []models.Link
+		for i := 1; i <= 100; i++ {
+			links = append(links, createTestLink(models.LinkStatusAvailable, time.Duration(i)*time.Millisecond))
+		}
+		// not available and skipped links are not part of the percentiles
+		links = append(links,
+			createTestLink(models.LinkStatusNotAvailable, time.Minute),
+			createTestLink(models.LinkStatusSkipped, time.Minute),
+		)
+
+		got := Calculate(links).AvailableDurationPercentiles
+
+		want := []models.DurationPercentile{
+			{Percentile: 50, Duration: 50 * time.Millisecond},
+			{Percentile: 90, Duration: 90 * time.Millisecond},
+			{Percentile: 99, Duration: 99 * time.Millisecond},
+		}
+		if len(got) != len(want) {
+			t.Fatalf("Calculate() percentiles = %+v, want %+v", got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("Calculate() percentiles[%d] = %+v, want %+v", i, got[i], want[i])
+			}
+		}
+	})
+
+	t.Run("custom percentiles use nearest rank", func(t *testing.T) {
+		links := []models.Link{
+			createTestLink(models.LinkStatusAvailable, 300*time.Millisecond),
+			createTestLink(models.LinkStatusAvailable, 100*time.Millisecond),
+			createTestLink(models.LinkStatusAvailable, 200*time.Millisecond),
+		}
+
+		got := Calculate(links, 1, 75, 100).AvailableDurationPercentiles
+
+		want := []time.Duration{100 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond}
+		if len(got) != len(want) {
+			t.Fatalf("Calculate() percentiles = %+v, want %d entries", got, len(want))
+		}
+		for i, d := range want {
+			if got[i].Duration != d {
+				t.Errorf("Calculate() percentiles[%d] = %s, want %s", i, got[i].Duration, d)
+			}
+		}
+		if links[0].Duration != 300*time.Millisecond {
+			t.Error("Calculate() reordered the given links")
+		}
+	})
+
+	t.Run("no available links have no percentiles", func(t *testing.T) {
+		got := Calculate([]models.Link{createTestLink(models.LinkStatusNotAvailable, time.Second)})
+
+		if got.AvailableDurationPercentiles != nil {
+			t.Errorf("Calculate() percentiles = %+v, want nil", got.AvailableDurationPercentiles)
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestSlowest(t *testing.T) {
+	groups := []models.Links{
+		{LinksNum: 1, Links: []models.Link{
+			{URL: "https://a.com", Duration: 100 * time.Millisecond},
+			{URL: "https://b.com", Duration: 3 * time.Second},
+		}},
+		{LinksNum: 2, Links: []models.Link{
+			{URL: "https://c.com", Duration: time.Second},
+		}},
+	}
+
+	got := Slowest(groups, 2)
+
+	if len(got) != 2 {
+		t.Fatalf("Slowest() returned %d links, want 2", len(got))
+	}
+	if got[0].URL != "https://b.com" || got[1].URL != "https://c.com" {
+		t.Errorf("Slowest() = %s, %s, want https://b.com, https://c.com", got[0].URL, got[1].URL)
+	}
+
+	if all := Slowest(groups, 10); len(all) != 3 {
+		t.Errorf("Slowest() with n over total returned %d links, want 3", len(all))
+	}
+}
+
+func TestAvailabilityPercent(t *testing.T) {
+	if got := AvailabilityPercent(models.Statistics{}); got != 0 {
+		t.Errorf("AvailabilityPercent(empty) = %v, want 0", got)
+	}
+	if got := AvailabilityPercent(models.Statistics{Total: 4, Available: 3}); got != 75 {
+		t.Errorf("AvailabilityPercent() = %v, want 75", got)
+	}
+	if got := AvailabilityPercent(models.Statistics{Total: 5, Available: 3, Ignored: 1}); got != 75 {
+		t.Errorf("AvailabilityPercent() with an ignored link = %v, want 75", got)
+	}
+	if got := AvailabilityPercent(models.Statistics{Total: 2, Ignored: 2}); got != 0 {
+		t.Errorf("AvailabilityPercent() with every link ignored = %v, want 0", got)
+	}
+}
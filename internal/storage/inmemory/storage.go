@@ -1,51 +1,244 @@
 package inmemory
 
 import (
-	"encoding/json"
-	"errors"
 	"fmt"
-	"io"
 	"log/slog"
-	"os"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/polonkoevv/linkchecker/internal/models"
+	"github.com/polonkoevv/linkchecker/internal/storage"
 )
 
-// Storage implements an in-memory link repository with optional JSON persistence.
+// Storage implements an in-memory link repository, persisted through Snapshot and Restore.
+// Links are deep-copied on the way in and out, so neither callers mutating their slices
+// nor concurrent writes can affect stored or returned groups.
 type Storage struct {
 	links map[int][]models.Link
-	mtx   sync.RWMutex
+	// names holds labels of named groups.
+	names map[int]string
+	// spans holds the earliest and latest CheckedAt of each group for range queries.
+	spans map[int]checkSpan
+	// nextNum is the number of the next inserted group, numbers of evicted groups are not reused.
+	nextNum int
+	// maxGroups bounds the number of stored groups, zero means unlimited.
+	maxGroups int
+	// flushEvery and flush persist the storage after every flushEvery inserts, zero disables it.
+	flushEvery int
+	flush      func()
+	// inserts counts inserts and replaces since the last flush.
+	inserts int
+	mtx     sync.RWMutex
+}
+
+// Option configures a Storage.
+type Option func(*Storage)
+
+// WithMaxGroups caps the number of stored groups. Inserting beyond the cap evicts the
+// oldest groups (lowest numbers). Zero or negative n keeps storage unlimited.
+func WithMaxGroups(n int) Option {
+	return func(s *Storage) {
+		if n > 0 {
+			s.maxGroups = n
+		}
+	}
+}
+
+// WithFlushEvery calls flush in a new goroutine after every n inserted groups, e.g. to save
+// a snapshot between shutdowns. flush is expected to take a Snapshot, inserts do not wait for it.
+// Zero or negative n disables flushing.
+func WithFlushEvery(n int, flush func()) Option {
+	return func(s *Storage) {
+		if n > 0 && flush != nil {
+			s.flushEvery = n
+			s.flush = flush
+		}
+	}
+}
+
+// checkSpan is the time interval covering CheckedAt of all links in a group.
+type checkSpan struct {
+	first time.Time
+	last  time.Time
+}
+
+// newCheckSpan computes the span of links.
+func newCheckSpan(links []models.Link) checkSpan {
+	var span checkSpan
+	for i, link := range links {
+		if i == 0 || link.CheckedAt.Before(span.first) {
+			span.first = link.CheckedAt
+		}
+		if i == 0 || link.CheckedAt.After(span.last) {
+			span.last = link.CheckedAt
+		}
+	}
+	return span
+}
+
+// cloneLinks returns a deep copy of links.
+func cloneLinks(links []models.Link) []models.Link {
+	res := make([]models.Link, len(links))
+	for i, link := range links {
+		res[i] = link.Clone()
+	}
+	return res
 }
 
 // New creates an empty in-memory Storage instance.
-func New() *Storage {
-	return &Storage{
-		links: make(map[int][]models.Link),
-		mtx:   sync.RWMutex{},
+func New(opts ...Option) *Storage {
+	s := &Storage{
+		links:   make(map[int][]models.Link),
+		names:   make(map[int]string),
+		spans:   make(map[int]checkSpan),
+		nextNum: 1,
+		mtx:     sync.RWMutex{},
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 // InsertMany stores a batch of links and returns its group number.
 func (s *Storage) InsertMany(links []models.Link) (int, error) {
+	return s.InsertNamed("", links)
+}
+
+// InsertNamed stores a batch of links labeled with name and returns its group number.
+// An empty name stores an unnamed group. With WithMaxGroups, the oldest groups are evicted to make room.
+// Links with empty URLs are dropped, storing fails if none are left.
+func (s *Storage) InsertNamed(name string, links []models.Link) (int, error) {
+	links, err := storage.DropEmptyURLs(links)
+	if err != nil {
+		return 0, err
+	}
+
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
 
-	if len(links) == 0 {
-		return 0, errors.New("empty links slice")
+	if s.maxGroups > 0 {
+		s.evict(s.maxGroups - 1)
 	}
 
-	num := len(s.links) + 1
-	s.links[num] = links
+	num := s.nextNum
+	s.nextNum++
+	stored := make([]models.Link, len(links))
+	for i, link := range links {
+		link.GroupNum = num
+		stored[i] = link.Clone()
+	}
+	s.links[num] = stored
+	s.spans[num] = newCheckSpan(stored)
+	if name != "" {
+		s.names[num] = name
+	}
+	s.countWrite()
 
 	slog.Debug("inserted links batch",
 		slog.Int("links_num", num),
+		slog.String("name", name),
 		slog.Int("links_count", len(links)),
 	)
 
 	return num, nil
 }
 
+// Replace replaces the links of stored group num, keeping its number and name, e.g. to keep
+// the latest results of repeated checks in one group. It fails with a *models.GroupNotFoundError
+// if the group does not exist. Links with empty URLs are dropped, replacing fails if none are left.
+// Replaces count as inserts for WithFlushEvery.
+func (s *Storage) Replace(num int, links []models.Link) error {
+	links, err := storage.DropEmptyURLs(links)
+	if err != nil {
+		return err
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if _, ok := s.links[num]; !ok {
+		return &models.GroupNotFoundError{Nums: []int{num}}
+	}
+
+	stored := make([]models.Link, len(links))
+	for i, link := range links {
+		link.GroupNum = num
+		stored[i] = link.Clone()
+	}
+	s.links[num] = stored
+	s.spans[num] = newCheckSpan(stored)
+	s.countWrite()
+
+	slog.Debug("replaced links batch",
+		slog.Int("links_num", num),
+		slog.Int("links_count", len(links)),
+	)
+
+	return nil
+}
+
+// SetIgnored marks the links of group num with the given URL as ignored or not.
+// It fails with a *models.GroupNotFoundError if the group does not exist and with
+// models.ErrLinkNotFound if it has no link with that URL. Changes count as inserts for WithFlushEvery.
+func (s *Storage) SetIgnored(num int, url string, ignored bool) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	links, ok := s.links[num]
+	if !ok {
+		return &models.GroupNotFoundError{Nums: []int{num}}
+	}
+
+	found := false
+	for i := range links {
+		if links[i].URL == url {
+			links[i].Ignored = ignored
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("%w: %s", models.ErrLinkNotFound, url)
+	}
+	s.countWrite()
+
+	slog.Debug("set links ignored",
+		slog.Int("links_num", num),
+		slog.String("url", url),
+		slog.Bool("ignored", ignored),
+	)
+
+	return nil
+}
+
+// countWrite flushes the storage in the background every flushEvery writes.
+// The caller must hold the write lock.
+func (s *Storage) countWrite() {
+	if s.flushEvery <= 0 {
+		return
+	}
+	s.inserts++
+	if s.inserts >= s.flushEvery {
+		s.inserts = 0
+		go s.flush()
+	}
+}
+
+// GetByNum returns the stored link group with the given number.
+// It fails with a *models.GroupNotFoundError if the group does not exist.
+func (s *Storage) GetByNum(num int) (models.Links, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	links, ok := s.links[num]
+	if !ok {
+		return models.Links{}, &models.GroupNotFoundError{Nums: []int{num}}
+	}
+
+	return models.Links{LinksNum: num, Links: cloneLinks(links), Name: s.names[num]}, nil
+}
+
 // GetByNums returns stored link groups for the given group numbers.
 // Returns found groups and logs warnings for missing ones.
 func (s *Storage) GetByNums(linksNum []int) ([]models.Links, error) {
@@ -64,7 +257,8 @@ func (s *Storage) GetByNums(linksNum []int) ([]models.Links, error) {
 		}
 		res = append(res, models.Links{
 			LinksNum: num,
-			Links:    links,
+			Links:    cloneLinks(links),
+			Name:     s.names[num],
 		})
 	}
 
@@ -74,10 +268,7 @@ func (s *Storage) GetByNums(linksNum []int) ([]models.Links, error) {
 			slog.Any("missing_nums", missing),
 			slog.Int("found_count", len(res)),
 		)
-	}
-
-	if len(res) == 0 && len(missing) > 0 {
-		return nil, fmt.Errorf("none of the requested link groups were found: %v", missing)
+		return nil, &models.GroupNotFoundError{Nums: missing}
 	}
 
 	slog.Debug("loaded links by nums",
@@ -99,7 +290,8 @@ func (s *Storage) GetAll() ([]models.Links, error) {
 	for k, v := range s.links {
 		res = append(res, models.Links{
 			LinksNum: k,
-			Links:    v,
+			Links:    cloneLinks(v),
+			Name:     s.names[k],
 		})
 	}
 
@@ -108,38 +300,297 @@ func (s *Storage) GetAll() ([]models.Links, error) {
 	return res, nil
 }
 
-// LoadFromFile populates storage state from a JSON file if it exists.
-func (s *Storage) LoadFromFile(path string) error {
-	s.mtx.Lock()
-	defer s.mtx.Unlock()
+// Summaries returns link counts and check time spans of all stored groups ordered by number.
+// AvailabilityPercent is left for the caller to compute.
+func (s *Storage) Summaries() ([]models.GroupSummary, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	res := make([]models.GroupSummary, 0, len(s.links))
+	for num, links := range s.links {
+		summary := models.GroupSummary{
+			LinksNum:       num,
+			Name:           s.names[num],
+			Total:          len(links),
+			FirstCheckedAt: s.spans[num].first,
+			LastCheckedAt:  s.spans[num].last,
+		}
+		for _, link := range links {
+			switch {
+			case link.Ignored:
+				summary.Ignored++
+			case link.Status == models.LinkStatusAvailable:
+				summary.Available++
+			}
+		}
+		res = append(res, summary)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].LinksNum < res[j].LinksNum
+	})
+
+	slog.Debug("loaded link group summaries", slog.Int("groups_count", len(res)))
+
+	return res, nil
+}
+
+// GetBetween returns groups ordered by number that have at least one link checked
+// within [from, to]. A zero from or to leaves that side of the range open.
+// Groups are matched by their precomputed check span, links are scanned only
+// for groups that partially overlap the range.
+func (s *Storage) GetBetween(from, to time.Time) ([]models.Links, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	inRange := func(t time.Time) bool {
+		return (from.IsZero() || !t.Before(from)) && (to.IsZero() || !t.After(to))
+	}
+
+	res := []models.Links{}
+	for num, links := range s.links {
+		span := s.spans[num]
+		if (!from.IsZero() && span.last.Before(from)) || (!to.IsZero() && span.first.After(to)) {
+			continue
+		}
+
+		matched := inRange(span.first) || inRange(span.last)
+		for i := 0; !matched && i < len(links); i++ {
+			matched = inRange(links[i].CheckedAt)
+		}
+		if !matched {
+			continue
+		}
+
+		res = append(res, models.Links{
+			LinksNum: num,
+			Links:    cloneLinks(links),
+			Name:     s.names[num],
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].LinksNum < res[j].LinksNum
+	})
+
+	slog.Debug("loaded links groups by check time",
+		slog.Time("from", from),
+		slog.Time("to", to),
+		slog.Int("groups_count", len(res)),
+	)
 
-	file, err := os.Open(path)
+	return res, nil
+}
+
+// Search returns stored links matching every condition of q.
+// Links are ordered by group number and keep their order within a group.
+func (s *Storage) Search(q models.SearchQuery) ([]models.Link, error) {
+	matcher, err := storage.NewMatcher(q)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil
+		return nil, err
+	}
+
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	nums := make([]int, 0, len(s.links))
+	for num := range s.links {
+		nums = append(nums, num)
+	}
+	sort.Ints(nums)
+
+	res := []models.Link{}
+
+	for _, num := range nums {
+		for _, link := range s.links[num] {
+			if matcher.Match(link) {
+				link.GroupNum = num
+				res = append(res, link.Clone())
+			}
 		}
-		return fmt.Errorf("open storage file: %w", err)
 	}
-	defer file.Close()
 
-	var groups []models.Links
-	if err := json.NewDecoder(file).Decode(&groups); err != nil {
-		if errors.Is(err, io.EOF) {
-			return nil
+	slog.Debug("searched links",
+		slog.Any("query", q),
+		slog.Int("matches", len(res)),
+	)
+
+	return res, nil
+}
+
+// LatestByURLs returns the most recently stored check (highest group number) of each given URL.
+// URLs that were never checked are absent from the result.
+func (s *Storage) LatestByURLs(urls []string) (map[string]models.Link, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	wanted := make(map[string]struct{}, len(urls))
+	for _, url := range urls {
+		wanted[url] = struct{}{}
+	}
+
+	res := make(map[string]models.Link)
+	latestNum := make(map[string]int)
+
+	for num, links := range s.links {
+		for _, link := range links {
+			if _, ok := wanted[link.URL]; !ok {
+				continue
+			}
+			if num > latestNum[link.URL] {
+				latestNum[link.URL] = num
+				res[link.URL] = link.Clone()
+			}
 		}
-		return fmt.Errorf("decode storage file: %w", err)
 	}
 
+	return res, nil
+}
+
+// Restore replaces storage state with the given link groups, e.g. a loaded snapshot.
+func (s *Storage) Restore(groups []models.Links) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.restore(groups)
+}
+
+// restore replaces storage state with groups. The caller must hold the write lock.
+func (s *Storage) restore(groups []models.Links) {
 	s.links = make(map[int][]models.Link, len(groups))
+	s.names = make(map[int]string)
+	s.spans = make(map[int]checkSpan, len(groups))
+	s.nextNum = 1
 	for _, g := range groups {
-		s.links[g.LinksNum] = g.Links
+		if g.LinksNum >= s.nextNum {
+			s.nextNum = g.LinksNum + 1
+		}
+		stored := cloneLinks(g.Links)
+		for i := range stored {
+			stored[i].GroupNum = g.LinksNum
+		}
+		s.links[g.LinksNum] = stored
+		s.spans[g.LinksNum] = newCheckSpan(stored)
+		if g.Name != "" {
+			s.names[g.LinksNum] = g.Name
+		}
 	}
 
-	return nil
+	if s.maxGroups > 0 {
+		s.evict(s.maxGroups)
+	}
+}
+
+// Import stores groups from a snapshot and returns the numbers they are stored under, in order.
+// ImportModeMerge renumbers the groups onto fresh numbers after all stored and evicted groups,
+// so stored groups are never overwritten. ImportModeReplace drops all stored groups first and
+// keeps the imported numbers, like Restore. With WithMaxGroups, the oldest groups are evicted
+// after the import.
+func (s *Storage) Import(groups []models.Links, mode models.ImportMode) ([]int, error) {
+	for _, g := range groups {
+		if len(g.Links) == 0 {
+			return nil, fmt.Errorf("import group %d: empty links slice", g.LinksNum)
+		}
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	nums := make([]int, 0, len(groups))
+	switch mode {
+	case models.ImportModeReplace:
+		s.restore(groups)
+		for _, g := range groups {
+			nums = append(nums, g.LinksNum)
+		}
+	case models.ImportModeMerge:
+		for _, g := range groups {
+			num := s.nextNum
+			s.nextNum++
+			stored := make([]models.Link, len(g.Links))
+			for i, link := range g.Links {
+				link.GroupNum = num
+				stored[i] = link.Clone()
+			}
+			s.links[num] = stored
+			s.spans[num] = newCheckSpan(stored)
+			if g.Name != "" {
+				s.names[num] = g.Name
+			}
+			nums = append(nums, num)
+		}
+		if s.maxGroups > 0 {
+			s.evict(s.maxGroups)
+		}
+	default:
+		return nil, fmt.Errorf("unknown import mode: %s", mode)
+	}
+
+	slog.Debug("imported links groups",
+		slog.String("mode", string(mode)),
+		slog.Any("links_nums", nums),
+	)
+
+	return nums, nil
+}
+
+// evict removes the oldest groups until at most keep groups are stored.
+// The caller must hold the write lock.
+func (s *Storage) evict(keep int) {
+	if len(s.links) <= keep {
+		return
+	}
+
+	nums := make([]int, 0, len(s.links))
+	for num := range s.links {
+		nums = append(nums, num)
+	}
+	sort.Ints(nums)
+
+	evicted := nums[:len(nums)-keep]
+	for _, num := range evicted {
+		delete(s.links, num)
+		delete(s.names, num)
+		delete(s.spans, num)
+	}
+
+	slog.Info("evicted oldest link groups over storage cap",
+		slog.Int("max_groups", s.maxGroups),
+		slog.Any("evicted_nums", evicted),
+	)
 }
 
-// SaveToFile writes current storage state to a JSON file.
-func (s *Storage) SaveToFile(path string) error {
+// Expire removes groups whose links were all checked before the given time, e.g. groups
+// older than a TTL, and returns their numbers in ascending order. Removed groups are left
+// out of the next Snapshot.
+func (s *Storage) Expire(before time.Time) ([]int, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	expired := []int{}
+	for num, span := range s.spans {
+		if span.last.Before(before) {
+			expired = append(expired, num)
+		}
+	}
+	sort.Ints(expired)
+
+	for _, num := range expired {
+		delete(s.links, num)
+		delete(s.names, num)
+		delete(s.spans, num)
+	}
+
+	if len(expired) > 0 {
+		slog.Debug("expired link groups",
+			slog.Time("before", before),
+			slog.Any("expired_nums", expired),
+		)
+	}
+
+	return expired, nil
+}
+
+// Snapshot returns all stored link groups ordered by group number for persistence.
+func (s *Storage) Snapshot() []models.Links {
 	s.mtx.RLock()
 	defer s.mtx.RUnlock()
 
@@ -147,26 +598,18 @@ func (s *Storage) SaveToFile(path string) error {
 	for num, links := range s.links {
 		groups = append(groups, models.Links{
 			LinksNum: num,
-			Links:    links,
+			Links:    cloneLinks(links),
+			Name:     s.names[num],
 		})
 	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].LinksNum < groups[j].LinksNum
+	})
 
-	tmpPath := path + ".tmp"
-
-	file, err := os.Create(tmpPath)
-	if err != nil {
-		return fmt.Errorf("create storage file: %w", err)
-	}
-	enc := json.NewEncoder(file)
-	enc.SetIndent("", "  ")
-
-	if err := enc.Encode(groups); err != nil {
-		file.Close()
-		return fmt.Errorf("encode storage file: %w", err)
-	}
-	if err := file.Close(); err != nil {
-		return fmt.Errorf("close storage file: %w", err)
-	}
+	return groups
+}
 
+// Close does nothing, in-memory state is persisted separately through Snapshot.
+func (s *Storage) Close() error {
 	return nil
 }
@@ -1,6 +1,7 @@
 package inmemory
 
 import (
+	"errors"
 	"sync"
 	"testing"
 
@@ -164,4 +165,98 @@ func TestStorage_GetAll(t *testing.T) {
 			t.Fatalf("GetAll() returned %d errors during concurrent reads", len(errs))
 		}
 	})
+
+	t.Run("returned links are deep copies", func(t *testing.T) {
+		storage := New()
+		matched := true
+		link := createTestLink("https://example.com", models.LinkStatusAvailable)
+		link.ContentMatched = &matched
+		if _, err := storage.InsertMany([]models.Link{link}); err != nil {
+			t.Fatalf("InsertMany() error = %v, want nil", err)
+		}
+		matched = false
+
+		result, err := storage.GetAll()
+		if err != nil {
+			t.Fatalf("GetAll() error = %v, want nil", err)
+		}
+		result[0].Links[0].URL = "https://mutated.test"
+		*result[0].Links[0].ContentMatched = false
+
+		again, err := storage.GetAll()
+		if err != nil {
+			t.Fatalf("GetAll() error = %v, want nil", err)
+		}
+		got := again[0].Links[0]
+		if got.URL != "https://example.com" {
+			t.Errorf("stored URL = %s, want https://example.com", got.URL)
+		}
+		if got.ContentMatched == nil || !*got.ContentMatched {
+			t.Errorf("stored ContentMatched = %v, want true", got.ContentMatched)
+		}
+	})
+
+	t.Run("concurrent reads and writes", func(t *testing.T) {
+		// run with -race to catch shared state between callers and the storage
+		storage := New(WithMaxGroups(5))
+		latency := &models.LatencyStats{Samples: 2}
+
+		var wg sync.WaitGroup
+		for w := 0; w < 4; w++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 50; i++ {
+					link := createTestLink("https://example.com", models.LinkStatusAvailable)
+					link.Latency = latency
+					if _, err := storage.InsertMany([]models.Link{link}); err != nil {
+						t.Errorf("InsertMany() error = %v, want nil", err)
+						return
+					}
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 50; i++ {
+					groups, err := storage.GetAll()
+					if err != nil {
+						t.Errorf("GetAll() error = %v, want nil", err)
+						return
+					}
+					nums := make([]int, 0, len(groups))
+					for _, group := range groups {
+						nums = append(nums, group.LinksNum)
+						for j := range group.Links {
+							group.Links[j].Status = models.LinkStatusNotAvailable
+							group.Links[j].Latency.Samples++
+						}
+					}
+					if len(nums) == 0 {
+						continue
+					}
+					found, err := storage.GetByNums(nums)
+					if err != nil && !errors.Is(err, models.ErrGroupNotFound) {
+						t.Errorf("GetByNums() error = %v, want nil", err)
+						return
+					}
+					for _, group := range found {
+						group.Links[0].URL = "https://mutated.test"
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		groups, err := storage.GetAll()
+		if err != nil {
+			t.Fatalf("GetAll() error = %v, want nil", err)
+		}
+		for _, group := range groups {
+			for _, link := range group.Links {
+				if link.URL != "https://example.com" || link.Status != models.LinkStatusAvailable || link.Latency.Samples != 2 {
+					t.Errorf("stored link = %+v, want it untouched by readers", link)
+				}
+			}
+		}
+	})
 }
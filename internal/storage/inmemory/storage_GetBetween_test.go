@@ -0,0 +1,61 @@
+package inmemory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestStorage_GetBetween(t *testing.T) {
+	base := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	linkAt := func(url string, at time.Time) models.Link {
+		link := createTestLink(url, models.LinkStatusAvailable)
+		link.CheckedAt = at
+		return link
+	}
+
+	storage := New()
+	_, _ = storage.InsertMany([]models.Link{linkAt("https://a.com", base)})
+	_, _ = storage.InsertMany([]models.Link{
+		linkAt("https://b.com", base.Add(24*time.Hour)),
+		linkAt("https://c.com", base.Add(72*time.Hour)),
+	})
+	_, _ = storage.InsertMany([]models.Link{linkAt("https://d.com", base.Add(96*time.Hour))})
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     []int
+	}{
+		{name: "open range returns all", want: []int{1, 2, 3}},
+		{name: "from only", from: base.Add(time.Hour), want: []int{2, 3}},
+		{name: "to only", to: base.Add(time.Hour), want: []int{1}},
+		{name: "bounds are inclusive", from: base, to: base, want: []int{1}},
+		{name: "range inside group span without links", from: base.Add(36 * time.Hour), to: base.Add(48 * time.Hour), want: []int{}},
+		{name: "range hits one link of a group", from: base.Add(48 * time.Hour), to: base.Add(80 * time.Hour), want: []int{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := storage.GetBetween(tt.from, tt.to)
+
+			if err != nil {
+				t.Fatalf("GetBetween() error = %v, want nil", err)
+			}
+			got := make([]int, 0, len(result))
+			for _, g := range result {
+				got = append(got, g.LinksNum)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("GetBetween() groups = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("GetBetween() groups = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
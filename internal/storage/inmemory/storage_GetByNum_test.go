@@ -0,0 +1,43 @@
+package inmemory
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestStorage_GetByNum(t *testing.T) {
+	t.Run("get existing group", func(t *testing.T) {
+		storage := New()
+		num, err := storage.InsertNamed("docs", []models.Link{
+			createTestLink("https://example.com", models.LinkStatusAvailable),
+			createTestLink("https://google.com", models.LinkStatusNotAvailable),
+		})
+		if err != nil {
+			t.Fatalf("InsertNamed() error = %v, want nil", err)
+		}
+
+		group, err := storage.GetByNum(num)
+		if err != nil {
+			t.Fatalf("GetByNum() error = %v, want nil", err)
+		}
+		if group.LinksNum != num || group.Name != "docs" || len(group.Links) != 2 {
+			t.Errorf("GetByNum() = %+v, want group %d named docs with 2 links", group, num)
+		}
+	})
+
+	t.Run("missing group", func(t *testing.T) {
+		storage := New()
+
+		_, err := storage.GetByNum(7)
+
+		var notFound *models.GroupNotFoundError
+		if !errors.As(err, &notFound) || len(notFound.Nums) != 1 || notFound.Nums[0] != 7 {
+			t.Errorf("GetByNum() error = %v, want GroupNotFoundError for 7", err)
+		}
+		if !errors.Is(err, models.ErrGroupNotFound) {
+			t.Errorf("GetByNum() error = %v, want ErrGroupNotFound", err)
+		}
+	})
+}
@@ -65,12 +65,19 @@ func TestStorage_GetByNums(t *testing.T) {
 		if err == nil {
 			t.Error("GetByNums() error = nil, want error")
 		}
+		if !errors.Is(err, models.ErrGroupNotFound) {
+			t.Errorf("GetByNums() error = %v, want ErrGroupNotFound", err)
+		}
+		var notFound *models.GroupNotFoundError
+		if !errors.As(err, &notFound) || len(notFound.Nums) != 1 || notFound.Nums[0] != 999 {
+			t.Errorf("GetByNums() error = %v, want GroupNotFoundError with [999]", err)
+		}
 		if result != nil {
 			t.Errorf("GetByNums() result = %v, want nil", result)
 		}
 	})
 
-	t.Run("get partial results returns found groups without error", func(t *testing.T) {
+	t.Run("get mixed found and missing groups returns error", func(t *testing.T) {
 		storage := New()
 
 		links1 := []models.Link{
@@ -83,13 +90,17 @@ func TestStorage_GetByNums(t *testing.T) {
 		num1, _ := storage.InsertMany(links1)
 		num2, _ := storage.InsertMany(links2)
 
-		result, err := storage.GetByNums([]int{num1, 999, num2})
+		result, err := storage.GetByNums([]int{num1, 999, num2, 1000})
 
-		if err != nil {
-			t.Fatalf("GetByNums() error = %v, want nil (partial results should not error)", err)
+		var notFound *models.GroupNotFoundError
+		if !errors.As(err, &notFound) {
+			t.Fatalf("GetByNums() error = %v, want GroupNotFoundError", err)
 		}
-		if len(result) != 2 {
-			t.Fatalf("GetByNums() returned %d groups, want 2", len(result))
+		if len(notFound.Nums) != 2 || notFound.Nums[0] != 999 || notFound.Nums[1] != 1000 {
+			t.Errorf("GetByNums() missing = %v, want [999 1000]", notFound.Nums)
+		}
+		if result != nil {
+			t.Errorf("GetByNums() result = %v, want nil", result)
 		}
 	})
 
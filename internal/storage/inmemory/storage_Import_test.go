@@ -0,0 +1,105 @@
+package inmemory
+
+import (
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestStorage_Import(t *testing.T) {
+	backup := func() []models.Links {
+		return []models.Links{
+			{LinksNum: 1, Name: "backup", Links: []models.Link{createTestLink("https://backup.example.com", models.LinkStatusAvailable)}},
+			{LinksNum: 2, Links: []models.Link{createTestLink("https://go.dev", models.LinkStatusNotAvailable)}},
+		}
+	}
+
+	t.Run("merge renumbers colliding groups", func(t *testing.T) {
+		storage := New()
+		_, _ = storage.InsertMany([]models.Link{createTestLink("https://example.com", models.LinkStatusAvailable)})
+		_, _ = storage.InsertMany([]models.Link{createTestLink("https://google.com", models.LinkStatusAvailable)})
+
+		nums, err := storage.Import(backup(), models.ImportModeMerge)
+		if err != nil {
+			t.Fatalf("Import() error = %v, want nil", err)
+		}
+		if len(nums) != 2 || nums[0] != 3 || nums[1] != 4 {
+			t.Fatalf("Import() = %v, want [3 4]", nums)
+		}
+
+		groups, err := storage.GetByNums([]int{1, 2, 3, 4})
+		if err != nil {
+			t.Fatalf("GetByNums() error = %v, want nil", err)
+		}
+		if len(groups) != 4 {
+			t.Fatalf("GetByNums() returned %d groups, want 4", len(groups))
+		}
+		if groups[0].Links[0].URL != "https://example.com" || groups[1].Links[0].URL != "https://google.com" {
+			t.Errorf("stored groups were overwritten: %+v", groups[:2])
+		}
+		if groups[2].Name != "backup" || groups[2].Links[0].URL != "https://backup.example.com" || groups[2].Links[0].GroupNum != 3 {
+			t.Errorf("imported group 3 = %+v, want backup group renumbered to 3", groups[2])
+		}
+
+		next, _ := storage.InsertMany([]models.Link{createTestLink("https://github.com", models.LinkStatusAvailable)})
+		if next != 5 {
+			t.Errorf("InsertMany() after Import() = %d, want 5", next)
+		}
+	})
+
+	t.Run("merge does not reuse evicted numbers", func(t *testing.T) {
+		storage := New(WithMaxGroups(1))
+		_, _ = storage.InsertMany([]models.Link{createTestLink("https://example.com", models.LinkStatusAvailable)})
+		_, _ = storage.InsertMany([]models.Link{createTestLink("https://google.com", models.LinkStatusAvailable)})
+
+		nums, err := storage.Import(backup()[:1], models.ImportModeMerge)
+		if err != nil {
+			t.Fatalf("Import() error = %v, want nil", err)
+		}
+		if len(nums) != 1 || nums[0] != 3 {
+			t.Errorf("Import() = %v, want [3]", nums)
+		}
+		if groups, _ := storage.GetAll(); len(groups) != 1 || groups[0].LinksNum != 3 {
+			t.Errorf("GetAll() = %+v, want only imported group 3", groups)
+		}
+	})
+
+	t.Run("replace drops stored groups and keeps numbers", func(t *testing.T) {
+		storage := New()
+		for range 3 {
+			_, _ = storage.InsertMany([]models.Link{createTestLink("https://example.com", models.LinkStatusAvailable)})
+		}
+
+		nums, err := storage.Import(backup(), models.ImportModeReplace)
+		if err != nil {
+			t.Fatalf("Import() error = %v, want nil", err)
+		}
+		if len(nums) != 2 || nums[0] != 1 || nums[1] != 2 {
+			t.Fatalf("Import() = %v, want [1 2]", nums)
+		}
+
+		groups := storage.Snapshot()
+		if len(groups) != 2 || groups[0].Links[0].URL != "https://backup.example.com" || groups[1].Links[0].URL != "https://go.dev" {
+			t.Errorf("Snapshot() = %+v, want only imported groups", groups)
+		}
+	})
+
+	t.Run("empty group is rejected without changes", func(t *testing.T) {
+		storage := New()
+		_, _ = storage.InsertMany([]models.Link{createTestLink("https://example.com", models.LinkStatusAvailable)})
+
+		_, err := storage.Import([]models.Links{{LinksNum: 1}}, models.ImportModeReplace)
+		if err == nil {
+			t.Fatal("Import() error = nil, want error")
+		}
+		if groups := storage.Snapshot(); len(groups) != 1 || groups[0].Links[0].URL != "https://example.com" {
+			t.Errorf("Snapshot() = %+v, want storage unchanged", groups)
+		}
+	})
+
+	t.Run("unknown mode is rejected", func(t *testing.T) {
+		if _, err := New().Import(backup(), "append"); err == nil {
+			t.Error("Import() error = nil, want error")
+		}
+	})
+}
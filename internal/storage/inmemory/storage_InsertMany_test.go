@@ -101,6 +101,45 @@ func TestStorage_InsertMany(t *testing.T) {
 		}
 	})
 
+	t.Run("links with empty URLs are dropped", func(t *testing.T) {
+		storage := New()
+		links := []models.Link{
+			createTestLink("", models.LinkStatusNotAvailable),
+			createTestLink("https://example.com", models.LinkStatusAvailable),
+			createTestLink("   ", models.LinkStatusNotAvailable),
+		}
+
+		num, err := storage.InsertMany(links)
+		if err != nil {
+			t.Fatalf("InsertMany() error = %v, want nil", err)
+		}
+
+		group, _ := storage.GetByNum(num)
+		if len(group.Links) != 1 || group.Links[0].URL != "https://example.com" {
+			t.Errorf("stored links = %+v, want only https://example.com", group.Links)
+		}
+	})
+
+	t.Run("batch of empty URLs returns error", func(t *testing.T) {
+		storage := New()
+		links := []models.Link{
+			createTestLink("", models.LinkStatusNotAvailable),
+			createTestLink("\t", models.LinkStatusNotAvailable),
+		}
+
+		num, err := storage.InsertMany(links)
+
+		if err == nil {
+			t.Error("InsertMany() error = nil, want error")
+		}
+		if num != 0 {
+			t.Errorf("InsertMany() num = %d, want 0 when error occurs", num)
+		}
+		if got := len(storage.Snapshot()); got != 0 {
+			t.Errorf("Snapshot() returned %d groups, want 0", got)
+		}
+	})
+
 	t.Run("large batch returns number and no error", func(t *testing.T) {
 		storage := New()
 		const size = 1000
@@ -120,6 +159,35 @@ func TestStorage_InsertMany(t *testing.T) {
 		}
 	})
 
+	t.Run("stored links carry group number", func(t *testing.T) {
+		storage := New()
+		links := []models.Link{
+			createTestLink("https://example.com", models.LinkStatusAvailable),
+			createTestLink("https://google.com", models.LinkStatusNotAvailable),
+		}
+
+		if _, err := storage.InsertMany(links); err != nil {
+			t.Fatalf("InsertMany() first batch error = %v, want nil", err)
+		}
+		num, err := storage.InsertMany(links)
+		if err != nil {
+			t.Fatalf("InsertMany() second batch error = %v, want nil", err)
+		}
+
+		groups, err := storage.GetByNums([]int{num})
+		if err != nil {
+			t.Fatalf("GetByNums() error = %v, want nil", err)
+		}
+		for _, link := range groups[0].Links {
+			if link.GroupNum != num {
+				t.Errorf("stored link %s GroupNum = %d, want %d", link.URL, link.GroupNum, num)
+			}
+		}
+		if links[0].GroupNum != 0 {
+			t.Errorf("InsertMany() modified caller slice, GroupNum = %d", links[0].GroupNum)
+		}
+	})
+
 	t.Run("non-empty slice returns nil error", func(t *testing.T) {
 		storage := New()
 		links := []models.Link{
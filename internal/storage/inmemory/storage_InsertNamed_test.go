@@ -0,0 +1,42 @@
+package inmemory
+
+import (
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestStorage_InsertNamed(t *testing.T) {
+	storage := New()
+
+	named, err := storage.InsertNamed("marketing-site-2024-06", []models.Link{
+		createTestLink("https://example.com", models.LinkStatusAvailable),
+	})
+	if err != nil {
+		t.Fatalf("InsertNamed() error = %v, want nil", err)
+	}
+	unnamed, _ := storage.InsertMany([]models.Link{
+		createTestLink("https://google.com", models.LinkStatusAvailable),
+	})
+
+	groups, err := storage.GetByNums([]int{named, unnamed})
+	if err != nil {
+		t.Fatalf("GetByNums() error = %v, want nil", err)
+	}
+	if groups[0].Name != "marketing-site-2024-06" {
+		t.Errorf("GetByNums() group %d Name = %q, want %q", named, groups[0].Name, "marketing-site-2024-06")
+	}
+	if groups[1].Name != "" {
+		t.Errorf("GetByNums() group %d Name = %q, want empty", unnamed, groups[1].Name)
+	}
+
+	restored := New()
+	restored.Restore(storage.Snapshot())
+
+	all, _ := restored.GetAll()
+	for _, g := range all {
+		if g.LinksNum == named && g.Name != "marketing-site-2024-06" {
+			t.Errorf("GetAll() after Restore Name = %q, want %q", g.Name, "marketing-site-2024-06")
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package inmemory
+
+import (
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestStorage_LatestByURLs(t *testing.T) {
+	storage := New()
+
+	first := createTestLink("https://example.com", models.LinkStatusAvailable)
+	first.ETag = `"v1"`
+	second := createTestLink("https://example.com", models.LinkStatusAvailable)
+	second.ETag = `"v2"`
+
+	_, _ = storage.InsertMany([]models.Link{first, createTestLink("https://google.com", models.LinkStatusAvailable)})
+	_, _ = storage.InsertMany([]models.Link{second})
+
+	result, err := storage.LatestByURLs([]string{"https://example.com", "https://never-checked.com"})
+
+	if err != nil {
+		t.Fatalf("LatestByURLs() error = %v, want nil", err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("LatestByURLs() returned %d links, want 1", len(result))
+	}
+	if got := result["https://example.com"]; got.ETag != `"v2"` || got.GroupNum != 2 {
+		t.Errorf("LatestByURLs() = etag %s in group %d, want \"v2\" in group 2", got.ETag, got.GroupNum)
+	}
+}
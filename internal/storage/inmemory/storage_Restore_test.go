@@ -0,0 +1,54 @@
+package inmemory
+
+import (
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestStorage_Restore(t *testing.T) {
+	t.Run("snapshot restores into a new storage", func(t *testing.T) {
+		src := New()
+		_, _ = src.InsertMany([]models.Link{createTestLink("https://example.com", models.LinkStatusAvailable)})
+		_, _ = src.InsertMany([]models.Link{createTestLink("https://google.com", models.LinkStatusAvailable)})
+
+		snapshot := src.Snapshot()
+		if len(snapshot) != 2 || snapshot[0].LinksNum != 1 || snapshot[1].LinksNum != 2 {
+			t.Fatalf("Snapshot() = %+v, want groups 1 and 2 in order", snapshot)
+		}
+
+		dst := New()
+		dst.Restore(snapshot)
+
+		groups, err := dst.GetByNums([]int{2})
+		if err != nil {
+			t.Fatalf("GetByNums() error = %v, want nil", err)
+		}
+		if groups[0].Links[0].URL != "https://google.com" {
+			t.Errorf("restored group 2 URL = %s, want https://google.com", groups[0].Links[0].URL)
+		}
+
+		num, err := dst.InsertMany([]models.Link{createTestLink("https://github.com", models.LinkStatusAvailable)})
+		if err != nil {
+			t.Fatalf("InsertMany() error = %v, want nil", err)
+		}
+		if num != 3 {
+			t.Errorf("InsertMany() after Restore num = %d, want 3", num)
+		}
+	})
+
+	t.Run("restore sets group numbers on links", func(t *testing.T) {
+		storage := New()
+		storage.Restore([]models.Links{
+			{LinksNum: 5, Links: []models.Link{createTestLink("https://example.com", models.LinkStatusAvailable)}},
+		})
+
+		groups, err := storage.GetByNums([]int{5})
+		if err != nil {
+			t.Fatalf("GetByNums() error = %v, want nil", err)
+		}
+		if groups[0].Links[0].GroupNum != 5 {
+			t.Errorf("restored link GroupNum = %d, want 5", groups[0].Links[0].GroupNum)
+		}
+	})
+}
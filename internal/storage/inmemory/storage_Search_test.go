@@ -0,0 +1,58 @@
+package inmemory
+
+import (
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestStorage_Search(t *testing.T) {
+	t.Run("returns matching links with group numbers", func(t *testing.T) {
+		storage := New()
+
+		_, _ = storage.InsertMany([]models.Link{
+			createTestLink("https://example.com", models.LinkStatusAvailable),
+			createTestLink("https://google.com", models.LinkStatusAvailable),
+		})
+		_, _ = storage.InsertMany([]models.Link{
+			createTestLink("https://github.com", models.LinkStatusAvailable),
+		})
+		_, _ = storage.InsertMany([]models.Link{
+			createTestLink("https://docs.EXAMPLE.com/start", models.LinkStatusNotAvailable),
+		})
+
+		result, err := storage.Search(models.SearchQuery{Query: "example.com"})
+
+		if err != nil {
+			t.Fatalf("Search() error = %v, want nil", err)
+		}
+		if len(result) != 2 {
+			t.Fatalf("Search() returned %d links, want 2", len(result))
+		}
+		if result[0].URL != "https://example.com" || result[0].GroupNum != 1 {
+			t.Errorf("Search() result[0] = %s in group %d, want https://example.com in group 1", result[0].URL, result[0].GroupNum)
+		}
+		if result[1].URL != "https://docs.EXAMPLE.com/start" || result[1].GroupNum != 3 {
+			t.Errorf("Search() result[1] = %s in group %d, want https://docs.EXAMPLE.com/start in group 3", result[1].URL, result[1].GroupNum)
+		}
+	})
+
+	t.Run("no matches returns empty slice", func(t *testing.T) {
+		storage := New()
+		_, _ = storage.InsertMany([]models.Link{
+			createTestLink("https://example.com", models.LinkStatusAvailable),
+		})
+
+		result, err := storage.Search(models.SearchQuery{Query: "nothing-here"})
+
+		if err != nil {
+			t.Fatalf("Search() error = %v, want nil", err)
+		}
+		if result == nil {
+			t.Error("Search() returned nil, want empty slice")
+		}
+		if len(result) != 0 {
+			t.Errorf("Search() returned %d links, want 0", len(result))
+		}
+	})
+}
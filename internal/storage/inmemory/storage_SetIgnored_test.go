@@ -0,0 +1,54 @@
+package inmemory
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestStorage_SetIgnored(t *testing.T) {
+	storage := New()
+	num, _ := storage.InsertNamed("docs", []models.Link{
+		createTestLink("https://a.com", models.LinkStatusAvailable),
+		createTestLink("https://b.com/404", models.LinkStatusNotAvailable),
+	})
+	other, _ := storage.InsertMany([]models.Link{createTestLink("https://b.com/404", models.LinkStatusNotAvailable)})
+
+	if err := storage.SetIgnored(num, "https://b.com/404", true); err != nil {
+		t.Fatalf("SetIgnored() error = %v, want nil", err)
+	}
+
+	group, err := storage.GetByNum(num)
+	if err != nil {
+		t.Fatalf("GetByNum() error = %v, want nil", err)
+	}
+	if group.Links[0].Ignored || !group.Links[1].Ignored || group.Links[1].Status != models.LinkStatusNotAvailable {
+		t.Errorf("Links = %+v, want only b.com/404 ignored with its status kept", group.Links)
+	}
+	if group, _ := storage.GetByNum(other); group.Links[0].Ignored {
+		t.Error("SetIgnored() marked the link of another group")
+	}
+
+	summaries, err := storage.Summaries()
+	if err != nil {
+		t.Fatalf("Summaries() error = %v, want nil", err)
+	}
+	if got := summaries[0]; got.Total != 2 || got.Available != 1 || got.Ignored != 1 {
+		t.Errorf("Summaries()[0] = %+v, want 2 links, 1 available and 1 ignored", got)
+	}
+
+	if err := storage.SetIgnored(num, "https://b.com/404", false); err != nil {
+		t.Fatalf("SetIgnored(false) error = %v, want nil", err)
+	}
+	if group, _ := storage.GetByNum(num); group.Links[1].Ignored {
+		t.Error("SetIgnored(false) kept the link ignored")
+	}
+
+	if err := storage.SetIgnored(99, "https://a.com", true); !errors.Is(err, models.ErrGroupNotFound) {
+		t.Errorf("SetIgnored(99) error = %v, want ErrGroupNotFound", err)
+	}
+	if err := storage.SetIgnored(num, "https://c.com", true); !errors.Is(err, models.ErrLinkNotFound) {
+		t.Errorf("SetIgnored() of a missing URL error = %v, want ErrLinkNotFound", err)
+	}
+}
@@ -0,0 +1,49 @@
+package inmemory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestStorage_Summaries(t *testing.T) {
+	storage := New()
+
+	first := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+	links := []models.Link{
+		createTestLink("https://example.com", models.LinkStatusAvailable),
+		createTestLink("https://google.com", models.LinkStatusNotAvailable),
+		createTestLink("https://go.dev", models.LinkStatusAvailable),
+	}
+	for i := range links {
+		links[i].CheckedAt = first.Add(time.Duration(i) * time.Minute)
+	}
+	docs, err := storage.InsertNamed("docs", links)
+	if err != nil {
+		t.Fatalf("InsertNamed() error = %v, want nil", err)
+	}
+	other, err := storage.InsertMany([]models.Link{createTestLink("https://broken.test", models.LinkStatusNotAvailable)})
+	if err != nil {
+		t.Fatalf("InsertMany() error = %v, want nil", err)
+	}
+
+	summaries, err := storage.Summaries()
+	if err != nil {
+		t.Fatalf("Summaries() error = %v, want nil", err)
+	}
+	if len(summaries) != 2 {
+		t.Fatalf("Summaries() returned %d groups, want 2: %+v", len(summaries), summaries)
+	}
+
+	got := summaries[0]
+	if got.LinksNum != docs || got.Name != "docs" || got.Total != 3 || got.Available != 2 {
+		t.Errorf("Summaries()[0] = %+v, want group %d named docs with 2 of 3 links available", got, docs)
+	}
+	if !got.FirstCheckedAt.Equal(first) || !got.LastCheckedAt.Equal(first.Add(2*time.Minute)) {
+		t.Errorf("Summaries()[0] checked at %s - %s, want %s - %s", got.FirstCheckedAt, got.LastCheckedAt, first, first.Add(2*time.Minute))
+	}
+	if got := summaries[1]; got.LinksNum != other || got.Total != 1 || got.Available != 0 {
+		t.Errorf("Summaries()[1] = %+v, want group %d with 0 of 1 links available", got, other)
+	}
+}
@@ -0,0 +1,52 @@
+package inmemory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestStorage_WithFlushEvery(t *testing.T) {
+	t.Run("flushes after every n inserts", func(t *testing.T) {
+		flushed := make(chan int, 10)
+		var storage *Storage
+		storage = New(WithFlushEvery(2, func() {
+			flushed <- len(storage.Snapshot())
+		}))
+
+		for i := 0; i < 5; i++ {
+			if _, err := storage.InsertMany([]models.Link{createTestLink("https://example.com", models.LinkStatusAvailable)}); err != nil {
+				t.Fatalf("InsertMany() error = %v, want nil", err)
+			}
+		}
+
+		for want := 2; want <= 4; want += 2 {
+			select {
+			case got := <-flushed:
+				// flushes run in the background and may see later inserts
+				if got < want {
+					t.Errorf("flush saw %d groups, want at least %d", got, want)
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("flush after %d inserts was not called", want)
+			}
+		}
+
+		select {
+		case <-flushed:
+			t.Error("flush called after 5 inserts more than twice")
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("zero disables flushing", func(t *testing.T) {
+		storage := New(WithFlushEvery(0, func() {
+			t.Error("flush called with flushing disabled")
+		}))
+
+		if _, err := storage.InsertMany([]models.Link{createTestLink("https://example.com", models.LinkStatusAvailable)}); err != nil {
+			t.Fatalf("InsertMany() error = %v, want nil", err)
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package inmemory
+
+import (
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestStorage_WithMaxGroups(t *testing.T) {
+	t.Run("insert evicts oldest groups over the cap", func(t *testing.T) {
+		storage := New(WithMaxGroups(2))
+
+		for _, url := range []string{"https://a.com", "https://b.com", "https://c.com"} {
+			if _, err := storage.InsertMany([]models.Link{createTestLink(url, models.LinkStatusAvailable)}); err != nil {
+				t.Fatalf("InsertMany() error = %v, want nil", err)
+			}
+		}
+
+		groups := storage.Snapshot()
+		if len(groups) != 2 || groups[0].LinksNum != 2 || groups[1].LinksNum != 3 {
+			t.Fatalf("Snapshot() = %+v, want groups 2 and 3", groups)
+		}
+
+		num, _ := storage.InsertMany([]models.Link{createTestLink("https://d.com", models.LinkStatusAvailable)})
+		if num != 4 {
+			t.Errorf("InsertMany() after eviction = %d, want 4 (numbers are not reused)", num)
+		}
+	})
+
+	t.Run("restore keeps newest groups within the cap", func(t *testing.T) {
+		storage := New(WithMaxGroups(1))
+		storage.Restore([]models.Links{
+			{LinksNum: 3, Links: []models.Link{createTestLink("https://a.com", models.LinkStatusAvailable)}},
+			{LinksNum: 7, Links: []models.Link{createTestLink("https://b.com", models.LinkStatusAvailable)}},
+		})
+
+		groups := storage.Snapshot()
+		if len(groups) != 1 || groups[0].LinksNum != 7 {
+			t.Fatalf("Snapshot() after Restore = %+v, want group 7", groups)
+		}
+
+		num, _ := storage.InsertMany([]models.Link{createTestLink("https://c.com", models.LinkStatusAvailable)})
+		if num != 8 {
+			t.Errorf("InsertMany() after Restore = %d, want 8", num)
+		}
+	})
+
+	t.Run("zero cap is unlimited", func(t *testing.T) {
+		storage := New(WithMaxGroups(0))
+		for i := 0; i < 5; i++ {
+			_, _ = storage.InsertMany([]models.Link{createTestLink("https://a.com", models.LinkStatusAvailable)})
+		}
+		if got := len(storage.Snapshot()); got != 5 {
+			t.Errorf("Snapshot() returned %d groups, want 5", got)
+		}
+	})
+}
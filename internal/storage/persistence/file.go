@@ -0,0 +1,71 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+// File keeps snapshots in a local JSON file.
+type File struct {
+	path    string
+	compact bool
+}
+
+// NewFile creates a file backend writing to path, as compact JSON if compact is set
+// and indented otherwise.
+func NewFile(path string, compact bool) *File {
+	return &File{path: path, compact: compact}
+}
+
+// Load reads link groups from the file, returning no groups if it does not exist.
+func (f *File) Load(_ context.Context) ([]models.Links, error) {
+	file, err := os.Open(f.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("open storage file: %w", err)
+	}
+	defer file.Close()
+
+	return Decode(file)
+}
+
+// Save writes link groups to a temporary file and atomically renames it over the target.
+func (f *File) Save(_ context.Context, groups []models.Links) error {
+	if dir := filepath.Dir(f.path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("create storage dir: %w", err)
+		}
+	}
+
+	tmpPath := f.path + ".tmp"
+
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("create storage file: %w", err)
+	}
+
+	if err := Encode(file, groups, f.compact); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("close storage file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, f.path); err != nil {
+		return fmt.Errorf("rename storage file: %w", err)
+	}
+
+	return nil
+}
+
+// Location returns the file path.
+func (f *File) Location() string {
+	return f.path
+}
@@ -0,0 +1,111 @@
+package persistence
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestFile_Save(t *testing.T) {
+	t.Run("round trips saved groups", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "nested", "links.json")
+		backend := NewFile(path, false)
+
+		groups := []models.Links{
+			{
+				LinksNum: 1,
+				Links: []models.Link{
+					{URL: "https://example.com", Status: models.LinkStatusAvailable, Duration: time.Second, CheckedAt: time.Now().UTC()},
+				},
+			},
+		}
+
+		if err := backend.Save(context.Background(), groups); err != nil {
+			t.Fatalf("Save() error = %v, want nil", err)
+		}
+		if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+			t.Errorf("Save() left temporary file, stat error = %v", err)
+		}
+
+		loaded, err := backend.Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() error = %v, want nil", err)
+		}
+		if len(loaded) != 1 || len(loaded[0].Links) != 1 || loaded[0].Links[0].URL != "https://example.com" {
+			t.Errorf("Load() = %+v, want saved groups", loaded)
+		}
+	})
+
+	t.Run("compact writes JSON without indentation", func(t *testing.T) {
+		groups := []models.Links{
+			{LinksNum: 1, Links: []models.Link{{URL: "https://example.com", Status: models.LinkStatusAvailable}}},
+		}
+
+		dir := t.TempDir()
+		compactPath := filepath.Join(dir, "compact.json")
+		indentedPath := filepath.Join(dir, "indented.json")
+		if err := NewFile(compactPath, true).Save(context.Background(), groups); err != nil {
+			t.Fatalf("Save() compact error = %v, want nil", err)
+		}
+		if err := NewFile(indentedPath, false).Save(context.Background(), groups); err != nil {
+			t.Fatalf("Save() indented error = %v, want nil", err)
+		}
+
+		compact, err := os.ReadFile(compactPath)
+		if err != nil {
+			t.Fatalf("ReadFile() error = %v", err)
+		}
+		indented, err := os.ReadFile(indentedPath)
+		if err != nil {
+			t.Fatalf("ReadFile() error = %v", err)
+		}
+		if strings.Contains(string(compact), "\n  ") {
+			t.Errorf("compact snapshot is indented: %s", compact)
+		}
+		if !strings.Contains(string(indented), "\n  ") {
+			t.Errorf("default snapshot is not indented: %s", indented)
+		}
+
+		loaded, err := NewFile(compactPath, true).Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() error = %v, want nil", err)
+		}
+		if len(loaded) != 1 || loaded[0].Links[0].URL != "https://example.com" {
+			t.Errorf("Load() = %+v, want saved groups", loaded)
+		}
+	})
+
+	t.Run("missing file loads no groups", func(t *testing.T) {
+		backend := NewFile(filepath.Join(t.TempDir(), "missing.json"), false)
+
+		loaded, err := backend.Load(context.Background())
+
+		if err != nil {
+			t.Fatalf("Load() error = %v, want nil", err)
+		}
+		if len(loaded) != 0 {
+			t.Errorf("Load() returned %d groups, want 0", len(loaded))
+		}
+	})
+
+	t.Run("empty file loads no groups", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "empty.json")
+		if err := os.WriteFile(path, nil, 0o600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+
+		loaded, err := NewFile(path, false).Load(context.Background())
+
+		if err != nil {
+			t.Fatalf("Load() error = %v, want nil", err)
+		}
+		if len(loaded) != 0 {
+			t.Errorf("Load() returned %d groups, want 0", len(loaded))
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/polonkoevv/linkchecker/internal/config"
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+// Backend names accepted in STORAGE_BACKEND.
+const (
+	BackendFile = "file"
+	BackendS3   = "s3"
+	// BackendSQLite keeps links in a SQLite database, which needs no snapshots.
+	BackendSQLite = "sqlite"
+)
+
+// Backend loads and saves snapshots of stored link groups.
+type Backend interface {
+	// Load returns the saved link groups, or no groups if nothing was saved yet.
+	Load(ctx context.Context) ([]models.Links, error)
+	// Save replaces the saved snapshot with groups.
+	Save(ctx context.Context, groups []models.Links) error
+	// Location describes where snapshots are kept, for logging.
+	Location() string
+}
+
+// New creates the persistence backend selected in the storage configuration.
+func New(cfg config.StorageConfig) (Backend, error) {
+	switch cfg.Backend {
+	case BackendFile:
+		return NewFile(cfg.FileStoragePath, cfg.CompactJSON), nil
+	case BackendS3:
+		return NewS3(cfg.S3, cfg.CompactJSON)
+	case BackendSQLite:
+		return nil, fmt.Errorf("storage backend %s stores links directly and has no snapshots", cfg.Backend)
+	default:
+		return nil, fmt.Errorf("unknown storage backend: %s", cfg.Backend)
+	}
+}
+
+// Decode reads link groups in the snapshot format, treating empty input as no groups.
+// Snapshots that cannot be restored, see validate, fail with models.ErrInvalidSnapshot.
+func Decode(r io.Reader) ([]models.Links, error) {
+	var groups []models.Links
+	if err := json.NewDecoder(r).Decode(&groups); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("decode storage snapshot: %w: %w", models.ErrInvalidSnapshot, err)
+	}
+	if err := validate(groups); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// validate rejects groups with non-positive or repeated numbers and groups without links,
+// which storage cannot restore consistently.
+func validate(groups []models.Links) error {
+	seen := make(map[int]struct{}, len(groups))
+	for i, g := range groups {
+		if g.LinksNum <= 0 {
+			return fmt.Errorf("%w: group %d: links_num must be positive, got %d", models.ErrInvalidSnapshot, i, g.LinksNum)
+		}
+		if _, ok := seen[g.LinksNum]; ok {
+			return fmt.Errorf("%w: group %d: duplicate links_num %d", models.ErrInvalidSnapshot, i, g.LinksNum)
+		}
+		seen[g.LinksNum] = struct{}{}
+		if len(g.Links) == 0 {
+			return fmt.Errorf("%w: group %d: links must not be empty", models.ErrInvalidSnapshot, i)
+		}
+	}
+	return nil
+}
+
+// Encode writes link groups in the snapshot format, indented unless compact is set.
+func Encode(w io.Writer, groups []models.Links, compact bool) error {
+	enc := json.NewEncoder(w)
+	if !compact {
+		enc.SetIndent("", "  ")
+	}
+
+	if err := enc.Encode(groups); err != nil {
+		return fmt.Errorf("encode storage snapshot: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package persistence
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantGroups int
+		wantErr    bool
+	}{
+		{name: "empty input", input: "", wantGroups: 0},
+		{name: "empty array", input: "[]", wantGroups: 0},
+		{name: "valid groups", input: `[{"links_num":1,"links":[{"url":"https://example.com"}]},{"links_num":3,"links":[{"url":"https://go.dev"}]}]`, wantGroups: 2},
+		{name: "malformed JSON", input: `[{"links_num":1`, wantErr: true},
+		{name: "not an array", input: `{"links_num":1}`, wantErr: true},
+		{name: "non-positive number", input: `[{"links_num":0,"links":[{"url":"https://example.com"}]}]`, wantErr: true},
+		{name: "duplicate number", input: `[{"links_num":1,"links":[{"url":"https://a.com"}]},{"links_num":1,"links":[{"url":"https://b.com"}]}]`, wantErr: true},
+		{name: "group without links", input: `[{"links_num":1,"links":[]}]`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups, err := Decode(strings.NewReader(tt.input))
+
+			if tt.wantErr {
+				if !errors.Is(err, models.ErrInvalidSnapshot) {
+					t.Fatalf("Decode() error = %v, want ErrInvalidSnapshot", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Decode() error = %v, want nil", err)
+			}
+			if len(groups) != tt.wantGroups {
+				t.Errorf("Decode() returned %d groups, want %d", len(groups), tt.wantGroups)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package persistence
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/polonkoevv/linkchecker/internal/config"
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+// S3 keeps snapshots as a single object in an S3-compatible bucket.
+type S3 struct {
+	client  *minio.Client
+	bucket  string
+	key     string
+	compact bool
+}
+
+// NewS3 creates an S3 backend for the configured endpoint, bucket and object key,
+// writing compact JSON if compact is set and indented otherwise.
+func NewS3(cfg config.S3Config, compact bool) (*S3, error) {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKeyID, cfg.SecretAccessKey, ""),
+		Secure: cfg.UseSSL,
+		Region: cfg.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create s3 client: %w", err)
+	}
+
+	return &S3{
+		client:  client,
+		bucket:  cfg.Bucket,
+		key:     cfg.Key,
+		compact: compact,
+	}, nil
+}
+
+// Load reads link groups from the object, returning no groups if it does not exist.
+func (s *S3) Load(ctx context.Context) ([]models.Links, error) {
+	obj, err := s.client.GetObject(ctx, s.bucket, s.key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("get storage object: %w", err)
+	}
+	defer obj.Close()
+
+	if _, err := obj.Stat(); err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("stat storage object: %w", err)
+	}
+
+	return Decode(obj)
+}
+
+// Save uploads link groups, replacing the object.
+func (s *S3) Save(ctx context.Context, groups []models.Links) error {
+	var buf bytes.Buffer
+	if err := Encode(&buf, groups, s.compact); err != nil {
+		return err
+	}
+
+	_, err := s.client.PutObject(ctx, s.bucket, s.key, &buf, int64(buf.Len()), minio.PutObjectOptions{
+		ContentType: "application/json",
+	})
+	if err != nil {
+		return fmt.Errorf("put storage object: %w", err)
+	}
+
+	return nil
+}
+
+// Location returns the object address.
+func (s *S3) Location() string {
+	return fmt.Sprintf("s3://%s/%s", s.bucket, s.key)
+}
@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+	"github.com/polonkoevv/linkchecker/internal/urlchecker"
+)
+
+// Limits keeping search patterns cheap to compile and match. RE2 matching is linear in the
+// input, so bounding the pattern and its compiled program bounds the cost per link.
+const (
+	maxSearchPatternLength = 256
+	maxRegexProgramSize    = 2000
+)
+
+// Matcher reports whether stored links match a search query.
+type Matcher struct {
+	query  string
+	glob   *regexp.Regexp
+	regex  *regexp.Regexp
+	host   string
+	status models.LinkStatus
+}
+
+// NewMatcher compiles q. It fails with models.ErrInvalidSearch if a pattern is invalid or too complex.
+func NewMatcher(q models.SearchQuery) (*Matcher, error) {
+	m := &Matcher{
+		query:  strings.ToLower(q.Query),
+		host:   strings.ToLower(q.Host),
+		status: q.Status,
+	}
+
+	if q.Pattern != "" {
+		if len(q.Pattern) > maxSearchPatternLength {
+			return nil, fmt.Errorf("%w: pattern: longer than %d characters", models.ErrInvalidSearch, maxSearchPatternLength)
+		}
+		m.glob = regexp.MustCompile("(?is)^" + globToRegexp(q.Pattern) + "$")
+	}
+
+	if q.Regex != "" {
+		re, err := compileRegex(q.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("%w: regex: %w", models.ErrInvalidSearch, err)
+		}
+		m.regex = re
+	}
+
+	return m, nil
+}
+
+// Match reports whether link satisfies every condition of the query.
+func (m *Matcher) Match(link models.Link) bool {
+	if m.status != "" && link.Status != m.status {
+		return false
+	}
+	if m.query != "" && !strings.Contains(strings.ToLower(link.URL), m.query) {
+		return false
+	}
+	if m.glob != nil && !m.glob.MatchString(link.URL) {
+		return false
+	}
+	if m.regex != nil && !m.regex.MatchString(link.URL) {
+		return false
+	}
+	if m.host != "" && !urlchecker.MatchHost([]string{m.host}, urlchecker.Host(link.URL)) {
+		return false
+	}
+	return true
+}
+
+// globToRegexp translates a glob where * matches any run of characters and ? a single one.
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	for _, r := range glob {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	return b.String()
+}
+
+// compileRegex compiles a RE2 expression, rejecting ones too long or compiling to a large
+// program, e.g. through nested repetition counts.
+func compileRegex(expr string) (*regexp.Regexp, error) {
+	if len(expr) > maxSearchPatternLength {
+		return nil, fmt.Errorf("longer than %d characters", maxSearchPatternLength)
+	}
+
+	parsed, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+	prog, err := syntax.Compile(parsed.Simplify())
+	if err != nil {
+		return nil, err
+	}
+	if len(prog.Inst) > maxRegexProgramSize {
+		return nil, fmt.Errorf("too complex")
+	}
+
+	return regexp.Compile(expr)
+}
@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestNewMatcher(t *testing.T) {
+	available := models.Link{URL: "https://docs.example.com/guide/start", Status: models.LinkStatusAvailable}
+	broken := models.Link{URL: "https://example.com/missing.pdf", Status: models.LinkStatusNotAvailable}
+	other := models.Link{URL: "https://github.com/repo", Status: models.LinkStatusAvailable}
+
+	tests := []struct {
+		name  string
+		query models.SearchQuery
+		want  []bool
+	}{
+		{name: "substring", query: models.SearchQuery{Query: "EXAMPLE"}, want: []bool{true, true, false}},
+		{name: "glob star", query: models.SearchQuery{Pattern: "*.pdf"}, want: []bool{false, true, false}},
+		{name: "glob is anchored", query: models.SearchQuery{Pattern: "*/guide"}, want: []bool{false, false, false}},
+		{name: "glob question mark", query: models.SearchQuery{Pattern: "https://github.co?/*"}, want: []bool{false, false, true}},
+		{name: "regex", query: models.SearchQuery{Regex: `/guide/\w+$`}, want: []bool{true, false, false}},
+		{name: "host", query: models.SearchQuery{Host: "EXAMPLE.com"}, want: []bool{false, true, false}},
+		{name: "host wildcard", query: models.SearchQuery{Host: "*.example.com"}, want: []bool{true, false, false}},
+		{name: "status", query: models.SearchQuery{Status: models.LinkStatusNotAvailable}, want: []bool{false, true, false}},
+		{name: "conditions combine with AND", query: models.SearchQuery{Pattern: "*example*", Status: models.LinkStatusAvailable}, want: []bool{true, false, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matcher, err := NewMatcher(tt.query)
+			if err != nil {
+				t.Fatalf("NewMatcher() error = %v, want nil", err)
+			}
+			for i, link := range []models.Link{available, broken, other} {
+				if got := matcher.Match(link); got != tt.want[i] {
+					t.Errorf("Match(%s) = %v, want %v", link.URL, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNewMatcher_InvalidPatterns(t *testing.T) {
+	tests := []struct {
+		name  string
+		query models.SearchQuery
+	}{
+		{name: "malformed regex", query: models.SearchQuery{Regex: "(unclosed"}},
+		{name: "too complex regex", query: models.SearchQuery{Regex: "(a{100}){100}"}},
+		{name: "too long regex", query: models.SearchQuery{Regex: strings.Repeat("a", maxSearchPatternLength+1)}},
+		{name: "too long glob", query: models.SearchQuery{Pattern: strings.Repeat("*", maxSearchPatternLength+1)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewMatcher(tt.query); !errors.Is(err, models.ErrInvalidSearch) {
+				t.Errorf("NewMatcher() error = %v, want ErrInvalidSearch", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,716 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	// registers the "sqlite3" database/sql driver
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+	"github.com/polonkoevv/linkchecker/internal/storage"
+)
+
+// schema creates the tables on first start. Links keep the queried fields in
+// indexed columns and the complete check result as JSON in data.
+const schema = `
+CREATE TABLE IF NOT EXISTS link_groups (
+	num  INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS links (
+	group_num   INTEGER NOT NULL REFERENCES link_groups (num),
+	position    INTEGER NOT NULL,
+	url         TEXT    NOT NULL,
+	status      TEXT    NOT NULL,
+	status_code INTEGER NOT NULL DEFAULT 0,
+	error       TEXT    NOT NULL DEFAULT '',
+	checked_at  INTEGER NOT NULL,
+	duration    INTEGER NOT NULL,
+	data        TEXT    NOT NULL,
+	PRIMARY KEY (group_num, position)
+);
+
+CREATE INDEX IF NOT EXISTS links_url_idx ON links (url, group_num);
+CREATE INDEX IF NOT EXISTS links_checked_at_idx ON links (checked_at);
+CREATE INDEX IF NOT EXISTS links_status_idx ON links (status, checked_at);
+`
+
+// busyTimeout is how long a statement waits for a lock held by another process.
+const busyTimeout = 5 * time.Second
+
+// memoryPath opens a database that is not backed by a file.
+const memoryPath = ":memory:"
+
+// latestBatchSize bounds the number of values bound into one LatestByURLs or Expire query.
+const latestBatchSize = 500
+
+// Storage implements a link repository backed by a SQLite database file.
+type Storage struct {
+	db *sql.DB
+}
+
+// New opens the SQLite database at path, creating it and its schema if needed.
+// The ":memory:" path opens a database that lives only as long as the Storage.
+func New(path string) (*Storage, error) {
+	if path != memoryPath {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, fmt.Errorf("create storage dir: %w", err)
+		}
+	}
+
+	dsn := fmt.Sprintf("file:%s?_busy_timeout=%d&_foreign_keys=on", path, busyTimeout.Milliseconds())
+
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite database: %w", err)
+	}
+	// SQLite allows a single writer, serializing access avoids "database is locked" errors
+	// and keeps an in-memory database on one connection.
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(schema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("create sqlite schema: %w", err)
+	}
+
+	return &Storage{db: db}, nil
+}
+
+// Close closes the database.
+func (s *Storage) Close() error {
+	return s.db.Close()
+}
+
+// InsertMany stores a batch of links and returns its group number.
+func (s *Storage) InsertMany(links []models.Link) (int, error) {
+	return s.InsertNamed("", links)
+}
+
+// InsertNamed stores a batch of links labeled with name and returns its group number.
+// An empty name stores an unnamed group. Links with empty URLs are dropped, storing fails if none are left.
+func (s *Storage) InsertNamed(name string, links []models.Link) (int, error) {
+	links, err := storage.DropEmptyURLs(links)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("begin insert: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	num, err := insertGroup(tx, name, links)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit insert: %w", err)
+	}
+
+	slog.Debug("inserted links batch",
+		slog.Int("links_num", num),
+		slog.String("name", name),
+		slog.Int("links_count", len(links)),
+	)
+
+	return num, nil
+}
+
+// Replace replaces the links of stored group num in one transaction, keeping its number and name.
+// It fails with a *models.GroupNotFoundError if the group does not exist. Links with empty URLs
+// are dropped, replacing fails if none are left.
+func (s *Storage) Replace(num int, links []models.Link) error {
+	links, err := storage.DropEmptyURLs(links)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin replace: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.Exec(`DELETE FROM links WHERE group_num = ?`, num)
+	if err != nil {
+		return fmt.Errorf("delete links of group %d: %w", num, err)
+	}
+	if deleted, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("delete links of group %d: %w", num, err)
+	} else if deleted == 0 {
+		return &models.GroupNotFoundError{Nums: []int{num}}
+	}
+
+	if err := insertLinks(tx, num, links); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit replace: %w", err)
+	}
+
+	slog.Debug("replaced links batch",
+		slog.Int("links_num", num),
+		slog.Int("links_count", len(links)),
+	)
+
+	return nil
+}
+
+// SetIgnored marks the links of group num with the given URL as ignored or not.
+// It fails with a *models.GroupNotFoundError if the group does not exist and with
+// models.ErrLinkNotFound if it has no link with that URL.
+func (s *Storage) SetIgnored(num int, url string, ignored bool) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin set ignored: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var exists bool
+	if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM link_groups WHERE num = ?)`, num).Scan(&exists); err != nil {
+		return fmt.Errorf("look up group %d: %w", num, err)
+	}
+	if !exists {
+		return &models.GroupNotFoundError{Nums: []int{num}}
+	}
+
+	rows, err := tx.Query(`SELECT position, data FROM links WHERE group_num = ? AND url = ?`, num, url)
+	if err != nil {
+		return fmt.Errorf("load links of group %d: %w", num, err)
+	}
+	updated := make(map[int][]byte)
+	for rows.Next() {
+		var (
+			position int
+			data     []byte
+			link     models.Link
+		)
+		if err := rows.Scan(&position, &data); err != nil {
+			rows.Close()
+			return fmt.Errorf("scan link: %w", err)
+		}
+		if err := json.Unmarshal(data, &link); err != nil {
+			rows.Close()
+			return fmt.Errorf("decode link: %w", err)
+		}
+		link.Ignored = ignored
+		if updated[position], err = json.Marshal(link); err != nil {
+			rows.Close()
+			return fmt.Errorf("encode link %s: %w", url, err)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("load links of group %d: %w", num, err)
+	}
+	if len(updated) == 0 {
+		return fmt.Errorf("%w: %s", models.ErrLinkNotFound, url)
+	}
+
+	for position, data := range updated {
+		if _, err := tx.Exec(`UPDATE links SET data = ? WHERE group_num = ? AND position = ?`, data, num, position); err != nil {
+			return fmt.Errorf("update link %s: %w", url, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit set ignored: %w", err)
+	}
+
+	slog.Debug("set links ignored",
+		slog.Int("links_num", num),
+		slog.String("url", url),
+		slog.Bool("ignored", ignored),
+	)
+
+	return nil
+}
+
+// Import stores groups from a snapshot in one transaction and returns the numbers they are
+// stored under, in order. ImportModeMerge inserts the groups under fresh numbers from the
+// group counter, so stored groups are never overwritten. ImportModeReplace deletes all stored
+// groups first and keeps the imported numbers. A failed import leaves storage unchanged.
+func (s *Storage) Import(groups []models.Links, mode models.ImportMode) ([]int, error) {
+	for _, g := range groups {
+		if len(g.Links) == 0 {
+			return nil, fmt.Errorf("import group %d: empty links slice", g.LinksNum)
+		}
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("begin import: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	nums := make([]int, 0, len(groups))
+	switch mode {
+	case models.ImportModeReplace:
+		if _, err := tx.Exec(`DELETE FROM links`); err != nil {
+			return nil, fmt.Errorf("delete links: %w", err)
+		}
+		if _, err := tx.Exec(`DELETE FROM link_groups`); err != nil {
+			return nil, fmt.Errorf("delete link groups: %w", err)
+		}
+		for _, g := range groups {
+			if _, err := tx.Exec(`INSERT INTO link_groups (num, name) VALUES (?, ?)`, g.LinksNum, g.Name); err != nil {
+				return nil, fmt.Errorf("insert link group %d: %w", g.LinksNum, err)
+			}
+			if err := insertLinks(tx, g.LinksNum, g.Links); err != nil {
+				return nil, err
+			}
+			nums = append(nums, g.LinksNum)
+		}
+	case models.ImportModeMerge:
+		for _, g := range groups {
+			num, err := insertGroup(tx, g.Name, g.Links)
+			if err != nil {
+				return nil, err
+			}
+			nums = append(nums, num)
+		}
+	default:
+		return nil, fmt.Errorf("unknown import mode: %s", mode)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit import: %w", err)
+	}
+
+	slog.Debug("imported links groups",
+		slog.String("mode", string(mode)),
+		slog.Any("links_nums", nums),
+	)
+
+	return nums, nil
+}
+
+// Expire deletes groups whose links were all checked before the given time in one
+// transaction and returns their numbers in ascending order.
+func (s *Storage) Expire(before time.Time) ([]int, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("begin expire: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.Query(`SELECT group_num FROM links
+		GROUP BY group_num HAVING MAX(checked_at) < ? ORDER BY group_num`, unixNano(before))
+	if err != nil {
+		return nil, fmt.Errorf("select expired groups: %w", err)
+	}
+	expired := []int{}
+	for rows.Next() {
+		var num int
+		if err := rows.Scan(&num); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan expired group: %w", err)
+		}
+		expired = append(expired, num)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("select expired groups: %w", err)
+	}
+
+	if len(expired) == 0 {
+		return expired, nil
+	}
+
+	for start := 0; start < len(expired); start += latestBatchSize {
+		batch := expired[start:min(start+latestBatchSize, len(expired))]
+		args := make([]any, len(batch))
+		for i, num := range batch {
+			args[i] = num
+		}
+		in := placeholders(len(batch))
+		if _, err := tx.Exec(`DELETE FROM links WHERE group_num IN (`+in+`)`, args...); err != nil {
+			return nil, fmt.Errorf("delete expired links: %w", err)
+		}
+		if _, err := tx.Exec(`DELETE FROM link_groups WHERE num IN (`+in+`)`, args...); err != nil {
+			return nil, fmt.Errorf("delete expired link groups: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit expire: %w", err)
+	}
+
+	slog.Debug("expired link groups",
+		slog.Time("before", before),
+		slog.Any("expired_nums", expired),
+	)
+
+	return expired, nil
+}
+
+// insertGroup stores links as a new group labeled with name within tx and returns its number.
+func insertGroup(tx *sql.Tx, name string, links []models.Link) (int, error) {
+	res, err := tx.Exec(`INSERT INTO link_groups (name) VALUES (?)`, name)
+	if err != nil {
+		return 0, fmt.Errorf("insert link group: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("insert link group: %w", err)
+	}
+	num := int(id)
+
+	if err := insertLinks(tx, num, links); err != nil {
+		return 0, err
+	}
+
+	return num, nil
+}
+
+// insertLinks stores links of group num in order within tx.
+func insertLinks(tx *sql.Tx, num int, links []models.Link) error {
+	stmt, err := tx.Prepare(`INSERT INTO links
+		(group_num, position, url, status, status_code, error, checked_at, duration, data)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("prepare link insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for i, link := range links {
+		link.GroupNum = num
+		data, err := json.Marshal(link)
+		if err != nil {
+			return fmt.Errorf("encode link %s: %w", link.URL, err)
+		}
+		if _, err := stmt.Exec(num, i, link.URL, string(link.Status), link.StatusCode, link.Error,
+			unixNano(link.CheckedAt), int64(link.Duration), data); err != nil {
+			return fmt.Errorf("insert link %s: %w", link.URL, err)
+		}
+	}
+
+	return nil
+}
+
+// GetByNums returns stored link groups for the given group numbers in the requested order.
+// Returns found groups and logs warnings for missing ones.
+func (s *Storage) GetByNums(linksNum []int) ([]models.Links, error) {
+	if len(linksNum) == 0 {
+		return []models.Links{}, nil
+	}
+
+	args := make([]any, len(linksNum))
+	for i, num := range linksNum {
+		args[i] = num
+	}
+	found, err := s.groups("g.num IN ("+placeholders(len(args))+")", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	byNum := make(map[int]models.Links, len(found))
+	for _, g := range found {
+		byNum[g.LinksNum] = g
+	}
+
+	res := make([]models.Links, 0, len(linksNum))
+	missing := make([]int, 0)
+	for _, num := range linksNum {
+		g, ok := byNum[num]
+		if !ok {
+			missing = append(missing, num)
+			slog.Warn("requested links_num not found", slog.Int("links_num", num))
+			continue
+		}
+		res = append(res, g)
+	}
+
+	if len(missing) > 0 {
+		slog.Warn("some link groups were not found",
+			slog.Int("missing_count", len(missing)),
+			slog.Any("missing_nums", missing),
+			slog.Int("found_count", len(res)),
+		)
+		return nil, &models.GroupNotFoundError{Nums: missing}
+	}
+
+	return res, nil
+}
+
+// GetByNum returns the stored link group with the given number.
+// It fails with a *models.GroupNotFoundError if the group does not exist.
+func (s *Storage) GetByNum(num int) (models.Links, error) {
+	found, err := s.groups("g.num = ?", num)
+	if err != nil {
+		return models.Links{}, err
+	}
+	if len(found) == 0 {
+		return models.Links{}, &models.GroupNotFoundError{Nums: []int{num}}
+	}
+
+	return found[0], nil
+}
+
+// GetAll returns all stored link groups ordered by group number.
+func (s *Storage) GetAll() ([]models.Links, error) {
+	res, err := s.groups("")
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("loaded all links groups", slog.Int("groups_count", len(res)))
+
+	return res, nil
+}
+
+// Summaries returns link counts and check time spans of all stored groups ordered by number,
+// aggregated from the indexed columns and the ignored flag without decoding links.
+// AvailabilityPercent is left for the caller to compute.
+func (s *Storage) Summaries() ([]models.GroupSummary, error) {
+	rows, err := s.db.Query(`SELECT g.num, g.name, COUNT(*), SUM(l.status = ? AND NOT l.ignored), SUM(l.ignored),
+			MIN(l.checked_at), MAX(l.checked_at)
+		FROM link_groups AS g
+		JOIN (SELECT *, COALESCE(json_extract(data, '$.ignored'), 0) AS ignored FROM links) AS l ON l.group_num = g.num
+		GROUP BY g.num ORDER BY g.num`, models.LinkStatusAvailable)
+	if err != nil {
+		return nil, fmt.Errorf("load group summaries: %w", err)
+	}
+	defer rows.Close()
+
+	res := []models.GroupSummary{}
+	for rows.Next() {
+		var (
+			summary     models.GroupSummary
+			first, last int64
+		)
+		if err := rows.Scan(&summary.LinksNum, &summary.Name, &summary.Total, &summary.Available, &summary.Ignored, &first, &last); err != nil {
+			return nil, fmt.Errorf("scan group summary: %w", err)
+		}
+		summary.FirstCheckedAt = fromUnixNano(first)
+		summary.LastCheckedAt = fromUnixNano(last)
+		res = append(res, summary)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("load group summaries: %w", err)
+	}
+
+	slog.Debug("loaded link group summaries", slog.Int("groups_count", len(res)))
+
+	return res, nil
+}
+
+// GetBetween returns groups ordered by number that have at least one link checked
+// within [from, to]. A zero from or to leaves that side of the range open.
+func (s *Storage) GetBetween(from, to time.Time) ([]models.Links, error) {
+	conds := make([]string, 0, 2)
+	args := make([]any, 0, 2)
+	if !from.IsZero() {
+		conds = append(conds, "checked_at >= ?")
+		args = append(args, unixNano(from))
+	}
+	if !to.IsZero() {
+		conds = append(conds, "checked_at <= ?")
+		args = append(args, unixNano(to))
+	}
+
+	where := ""
+	if len(conds) > 0 {
+		where = "g.num IN (SELECT group_num FROM links WHERE " + strings.Join(conds, " AND ") + ")"
+	}
+
+	res, err := s.groups(where, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("loaded links groups by check time",
+		slog.Time("from", from),
+		slog.Time("to", to),
+		slog.Int("groups_count", len(res)),
+	)
+
+	return res, nil
+}
+
+// Search returns stored links matching every condition of q, the substring is matched
+// case-insensitively for ASCII letters only. Links are ordered by group number and keep their order within a group.
+func (s *Storage) Search(q models.SearchQuery) ([]models.Link, error) {
+	matcher, err := storage.NewMatcher(q)
+	if err != nil {
+		return nil, err
+	}
+
+	// The substring and status narrow rows in SQL, patterns and host are matched on the decoded links.
+	conds := []string{"1 = 1"}
+	args := make([]any, 0, 2)
+	if q.Query != "" {
+		conds = append(conds, `url LIKE ? ESCAPE '\'`)
+		args = append(args, "%"+escapeLike(q.Query)+"%")
+	}
+	if q.Status != "" {
+		conds = append(conds, "status = ?")
+		args = append(args, q.Status)
+	}
+
+	rows, err := s.db.Query(`SELECT group_num, data FROM links
+		WHERE `+strings.Join(conds, " AND ")+` ORDER BY group_num, position`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("search links: %w", err)
+	}
+	defer rows.Close()
+
+	res := []models.Link{}
+	for rows.Next() {
+		link, err := scanLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		if matcher.Match(link) {
+			res = append(res, link)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("search links: %w", err)
+	}
+
+	slog.Debug("searched links",
+		slog.Any("query", q),
+		slog.Int("matches", len(res)),
+	)
+
+	return res, nil
+}
+
+// LatestByURLs returns the most recently stored check (highest group number) of each given URL.
+// URLs that were never checked are absent from the result.
+func (s *Storage) LatestByURLs(urls []string) (map[string]models.Link, error) {
+	res := make(map[string]models.Link)
+
+	for start := 0; start < len(urls); start += latestBatchSize {
+		batch := urls[start:min(start+latestBatchSize, len(urls))]
+		args := make([]any, len(batch))
+		for i, url := range batch {
+			args[i] = url
+		}
+
+		rows, err := s.db.Query(`SELECT group_num, data FROM links AS l
+			WHERE url IN (`+placeholders(len(args))+`)
+			AND group_num = (SELECT MAX(group_num) FROM links WHERE url = l.url)
+			ORDER BY position`, args...)
+		if err != nil {
+			return nil, fmt.Errorf("load latest links: %w", err)
+		}
+
+		for rows.Next() {
+			link, err := scanLink(rows)
+			if err != nil {
+				rows.Close()
+				return nil, err
+			}
+			// a URL listed twice in a group keeps its first check, as in-memory storage does
+			if _, ok := res[link.URL]; !ok {
+				res[link.URL] = link
+			}
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("load latest links: %w", err)
+		}
+	}
+
+	return res, nil
+}
+
+// groups loads link groups matching where (a condition on link_groups aliased as g,
+// empty for all groups) ordered by group number.
+func (s *Storage) groups(where string, args ...any) ([]models.Links, error) {
+	query := `SELECT g.num, g.name, l.group_num, l.data FROM link_groups AS g
+		JOIN links AS l ON l.group_num = g.num`
+	if where != "" {
+		query += " WHERE " + where
+	}
+	query += " ORDER BY g.num, l.position"
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("load link groups: %w", err)
+	}
+	defer rows.Close()
+
+	res := []models.Links{}
+	for rows.Next() {
+		var (
+			num  int
+			name string
+		)
+		link, err := scanLink(rows, &num, &name)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(res) == 0 || res[len(res)-1].LinksNum != num {
+			res = append(res, models.Links{LinksNum: num, Name: name})
+		}
+		last := &res[len(res)-1]
+		last.Links = append(last.Links, link)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("load link groups: %w", err)
+	}
+
+	return res, nil
+}
+
+// scanLink scans a row ending with group_num and data columns into a link,
+// scanning any preceding columns into dest.
+func scanLink(rows *sql.Rows, dest ...any) (models.Link, error) {
+	var (
+		groupNum int
+		data     []byte
+	)
+	if err := rows.Scan(append(dest, &groupNum, &data)...); err != nil {
+		return models.Link{}, fmt.Errorf("scan link: %w", err)
+	}
+
+	var link models.Link
+	if err := json.Unmarshal(data, &link); err != nil {
+		return models.Link{}, fmt.Errorf("decode link: %w", err)
+	}
+	link.GroupNum = groupNum
+
+	return link, nil
+}
+
+// placeholders returns n comma-separated query parameter placeholders.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
+
+// escapeLike escapes LIKE wildcards in s for use with ESCAPE '\'.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// unixNano converts t to nanoseconds since the epoch, a zero time is stored as 0.
+func unixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+// fromUnixNano converts nanoseconds stored by unixNano back to a UTC time, 0 to the zero time.
+func fromUnixNano(n int64) time.Time {
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n).UTC()
+}
@@ -0,0 +1,49 @@
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestStorage_Expire(t *testing.T) {
+	base := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	linkAt := func(url string, at time.Time) models.Link {
+		link := createTestLink(url, models.LinkStatusAvailable)
+		link.CheckedAt = at
+		return link
+	}
+
+	storage := newTestStorage(t)
+	_, _ = storage.InsertMany([]models.Link{linkAt("https://a.com", base)})
+	_, _ = storage.InsertMany([]models.Link{
+		linkAt("https://b.com", base),
+		linkAt("https://c.com", base.Add(72*time.Hour)),
+	})
+	_, _ = storage.InsertMany([]models.Link{linkAt("https://d.com", base.Add(24*time.Hour))})
+
+	expired, err := storage.Expire(base.Add(48 * time.Hour))
+	if err != nil {
+		t.Fatalf("Expire() error = %v, want nil", err)
+	}
+	if len(expired) != 2 || expired[0] != 1 || expired[1] != 3 {
+		t.Fatalf("Expire() = %v, want [1 3] (group 2 has a recent link)", expired)
+	}
+
+	groups, _ := storage.GetAll()
+	if len(groups) != 1 || groups[0].LinksNum != 2 || len(groups[0].Links) != 2 {
+		t.Fatalf("GetAll() after Expire = %+v, want group 2 with both links", groups)
+	}
+
+	expired, err = storage.Expire(base.Add(48 * time.Hour))
+	if err != nil || len(expired) != 0 {
+		t.Errorf("second Expire() = %v, %v, want no groups", expired, err)
+	}
+
+	num, _ := storage.InsertMany([]models.Link{linkAt("https://e.com", base)})
+	if num != 4 {
+		t.Errorf("InsertMany() after Expire = %d, want 4 (numbers are not reused)", num)
+	}
+}
@@ -0,0 +1,36 @@
+package sqlite
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestStorage_GetByNum(t *testing.T) {
+	storage := newTestStorage(t)
+
+	num, err := storage.InsertNamed("docs", []models.Link{
+		createTestLink("https://example.com", models.LinkStatusAvailable),
+		createTestLink("https://google.com", models.LinkStatusNotAvailable),
+	})
+	if err != nil {
+		t.Fatalf("InsertNamed() error = %v, want nil", err)
+	}
+
+	group, err := storage.GetByNum(num)
+	if err != nil {
+		t.Fatalf("GetByNum() error = %v, want nil", err)
+	}
+	if group.LinksNum != num || group.Name != "docs" || len(group.Links) != 2 {
+		t.Fatalf("GetByNum() = %+v, want group %d named docs with 2 links", group, num)
+	}
+	if group.Links[0].URL != "https://example.com" || group.Links[1].URL != "https://google.com" {
+		t.Errorf("GetByNum() links = %+v, want insertion order", group.Links)
+	}
+
+	_, err = storage.GetByNum(num + 1)
+	if !errors.Is(err, models.ErrGroupNotFound) {
+		t.Errorf("GetByNum() error = %v, want ErrGroupNotFound", err)
+	}
+}
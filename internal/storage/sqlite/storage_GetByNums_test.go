@@ -0,0 +1,38 @@
+package sqlite
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestStorage_GetByNums(t *testing.T) {
+	storage := newTestStorage(t)
+
+	num1, err := storage.InsertMany([]models.Link{createTestLink("https://example.com", models.LinkStatusAvailable)})
+	if err != nil {
+		t.Fatalf("InsertMany() error = %v, want nil", err)
+	}
+	num2, err := storage.InsertMany([]models.Link{createTestLink("https://google.com", models.LinkStatusAvailable)})
+	if err != nil {
+		t.Fatalf("InsertMany() error = %v, want nil", err)
+	}
+
+	groups, err := storage.GetByNums([]int{num2, num1})
+	if err != nil {
+		t.Fatalf("GetByNums() error = %v, want nil", err)
+	}
+	if len(groups) != 2 || groups[0].LinksNum != num2 || groups[1].LinksNum != num1 {
+		t.Fatalf("GetByNums() = %+v, want groups %d and %d in request order", groups, num2, num1)
+	}
+
+	groups, err = storage.GetByNums([]int{num1, 999, num2})
+	var notFound *models.GroupNotFoundError
+	if !errors.As(err, &notFound) || len(notFound.Nums) != 1 || notFound.Nums[0] != 999 {
+		t.Fatalf("GetByNums() error = %v, want GroupNotFoundError with [999]", err)
+	}
+	if groups != nil {
+		t.Errorf("GetByNums() = %+v, want nil", groups)
+	}
+}
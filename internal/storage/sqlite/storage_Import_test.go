@@ -0,0 +1,91 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestStorage_Import(t *testing.T) {
+	backup := func() []models.Links {
+		return []models.Links{
+			{LinksNum: 1, Name: "backup", Links: []models.Link{
+				createTestLink("https://backup.example.com", models.LinkStatusAvailable),
+				createTestLink("https://google.com", models.LinkStatusNotAvailable),
+			}},
+			{LinksNum: 9, Links: []models.Link{createTestLink("https://go.dev", models.LinkStatusAvailable)}},
+		}
+	}
+
+	t.Run("merge renumbers colliding groups", func(t *testing.T) {
+		storage := newTestStorage(t)
+		_, _ = storage.InsertMany([]models.Link{createTestLink("https://example.com", models.LinkStatusAvailable)})
+
+		nums, err := storage.Import(backup(), models.ImportModeMerge)
+		if err != nil {
+			t.Fatalf("Import() error = %v, want nil", err)
+		}
+		if len(nums) != 2 || nums[0] != 2 || nums[1] != 3 {
+			t.Fatalf("Import() = %v, want [2 3]", nums)
+		}
+
+		groups, err := storage.GetAll()
+		if err != nil {
+			t.Fatalf("GetAll() error = %v, want nil", err)
+		}
+		if len(groups) != 3 || groups[0].Links[0].URL != "https://example.com" {
+			t.Fatalf("GetAll() = %+v, want stored group 1 kept and 2 imported", groups)
+		}
+		if groups[1].Name != "backup" || len(groups[1].Links) != 2 || groups[1].Links[1].GroupNum != 2 {
+			t.Errorf("imported group 2 = %+v, want backup group renumbered to 2", groups[1])
+		}
+	})
+
+	t.Run("replace drops stored groups and keeps numbers", func(t *testing.T) {
+		storage := newTestStorage(t)
+		_, _ = storage.InsertMany([]models.Link{createTestLink("https://old.example.com", models.LinkStatusAvailable)})
+
+		nums, err := storage.Import(backup(), models.ImportModeReplace)
+		if err != nil {
+			t.Fatalf("Import() error = %v, want nil", err)
+		}
+		if len(nums) != 2 || nums[0] != 1 || nums[1] != 9 {
+			t.Fatalf("Import() = %v, want [1 9]", nums)
+		}
+
+		groups, err := storage.GetAll()
+		if err != nil {
+			t.Fatalf("GetAll() error = %v, want nil", err)
+		}
+		if len(groups) != 2 || groups[0].Links[0].URL != "https://backup.example.com" || groups[1].LinksNum != 9 {
+			t.Fatalf("GetAll() = %+v, want only imported groups 1 and 9", groups)
+		}
+
+		next, err := storage.InsertMany([]models.Link{createTestLink("https://new.example.com", models.LinkStatusAvailable)})
+		if err != nil {
+			t.Fatalf("InsertMany() error = %v, want nil", err)
+		}
+		if next <= 9 {
+			t.Errorf("InsertMany() after Import() = %d, want a number above 9", next)
+		}
+	})
+
+	t.Run("failed import leaves storage unchanged", func(t *testing.T) {
+		storage := newTestStorage(t)
+		_, _ = storage.InsertMany([]models.Link{createTestLink("https://example.com", models.LinkStatusAvailable)})
+
+		groups := backup()
+		groups[1].LinksNum = groups[0].LinksNum
+		if _, err := storage.Import(groups, models.ImportModeReplace); err == nil {
+			t.Fatal("Import() error = nil, want error for duplicate group numbers")
+		}
+
+		stored, err := storage.GetAll()
+		if err != nil {
+			t.Fatalf("GetAll() error = %v, want nil", err)
+		}
+		if len(stored) != 1 || stored[0].Links[0].URL != "https://example.com" {
+			t.Errorf("GetAll() = %+v, want storage unchanged", stored)
+		}
+	})
+}
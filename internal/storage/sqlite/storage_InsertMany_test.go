@@ -0,0 +1,46 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestStorage_InsertMany(t *testing.T) {
+	t.Run("links with empty URLs are dropped", func(t *testing.T) {
+		storage := newTestStorage(t)
+		links := []models.Link{
+			createTestLink("https://a.com", models.LinkStatusAvailable),
+			createTestLink(" ", models.LinkStatusNotAvailable),
+			createTestLink("https://b.com", models.LinkStatusAvailable),
+		}
+
+		num, err := storage.InsertMany(links)
+		if err != nil {
+			t.Fatalf("InsertMany() error = %v, want nil", err)
+		}
+
+		group, err := storage.GetByNum(num)
+		if err != nil {
+			t.Fatalf("GetByNum() error = %v, want nil", err)
+		}
+		if len(group.Links) != 2 || group.Links[0].URL != "https://a.com" || group.Links[1].URL != "https://b.com" {
+			t.Errorf("stored links = %+v, want https://a.com and https://b.com", group.Links)
+		}
+	})
+
+	t.Run("batch of empty URLs returns error", func(t *testing.T) {
+		storage := newTestStorage(t)
+		links := []models.Link{
+			createTestLink("", models.LinkStatusNotAvailable),
+		}
+
+		if _, err := storage.InsertMany(links); err == nil {
+			t.Error("InsertMany() error = nil, want error")
+		}
+		groups, _ := storage.GetAll()
+		if len(groups) != 0 {
+			t.Errorf("GetAll() returned %d groups, want 0", len(groups))
+		}
+	})
+}
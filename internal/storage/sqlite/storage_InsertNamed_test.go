@@ -0,0 +1,108 @@
+package sqlite
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func newTestStorage(t *testing.T) *Storage {
+	t.Helper()
+
+	storage, err := New(memoryPath)
+	if err != nil {
+		t.Fatalf("New() error = %v, want nil", err)
+	}
+	t.Cleanup(func() { _ = storage.Close() })
+	return storage
+}
+
+func createTestLink(url string, status models.LinkStatus) models.Link {
+	return models.Link{
+		URL:       url,
+		Status:    status,
+		Duration:  100 * time.Millisecond,
+		CheckedAt: time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestStorage_InsertNamed(t *testing.T) {
+	storage := newTestStorage(t)
+
+	matched := true
+	link := createTestLink("https://example.com", models.LinkStatusNotAvailable)
+	link.StatusCode = 500
+	link.ETag = `"v1"`
+	link.ContentMatched = &matched
+	link.Error = models.LinkErrorUnexpectedStatus
+
+	first, err := storage.InsertNamed("nightly", []models.Link{link, createTestLink("https://google.com", models.LinkStatusAvailable)})
+	if err != nil {
+		t.Fatalf("InsertNamed() error = %v, want nil", err)
+	}
+	second, _ := storage.InsertMany([]models.Link{createTestLink("https://go.dev", models.LinkStatusAvailable)})
+	if first != 1 || second != 2 {
+		t.Fatalf("InsertNamed() group numbers = %d, %d, want 1, 2", first, second)
+	}
+
+	groups, err := storage.GetByNums([]int{2, 1})
+	if err != nil {
+		t.Fatalf("GetByNums() error = %v, want nil", err)
+	}
+	if len(groups) != 2 || groups[0].LinksNum != 2 || groups[1].LinksNum != 1 {
+		t.Fatalf("GetByNums() = %+v, want groups 2 and 1", groups)
+	}
+	if groups[0].Name != "" || groups[1].Name != "nightly" {
+		t.Errorf("GetByNums() names = %q, %q, want \"\", \"nightly\"", groups[0].Name, groups[1].Name)
+	}
+
+	got := groups[1].Links
+	if len(got) != 2 || got[0].URL != "https://example.com" || got[1].URL != "https://google.com" {
+		t.Fatalf("GetByNums() links = %+v, want example.com then google.com", got)
+	}
+	if got[0].StatusCode != 500 || got[0].ETag != `"v1"` || got[0].ContentMatched == nil || !*got[0].ContentMatched ||
+		got[0].Error != models.LinkErrorUnexpectedStatus || got[0].GroupNum != 1 || !got[0].CheckedAt.Equal(link.CheckedAt) {
+		t.Errorf("GetByNums() link = %+v, want stored fields of %+v", got[0], link)
+	}
+
+	if _, err := storage.GetByNums([]int{42}); !errors.Is(err, models.ErrGroupNotFound) {
+		t.Errorf("GetByNums() error = %v, want ErrGroupNotFound", err)
+	}
+	if _, err := storage.InsertNamed("empty", nil); err == nil {
+		t.Error("InsertNamed() error = nil, want error for empty links")
+	}
+}
+
+func TestStorage_New(t *testing.T) {
+	path := t.TempDir() + "/db/links.db"
+
+	storage, err := New(path)
+	if err != nil {
+		t.Fatalf("New() error = %v, want nil", err)
+	}
+	_, _ = storage.InsertNamed("kept", []models.Link{createTestLink("https://example.com", models.LinkStatusAvailable)})
+	if err := storage.Close(); err != nil {
+		t.Fatalf("Close() error = %v, want nil", err)
+	}
+
+	reopened, err := New(path)
+	if err != nil {
+		t.Fatalf("New() reopen error = %v, want nil", err)
+	}
+	defer reopened.Close()
+
+	groups, err := reopened.GetAll()
+	if err != nil {
+		t.Fatalf("GetAll() error = %v, want nil", err)
+	}
+	if len(groups) != 1 || groups[0].Name != "kept" || len(groups[0].Links) != 1 {
+		t.Errorf("GetAll() after reopen = %+v, want the stored group", groups)
+	}
+
+	num, _ := reopened.InsertMany([]models.Link{createTestLink("https://go.dev", models.LinkStatusAvailable)})
+	if num != 2 {
+		t.Errorf("InsertMany() after reopen = %d, want 2", num)
+	}
+}
@@ -0,0 +1,52 @@
+package sqlite
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestStorage_Replace(t *testing.T) {
+	storage := newTestStorage(t)
+	num, _ := storage.InsertNamed("monitor", []models.Link{
+		createTestLink("https://a.com", models.LinkStatusAvailable),
+		createTestLink("https://b.com", models.LinkStatusAvailable),
+	})
+	_, _ = storage.InsertMany([]models.Link{createTestLink("https://c.com", models.LinkStatusAvailable)})
+
+	err := storage.Replace(num, []models.Link{
+		createTestLink("https://b.com", models.LinkStatusNotAvailable),
+		{URL: ""},
+	})
+	if err != nil {
+		t.Fatalf("Replace() error = %v, want nil", err)
+	}
+
+	group, err := storage.GetByNum(num)
+	if err != nil {
+		t.Fatalf("GetByNum() error = %v, want nil", err)
+	}
+	if group.Name != "monitor" {
+		t.Errorf("Name = %q, want %q (kept)", group.Name, "monitor")
+	}
+	if len(group.Links) != 1 || group.Links[0].URL != "https://b.com" ||
+		group.Links[0].Status != models.LinkStatusNotAvailable || group.Links[0].GroupNum != num {
+		t.Errorf("Links = %+v, want only the replaced b.com link", group.Links)
+	}
+
+	groups, _ := storage.GetAll()
+	if len(groups) != 2 {
+		t.Errorf("GetAll() returned %d groups, want 2 (no group added)", len(groups))
+	}
+
+	if err := storage.Replace(99, []models.Link{createTestLink("https://d.com", models.LinkStatusAvailable)}); !errors.Is(err, models.ErrGroupNotFound) {
+		t.Errorf("Replace(99) error = %v, want ErrGroupNotFound", err)
+	}
+	if err := storage.Replace(num, []models.Link{{URL: " "}}); err == nil {
+		t.Error("Replace() with only empty URLs error = nil, want error")
+	}
+	if group, _ := storage.GetByNum(num); len(group.Links) != 1 {
+		t.Errorf("failed Replace() changed the group: %+v", group.Links)
+	}
+}
@@ -0,0 +1,90 @@
+package sqlite
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestStorage_Search(t *testing.T) {
+	storage := newTestStorage(t)
+
+	_, _ = storage.InsertMany([]models.Link{
+		createTestLink("https://Example.com/a_b", models.LinkStatusAvailable),
+		createTestLink("https://google.com", models.LinkStatusAvailable),
+	})
+	_, _ = storage.InsertMany([]models.Link{createTestLink("https://example.com/axb", models.LinkStatusAvailable)})
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{query: "EXAMPLE", want: []string{"https://Example.com/a_b", "https://example.com/axb"}},
+		{query: "a_b", want: []string{"https://Example.com/a_b"}},
+		{query: "%", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			result, err := storage.Search(models.SearchQuery{Query: tt.query})
+
+			if err != nil {
+				t.Fatalf("Search() error = %v, want nil", err)
+			}
+			if len(result) != len(tt.want) {
+				t.Fatalf("Search() returned %d links, want %d", len(result), len(tt.want))
+			}
+			for i, link := range result {
+				if link.URL != tt.want[i] {
+					t.Errorf("Search()[%d] = %s, want %s", i, link.URL, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestStorage_Search_Filters(t *testing.T) {
+	storage := newTestStorage(t)
+
+	_, _ = storage.InsertMany([]models.Link{
+		createTestLink("https://example.com/report.pdf", models.LinkStatusAvailable),
+		createTestLink("https://example.com/old.pdf", models.LinkStatusNotAvailable),
+		createTestLink("https://docs.example.com/v2/guide", models.LinkStatusAvailable),
+	})
+
+	tests := []struct {
+		name  string
+		query models.SearchQuery
+		want  []string
+	}{
+		{name: "pattern", query: models.SearchQuery{Pattern: "*.PDF"}, want: []string{"https://example.com/report.pdf", "https://example.com/old.pdf"}},
+		{name: "pattern and status", query: models.SearchQuery{Pattern: "*.pdf", Status: models.LinkStatusNotAvailable}, want: []string{"https://example.com/old.pdf"}},
+		{name: "regex and host", query: models.SearchQuery{Regex: `/v\d+/`, Host: "docs.example.com"}, want: []string{"https://docs.example.com/v2/guide"}},
+		{name: "query and host", query: models.SearchQuery{Query: "guide", Host: "example.com"}, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := storage.Search(tt.query)
+
+			if err != nil {
+				t.Fatalf("Search() error = %v, want nil", err)
+			}
+			if len(result) != len(tt.want) {
+				t.Fatalf("Search() returned %d links, want %d", len(result), len(tt.want))
+			}
+			for i, link := range result {
+				if link.URL != tt.want[i] {
+					t.Errorf("Search()[%d] = %s, want %s", i, link.URL, tt.want[i])
+				}
+			}
+		})
+	}
+
+	t.Run("invalid regex", func(t *testing.T) {
+		if _, err := storage.Search(models.SearchQuery{Regex: "("}); !errors.Is(err, models.ErrInvalidSearch) {
+			t.Errorf("Search() error = %v, want ErrInvalidSearch", err)
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+// Storage is a repository of checked link groups. Both the in-memory and
+// the SQLite storages implement it.
+type Storage interface {
+	// InsertMany stores a batch of links and returns its group number.
+	InsertMany(links []models.Link) (int, error)
+	// InsertNamed stores a batch of links labeled with name and returns its group number.
+	InsertNamed(name string, links []models.Link) (int, error)
+	// Replace replaces the links of a stored group, keeping its number and name.
+	// It fails with models.ErrGroupNotFound if the group does not exist.
+	Replace(num int, links []models.Link) error
+	// SetIgnored marks the links of group num with the given URL as ignored or not.
+	// It fails with models.ErrGroupNotFound if the group does not exist and with
+	// models.ErrLinkNotFound if it has no link with that URL.
+	SetIgnored(num int, url string, ignored bool) error
+	// GetByNums returns the requested groups, failing with models.ErrGroupNotFound if none exist.
+	GetByNums(linksNum []int) ([]models.Links, error)
+	// GetByNum returns a single group, failing with models.ErrGroupNotFound if it does not exist.
+	GetByNum(num int) (models.Links, error)
+	// GetAll returns all stored groups.
+	GetAll() ([]models.Links, error)
+	// Summaries returns link counts and check time spans of all stored groups ordered by number,
+	// without loading their links.
+	Summaries() ([]models.GroupSummary, error)
+	// GetBetween returns groups with at least one link checked within [from, to], ordered by number.
+	GetBetween(from, to time.Time) ([]models.Links, error)
+	// Search returns stored links matching every condition of q, ordered by group number.
+	// It fails with models.ErrInvalidSearch if a pattern of q is invalid.
+	Search(q models.SearchQuery) ([]models.Link, error)
+	// LatestByURLs returns the most recently stored check of each given URL.
+	LatestByURLs(urls []string) (map[string]models.Link, error)
+	// Import stores groups from a snapshot and returns the numbers they are stored under.
+	// ImportModeMerge renumbers them onto fresh numbers, ImportModeReplace drops stored groups first.
+	Import(groups []models.Links, mode models.ImportMode) ([]int, error)
+	// Expire removes groups whose links were all checked before the given time and
+	// returns their numbers in ascending order.
+	Expire(before time.Time) ([]int, error)
+	// Close releases resources held by the storage.
+	Close() error
+}
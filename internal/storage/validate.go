@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+// DropEmptyURLs returns links without those whose URL is empty or whitespace-only, so such
+// records never reach stored groups, reports and snapshots. links is returned as is when every
+// URL is set. It fails if links is empty or no link is left after filtering.
+func DropEmptyURLs(links []models.Link) ([]models.Link, error) {
+	if len(links) == 0 {
+		return nil, errors.New("empty links slice")
+	}
+
+	empty := 0
+	for _, link := range links {
+		if strings.TrimSpace(link.URL) == "" {
+			empty++
+		}
+	}
+	if empty == 0 {
+		return links, nil
+	}
+	if empty == len(links) {
+		return nil, fmt.Errorf("all %d links have empty URLs", len(links))
+	}
+
+	valid := make([]models.Link, 0, len(links)-empty)
+	for _, link := range links {
+		if strings.TrimSpace(link.URL) != "" {
+			valid = append(valid, link)
+		}
+	}
+
+	slog.Warn("dropped links with empty URLs",
+		slog.Int("dropped", empty),
+		slog.Int("links_count", len(links)),
+	)
+
+	return valid, nil
+}
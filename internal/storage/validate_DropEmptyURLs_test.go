@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestDropEmptyURLs(t *testing.T) {
+	tests := []struct {
+		name    string
+		urls    []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "all set", urls: []string{"https://a.com", "https://b.com"}, want: []string{"https://a.com", "https://b.com"}},
+		{name: "mixed", urls: []string{"", "https://a.com", "  ", "\t\n", "https://b.com"}, want: []string{"https://a.com", "https://b.com"}},
+		{name: "all empty", urls: []string{"", " "}, wantErr: true},
+		{name: "no links", urls: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			links := make([]models.Link, len(tt.urls))
+			for i, url := range tt.urls {
+				links[i] = models.Link{URL: url, Status: models.LinkStatusAvailable}
+			}
+
+			got, err := DropEmptyURLs(links)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("DropEmptyURLs() = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DropEmptyURLs() error = %v, want nil", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("DropEmptyURLs() returned %d links, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if got[i].URL != tt.want[i] {
+					t.Errorf("DropEmptyURLs()[%d].URL = %q, want %q", i, got[i].URL, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package urlchecker
+
+import "net/http"
+
+// HostCredentials are basic auth credentials sent to hosts matching Host, a glob pattern
+// like MatchHost takes (e.g. "intranet.example.com", "*.corp.local").
+type HostCredentials struct {
+	Host     string
+	Username string
+	Password string
+}
+
+// WithBasicAuth sends basic auth credentials to matching hosts: every request of a check,
+// including redirects, content and meta refresh fetches, gets the Authorization header of the
+// first entry matching its host. Requests to other hosts are sent without credentials.
+func WithBasicAuth(creds []HostCredentials) Option {
+	return func(c *Checker) {
+		c.basicAuth = creds
+	}
+}
+
+// setBasicAuth sets the Authorization header of req if credentials are configured for its host.
+func (c *Checker) setBasicAuth(req *http.Request) {
+	host := req.URL.Hostname()
+	for _, cred := range c.basicAuth {
+		if MatchHost([]string{cred.Host}, host) {
+			req.SetBasicAuth(cred.Username, cred.Password)
+			return
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package urlchecker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+// defaultMaxBodySize limits how much of a page body is read unless set with WithMaxBodySize.
+const defaultMaxBodySize = 1 << 20 // 1 MB
+
+// contentMatchContextKey is the context key for the content a checked page must contain.
+type contentMatchContextKey struct{}
+
+// ContextWithContentMatch returns a copy of ctx requiring available pages to match m.
+// A GET check matches its own response body, other methods fetch the body with an extra
+// GET request, so content matching is opt-in.
+func ContextWithContentMatch(ctx context.Context, m models.ContentMatch) context.Context {
+	return context.WithValue(ctx, contentMatchContextKey{}, m)
+}
+
+// contentMatchFromContext returns the content match stored by ContextWithContentMatch.
+func contentMatchFromContext(ctx context.Context) (models.ContentMatch, bool) {
+	m, ok := ctx.Value(contentMatchContextKey{}).(models.ContentMatch)
+	if !ok || m.IsZero() {
+		return models.ContentMatch{}, false
+	}
+	return m, true
+}
+
+// matchContent matches the first maxBodySize bytes of the page body of check response resp.
+// A GET response is read as is; HEAD and other responses without the page body, like 304 Not
+// Modified, fetch the page with an extra GET. It also reports whether the body got truncated.
+func (c *Checker) matchContent(ctx context.Context, resp *http.Response, m models.ContentMatch) (matched, truncated bool, err error) {
+	if resp.Request.Method != http.MethodGet || resp.StatusCode == http.StatusNotModified {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, resp.Request.URL.String(), http.NoBody)
+		if err != nil {
+			return false, false, fmt.Errorf("create request: %w", err)
+		}
+		req.Header.Set("User-Agent", c.userAgent)
+		req.Header.Set("Accept", c.acceptFor(ctx))
+		c.setBasicAuth(req)
+
+		resp, err = c.client.Do(req)
+		if err != nil {
+			return false, false, fmt.Errorf("fetch page: %w", err)
+		}
+		defer resp.Body.Close()
+	}
+
+	// one byte over the limit tells a truncated body from one of exactly the limit
+	body, err := io.ReadAll(io.LimitReader(resp.Body, c.maxBodySize+1))
+	if err != nil {
+		return false, false, fmt.Errorf("read body: %w", err)
+	}
+	if int64(len(body)) > c.maxBodySize {
+		body, truncated = body[:c.maxBodySize], true
+	}
+
+	return m.Match(body), truncated, nil
+}
@@ -0,0 +1,90 @@
+package urlchecker
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+// corsContextKey is the context key for the CORS preflight a URL is checked with.
+type corsContextKey struct{}
+
+// ContextWithCORSPreflight returns a copy of ctx checking URLs with a CORS preflight:
+// an OPTIONS request with Origin and Access-Control-Request-* headers built from p,
+// whose Access-Control-Allow-* response headers are recorded in Link.CORS.
+func ContextWithCORSPreflight(ctx context.Context, p models.CORSPreflight) context.Context {
+	return context.WithValue(ctx, corsContextKey{}, p)
+}
+
+// corsPreflightFromContext returns the preflight stored by ContextWithCORSPreflight.
+func corsPreflightFromContext(ctx context.Context) (models.CORSPreflight, bool) {
+	p, ok := ctx.Value(corsContextKey{}).(models.CORSPreflight)
+	if !ok || p.IsZero() {
+		return models.CORSPreflight{}, false
+	}
+	return p, true
+}
+
+// setCORSHeaders adds the preflight request headers of the preflight stored in ctx, if any.
+func setCORSHeaders(ctx context.Context, req *http.Request) {
+	p, ok := corsPreflightFromContext(ctx)
+	if !ok {
+		return
+	}
+	req.Header.Set("Origin", p.Origin)
+	req.Header.Set("Access-Control-Request-Method", p.Method)
+	if len(p.Headers) > 0 {
+		req.Header.Set("Access-Control-Request-Headers", strings.ToLower(strings.Join(p.Headers, ",")))
+	}
+}
+
+// corsSafelistedMethods need no Access-Control-Allow-Methods entry to be allowed.
+var corsSafelistedMethods = []string{http.MethodGet, http.MethodHead, http.MethodPost}
+
+// checkCORS evaluates the preflight response headers against p.
+// The "*" wildcard is honored only without Access-Control-Allow-Credentials, as browsers do.
+func checkCORS(p models.CORSPreflight, header http.Header) *models.CORSResult {
+	res := &models.CORSResult{
+		AllowOrigin:      header.Get("Access-Control-Allow-Origin"),
+		AllowMethods:     strings.Join(header.Values("Access-Control-Allow-Methods"), ", "),
+		AllowHeaders:     strings.Join(header.Values("Access-Control-Allow-Headers"), ", "),
+		AllowCredentials: header.Get("Access-Control-Allow-Credentials") == "true",
+		MaxAge:           header.Get("Access-Control-Max-Age"),
+	}
+	wildcard := !res.AllowCredentials
+
+	res.OriginAllowed = res.AllowOrigin == p.Origin || (wildcard && res.AllowOrigin == "*")
+
+	allowedMethods := splitHeaderList(res.AllowMethods)
+	res.MethodAllowed = slices.Contains(corsSafelistedMethods, p.Method) ||
+		slices.Contains(allowedMethods, p.Method) ||
+		(wildcard && slices.Contains(allowedMethods, "*"))
+
+	allowedHeaders := splitHeaderList(strings.ToLower(res.AllowHeaders))
+	res.HeadersAllowed = true
+	if !wildcard || !slices.Contains(allowedHeaders, "*") {
+		for _, h := range p.Headers {
+			if !slices.Contains(allowedHeaders, strings.ToLower(h)) {
+				res.HeadersAllowed = false
+				break
+			}
+		}
+	}
+
+	return res
+}
+
+// splitHeaderList splits a comma-separated header value into trimmed non-empty items.
+func splitHeaderList(value string) []string {
+	items := strings.Split(value, ",")
+	res := make([]string, 0, len(items))
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			res = append(res, item)
+		}
+	}
+	return res
+}
@@ -0,0 +1,154 @@
+package urlchecker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// maxMetaRefreshBodySize limits how much of an HTML page is read to find a meta-refresh tag.
+const maxMetaRefreshBodySize = 256 << 10 // 256 KB
+
+// isHTMLResponse reports whether the response declares an HTML content type.
+func isHTMLResponse(resp *http.Response) bool {
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	return mediaType == "text/html" || mediaType == "application/xhtml+xml"
+}
+
+// checkMetaRefresh downloads the HTML page at pageURL and, if it contains a
+// <meta http-equiv="refresh"> tag, checks its target one hop deep.
+// Returns the target URL and its status; ok is false when no meta-refresh was found.
+func (c *Checker) checkMetaRefresh(ctx context.Context, pageURL *url.URL) (target string, available, ok bool) {
+	refresh, err := c.findMetaRefresh(ctx, pageURL.String())
+	if err != nil {
+		slog.DebugContext(ctx, "failed to look up meta-refresh",
+			slog.String("url", pageURL.String()),
+			slog.Any("error", err),
+		)
+		return "", false, false
+	}
+	if refresh == "" {
+		return "", false, false
+	}
+
+	ref, err := url.Parse(refresh)
+	if err != nil {
+		slog.DebugContext(ctx, "invalid meta-refresh target",
+			slog.String("url", pageURL.String()),
+			slog.String("target", refresh),
+		)
+		return refresh, false, true
+	}
+	targetURL := pageURL.ResolveReference(ref)
+	target = targetURL.String()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, target, http.NoBody)
+	if err != nil {
+		return target, false, true
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("Accept", c.acceptFor(ctx))
+	c.setBasicAuth(req)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		slog.DebugContext(ctx, "meta-refresh target request failed",
+			slog.String("target", target),
+			slog.Any("error", err),
+		)
+		return target, false, true
+	}
+	defer resp.Body.Close()
+
+	return target, c.isAvailableStatus(resp.StatusCode), true
+}
+
+// findMetaRefresh fetches the page with GET and returns the raw meta-refresh URL, if any.
+func (c *Checker) findMetaRefresh(ctx context.Context, pageURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, http.NoBody)
+	if err != nil {
+		return "", fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("Accept", "text/html, */*")
+	c.setBasicAuth(req)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fetch page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if !isHTMLResponse(resp) {
+		return "", nil
+	}
+
+	return parseMetaRefresh(io.LimitReader(resp.Body, min(c.maxBodySize, maxMetaRefreshBodySize))), nil
+}
+
+// parseMetaRefresh scans HTML tokens until </head> or <body> and returns the URL
+// from the first <meta http-equiv="refresh" content="N; url=..."> tag.
+func parseMetaRefresh(r io.Reader) string {
+	z := html.NewTokenizer(r)
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return ""
+		case html.EndTagToken:
+			if name, _ := z.TagName(); string(name) == "head" {
+				return ""
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			if string(name) == "body" {
+				return ""
+			}
+			if string(name) != "meta" || !hasAttr {
+				continue
+			}
+
+			var httpEquiv, content string
+			for {
+				key, val, more := z.TagAttr()
+				switch strings.ToLower(string(key)) {
+				case "http-equiv":
+					httpEquiv = string(val)
+				case "content":
+					content = string(val)
+				}
+				if !more {
+					break
+				}
+			}
+
+			if strings.EqualFold(httpEquiv, "refresh") {
+				return refreshURL(content)
+			}
+		}
+	}
+}
+
+// refreshURL extracts the URL part from a meta-refresh content value like "0; url=/next".
+func refreshURL(content string) string {
+	_, after, found := strings.Cut(content, ";")
+	if !found {
+		return ""
+	}
+
+	after = strings.TrimSpace(after)
+	if len(after) >= 4 && strings.EqualFold(after[:4], "url=") {
+		after = after[4:]
+	}
+
+	return strings.Trim(strings.TrimSpace(after), `'"`)
+}
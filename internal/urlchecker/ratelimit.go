@@ -0,0 +1,98 @@
+package urlchecker
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultMaxRetryAfter is the longest Retry-After delay waited for unless set with WithRetryAfter.
+const defaultMaxRetryAfter = time.Minute
+
+// WithRetryAfter retries checks answered with 429 Too Many Requests up to retries times, each time
+// after the delay the Retry-After header asks for, in seconds or as an HTTP date. A missing or
+// invalid header, a delay over maxWait or one that would pass the check deadline is not waited for.
+// Links still answered with 429 then get the rate_limited status instead of not available.
+// Zero retries disables it, a non-positive maxWait keeps the default of one minute.
+func WithRetryAfter(retries int, maxWait time.Duration) Option {
+	return func(c *Checker) {
+		c.rateLimitRetries = max(retries, 0)
+		if maxWait > 0 {
+			c.maxRetryAfter = maxWait
+		}
+	}
+}
+
+// retryRateLimited retries a 429 response with send as long as the server sets a Retry-After
+// delay that can be waited for. It returns the last response, the number of retries made and
+// the error of a failed retry; the previous responses are closed.
+func (c *Checker) retryRateLimited(ctx context.Context, resp *http.Response, send func() (*http.Response, error)) (*http.Response, int, error) {
+	retries := 0
+	for ; retries < c.rateLimitRetries && resp.StatusCode == http.StatusTooManyRequests; retries++ {
+		delay, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		if !ok || !c.canWait(ctx, delay) {
+			slog.DebugContext(ctx, "not retrying rate limited check",
+				slog.String("url", resp.Request.URL.String()),
+				slog.String("retry_after", resp.Header.Get("Retry-After")),
+			)
+			break
+		}
+
+		slog.DebugContext(ctx, "rate limited, retrying after delay",
+			slog.String("url", resp.Request.URL.String()),
+			slog.Duration("delay", delay),
+			slog.Int("retry", retries+1),
+		)
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, c.maxBodySize))
+		resp.Body.Close()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, retries, ctx.Err()
+		case <-timer.C:
+		}
+
+		next, err := send()
+		if err != nil {
+			return nil, retries + 1, err
+		}
+		resp = next
+	}
+	return resp, retries, nil
+}
+
+// canWait reports whether delay is within the configured maximum and ends before the ctx deadline.
+func (c *Checker) canWait(ctx context.Context, delay time.Duration) bool {
+	if delay > c.maxRetryAfter {
+		return false
+	}
+	deadline, ok := ctx.Deadline()
+	return !ok || time.Now().Add(delay).Before(deadline)
+}
+
+// parseRetryAfter returns the delay of a Retry-After header given as seconds or as an HTTP date
+// relative to now. Dates in the past mean no delay.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 || int64(seconds) > int64(math.MaxInt64/time.Second) {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	at, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	return max(at.Sub(now), 0), true
+}
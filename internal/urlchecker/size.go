@@ -0,0 +1,76 @@
+package urlchecker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// WithSizeProbe enables probing the content size with a ranged GET (Range: bytes=0-0)
+// when the HEAD response has no Content-Length. It requires an extra request per such link.
+func WithSizeProbe(enabled bool) Option {
+	return func(c *Checker) {
+		c.probeSize = enabled
+	}
+}
+
+// contentLength returns the content size of resp, probing it with a ranged GET when the
+// HEAD response omits Content-Length and probing is enabled. Zero means the size is unknown.
+func (c *Checker) contentLength(ctx context.Context, resp *http.Response) int64 {
+	if resp.ContentLength >= 0 {
+		return resp.ContentLength
+	}
+	if !c.probeSize || resp.StatusCode >= 400 {
+		return 0
+	}
+
+	size, err := c.probeContentLength(ctx, resp.Request.URL.String())
+	if err != nil {
+		return 0
+	}
+	return size
+}
+
+// probeContentLength requests the first byte of pageURL and reads the total size from
+// Content-Range, or from Content-Length when the server ignores the range.
+func (c *Checker) probeContentLength(ctx context.Context, pageURL string) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, http.NoBody)
+	if err != nil {
+		return 0, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("Accept", c.acceptFor(ctx))
+	req.Header.Set("Range", "bytes=0-0")
+	c.setBasicAuth(req)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("probe size: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		return contentRangeSize(resp.Header.Get("Content-Range"))
+	case http.StatusOK:
+		if resp.ContentLength >= 0 {
+			return resp.ContentLength, nil
+		}
+	}
+	return 0, fmt.Errorf("probe size: unexpected response %d", resp.StatusCode)
+}
+
+// contentRangeSize parses the complete length from a Content-Range header such as "bytes 0-0/1234".
+func contentRangeSize(value string) (int64, error) {
+	_, total, ok := strings.Cut(value, "/")
+	if !ok || !strings.HasPrefix(value, "bytes ") {
+		return 0, fmt.Errorf("invalid Content-Range %q", value)
+	}
+	size, err := strconv.ParseInt(total, 10, 64)
+	if err != nil || size < 0 {
+		return 0, fmt.Errorf("invalid Content-Range %q", value)
+	}
+	return size, nil
+}
@@ -0,0 +1,72 @@
+package urlchecker
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// errBlockedAddress is returned by the dialer for connections to private, loopback or link-local addresses.
+var errBlockedAddress = errors.New("connection to private address blocked")
+
+// Dialer settings matching the net/http default transport, the dial timeout is set with WithTimeouts.
+const (
+	defaultDialTimeout = 30 * time.Second
+	dialKeepAlive      = 30 * time.Second
+)
+
+// WithPrivateAddressBlocking rejects connections to private, loopback, link-local and unspecified
+// addresses when enabled, reporting such links as blocked_private_address. The address is checked
+// after DNS resolution right before dialing, so redirects and DNS rebinding are covered as well.
+// Proxies from the environment are not used while blocking is enabled, as they would bypass the check.
+func WithPrivateAddressBlocking(enabled bool) Option {
+	return func(c *Checker) {
+		c.blockPrivate = enabled
+		if enabled {
+			c.transport.Proxy = nil
+		}
+	}
+}
+
+// newDialer returns the dialer of check connections, blocking private addresses if enabled.
+func (c *Checker) newDialer() *net.Dialer {
+	dialer := &net.Dialer{
+		Timeout:   c.dialTimeout,
+		KeepAlive: dialKeepAlive,
+	}
+	if c.blockPrivate {
+		dialer.Control = blockPrivateAddress
+	}
+	return dialer
+}
+
+// blockPrivateAddress is a net.Dialer Control function failing for addresses that are not publicly routable.
+func blockPrivateAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errBlockedAddress, address)
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errBlockedAddress, address)
+	}
+	if isPrivateAddress(addr) {
+		return fmt.Errorf("%w: %s", errBlockedAddress, addr)
+	}
+	return nil
+}
+
+// isPrivateAddress reports whether addr is private, loopback, link-local (including cloud metadata
+// endpoints such as 169.254.169.254) or unspecified. IPv4-mapped IPv6 addresses are checked as IPv4.
+func isPrivateAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsPrivate() ||
+		addr.IsLoopback() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsUnspecified()
+}
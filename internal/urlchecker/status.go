@@ -0,0 +1,35 @@
+package urlchecker
+
+// WithStatusCodes overrides the default rule that responses below 400 are available: codes in
+// available count as available (e.g. 401 and 403 for protected pages, 429 for rate limiting),
+// codes in notAvailable count as not available. An expected status of the link still takes
+// precedence over both.
+func WithStatusCodes(available, notAvailable []int) Option {
+	return func(c *Checker) {
+		c.availableCodes = codeSet(available)
+		c.notAvailableCodes = codeSet(notAvailable)
+	}
+}
+
+// codeSet returns codes as a set, nil if there are none.
+func codeSet(codes []int) map[int]struct{} {
+	if len(codes) == 0 {
+		return nil
+	}
+	set := make(map[int]struct{}, len(codes))
+	for _, code := range codes {
+		set[code] = struct{}{}
+	}
+	return set
+}
+
+// isAvailableStatus reports whether a response with the status code makes a link available.
+func (c *Checker) isAvailableStatus(code int) bool {
+	if _, ok := c.availableCodes[code]; ok {
+		return true
+	}
+	if _, ok := c.notAvailableCodes[code]; ok {
+		return false
+	}
+	return code < 400
+}
@@ -0,0 +1,93 @@
+package urlchecker
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+// WithTiming records a breakdown of every check into DNS lookup, connect, TLS handshake and
+// time to first byte in models.Link.Timing when enabled.
+func WithTiming(enabled bool) Option {
+	return func(c *Checker) {
+		c.timing = enabled
+	}
+}
+
+// timingTrace collects phase durations of a check from httptrace hooks. Phases of every request
+// of the check (redirects, scheme fallback) are added up, connections reused from the pool
+// contribute nothing to DNS, connect and TLS.
+type timingTrace struct {
+	start time.Time
+
+	// mu guards the fields below: dual-stack dialing may run connect hooks concurrently.
+	mu           sync.Mutex
+	dnsStart     time.Time
+	connectStart time.Time
+	tlsStart     time.Time
+	timing       models.Timing
+}
+
+// newTimingTrace starts a trace for a check started at start.
+func newTimingTrace(start time.Time) *timingTrace {
+	return &timingTrace{start: start}
+}
+
+// withTrace returns a copy of ctx reporting the requests made with it to t.
+func (t *timingTrace) withTrace(ctx context.Context) context.Context {
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			t.mu.Lock()
+			t.dnsStart = time.Now()
+			t.mu.Unlock()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.mu.Lock()
+			t.timing.DNS += time.Since(t.dnsStart)
+			t.mu.Unlock()
+		},
+		ConnectStart: func(_, _ string) {
+			t.mu.Lock()
+			if t.connectStart.IsZero() {
+				t.connectStart = time.Now()
+			}
+			t.mu.Unlock()
+		},
+		ConnectDone: func(_, _ string, err error) {
+			t.mu.Lock()
+			if err == nil && !t.connectStart.IsZero() {
+				t.timing.Connect += time.Since(t.connectStart)
+				t.connectStart = time.Time{}
+			}
+			t.mu.Unlock()
+		},
+		TLSHandshakeStart: func() {
+			t.mu.Lock()
+			t.tlsStart = time.Now()
+			t.mu.Unlock()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.mu.Lock()
+			t.timing.TLS += time.Since(t.tlsStart)
+			t.mu.Unlock()
+		},
+		GotFirstResponseByte: func() {
+			t.mu.Lock()
+			t.timing.TTFB = time.Since(t.start)
+			t.mu.Unlock()
+		},
+	})
+}
+
+// result returns the collected phase durations.
+func (t *timingTrace) result() *models.Timing {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	timing := t.timing
+	return &timing
+}
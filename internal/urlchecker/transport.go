@@ -0,0 +1,82 @@
+package urlchecker
+
+import (
+	"crypto/tls"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+// hostScopedTransport routes requests to hosts matching patterns through an
+// insecure transport and all other requests through the default secure one.
+type hostScopedTransport struct {
+	secure   http.RoundTripper
+	insecure http.RoundTripper
+	patterns []string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *hostScopedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if MatchHost(t.patterns, req.URL.Hostname()) {
+		return t.insecure.RoundTrip(req)
+	}
+	return t.secure.RoundTrip(req)
+}
+
+// Connection pool defaults, tuned for checking many URLs on the same hosts.
+const (
+	defaultMaxIdleConns        = 100
+	defaultMaxIdleConnsPerHost = 10
+	defaultIdleConnTimeout     = 90 * time.Second
+)
+
+// newTransport returns a copy of the default transport with HTTP/2 and a
+// connection pool sized for bulk checks, so TCP/TLS connections are reused.
+func newTransport() *http.Transport {
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.ForceAttemptHTTP2 = true
+	tr.MaxIdleConns = defaultMaxIdleConns
+	tr.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+	tr.IdleConnTimeout = defaultIdleConnTimeout
+	return tr
+}
+
+// roundTripper builds the client transport from the tuned base transport,
+// adding TLS verification skipping when it is enabled.
+func (c *Checker) roundTripper() http.RoundTripper {
+	if !c.insecureTLS {
+		return c.transport
+	}
+
+	insecure := newInsecureTransport(c.transport)
+	if len(c.insecureTLSHosts) == 0 {
+		return insecure
+	}
+
+	return &hostScopedTransport{
+		secure:   c.transport,
+		insecure: insecure,
+		patterns: c.insecureTLSHosts,
+	}
+}
+
+// newInsecureTransport returns a copy of base that skips TLS verification.
+func newInsecureTransport(base *http.Transport) *http.Transport {
+	tr := base.Clone()
+	tr.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: true, // #nosec G402 -- explicitly enabled by configuration
+	}
+	return tr
+}
+
+// MatchHost reports whether host matches any of the glob patterns (e.g. "*.staging.local").
+func MatchHost(patterns []string, host string) bool {
+	host = strings.ToLower(host)
+	for _, pattern := range patterns {
+		if ok, err := path.Match(strings.ToLower(pattern), host); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
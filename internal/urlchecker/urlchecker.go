@@ -2,178 +2,658 @@ package urlchecker
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/polonkoevv/linkchecker/internal/models"
 )
 
-// Checker performs HTTP HEAD requests to determine link availability.
+// Checker performs HTTP requests, HEAD unless set per check, to determine link availability.
 type Checker struct {
-	client *http.Client
+	client            *http.Client
+	transport         *http.Transport
+	followMetaRefresh bool
+	insecureTLS       bool
+	insecureTLSHosts  []string
+	defaultScheme     string
+	schemeFallback    bool
+	maxRedirects      int
+	userAgent         string
+	accept            string
+	probeSize         bool
+	logHeaders        bool
+	// timing records request phase durations of every check.
+	timing bool
+	// availableCodes and notAvailableCodes override the default availability of status codes.
+	availableCodes    map[int]struct{}
+	notAvailableCodes map[int]struct{}
+	// basicAuth holds credentials sent to matching hosts, in order of precedence.
+	basicAuth []HostCredentials
+	// maxBodySize caps every response body read, for content matching and meta refresh.
+	maxBodySize int64
+	// dialTimeout bounds establishing a connection, see WithTimeouts.
+	dialTimeout time.Duration
+	// blockPrivate rejects connections to addresses that are not publicly routable.
+	blockPrivate bool
+	// rateLimitRetries and maxRetryAfter configure retries of 429 responses, see WithRetryAfter.
+	rateLimitRetries int
+	maxRetryAfter    time.Duration
 }
 
-// NewChecker creates a new Checker with a default HTTP client.
-func NewChecker() *Checker {
-	return &Checker{
-		client: &http.Client{},
+// defaultScheme is assumed for URLs given without a scheme.
+const defaultScheme = "https"
+
+// defaultMaxRedirects matches the net/http client default.
+const defaultMaxRedirects = 10
+
+// defaultUserAgent identifies the checker in requests unless overridden with WithUserAgent.
+const defaultUserAgent = "WebStatusChecker/1.0"
+
+// defaultAccept is sent in the Accept header unless overridden with WithAccept.
+const defaultAccept = "*/*"
+
+// Option configures optional Checker behavior.
+type Option func(*Checker)
+
+// WithMetaRefresh enables following <meta http-equiv="refresh"> targets one hop
+// for HTML responses. It requires an extra GET request per HTML page.
+func WithMetaRefresh(enabled bool) Option {
+	return func(c *Checker) {
+		c.followMetaRefresh = enabled
 	}
 }
 
-// CheckURL checks the given URL without external context control.
-func (c *Checker) CheckURL(rawURL string) models.Link {
-	start := time.Now()
+// WithInsecureSkipVerify disables TLS certificate verification when enabled.
+// If hosts is empty, verification is skipped for every host, otherwise only for
+// hosts matching one of the glob patterns (e.g. "*.staging.local", "10.0.0.5").
+func WithInsecureSkipVerify(enabled bool, hosts []string) Option {
+	return func(c *Checker) {
+		c.insecureTLS = enabled
+		c.insecureTLSHosts = hosts
+	}
+}
 
-	// Normalizing URL
-	normalizedURL, err := c.normalizeURL(rawURL)
-	if err != nil {
-		slog.Warn("failed to normalize URL",
-			slog.String("raw_url", rawURL),
-			slog.Any("error", err),
-		)
-		return models.Link{
-			URL:       rawURL,
-			Status:    models.LinkStatusNotAvailable,
-			CheckedAt: start,
-			Duration:  time.Since(start),
+// WithUserAgent sets the User-Agent header sent with every check request.
+// An empty value keeps the default.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Checker) {
+		if userAgent != "" {
+			c.userAgent = userAgent
 		}
 	}
+}
 
-	// Creating request with correct headers
-	req, err := http.NewRequest("HEAD", normalizedURL, http.NoBody)
-	if err != nil {
-		slog.Error("failed to create HTTP request",
-			slog.String("url", normalizedURL),
-			slog.Any("error", err),
-		)
-		return models.Link{
-			URL:       rawURL,
-			Status:    models.LinkStatusNotAvailable,
-			CheckedAt: start,
-			Duration:  time.Since(start),
+// WithAccept sets the Accept header sent with every check request, for servers that negotiate
+// content and reject */*. An empty value keeps the default. ContextWithAccept overrides it per check.
+func WithAccept(accept string) Option {
+	return func(c *Checker) {
+		if accept != "" {
+			c.accept = accept
 		}
 	}
+}
 
-	req.Header.Set("User-Agent", "WebStatusChecker/1.0")
-	req.Header.Set("Accept", "*/*")
+// WithHeaderLogging logs the complete response header set of every check at debug level
+// when enabled. Set-Cookie values are redacted.
+func WithHeaderLogging(enabled bool) Option {
+	return func(c *Checker) {
+		c.logHeaders = enabled
+	}
+}
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		slog.Debug("HTTP request failed",
-			slog.String("url", normalizedURL),
-			slog.Any("error", err),
-		)
-		return models.Link{
-			URL:       rawURL,
-			Status:    models.LinkStatusNotAvailable,
-			CheckedAt: start,
-			Duration:  time.Since(start),
+// WithMaxBodySize caps how many bytes of a response body are read, so that large or endless
+// bodies cannot exhaust memory. Content is matched against the truncated body, a mismatch on it
+// is reported as body_too_large. Non-positive values keep the default of 1 MB.
+func WithMaxBodySize(n int64) Option {
+	return func(c *Checker) {
+		if n > 0 {
+			c.maxBodySize = n
 		}
 	}
-	defer resp.Body.Close()
+}
 
-	duration := time.Since(start)
+// WithConnectionPool tunes connection reuse: the total number of idle connections,
+// idle connections kept per host and how long an idle connection stays open.
+// Non-positive values keep the defaults.
+func WithConnectionPool(maxIdleConns, maxIdleConnsPerHost int, idleConnTimeout time.Duration) Option {
+	return func(c *Checker) {
+		if maxIdleConns > 0 {
+			c.transport.MaxIdleConns = maxIdleConns
+		}
+		if maxIdleConnsPerHost > 0 {
+			c.transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
+		}
+		if idleConnTimeout > 0 {
+			c.transport.IdleConnTimeout = idleConnTimeout
+		}
+	}
+}
 
-	// Considering available if status is 2xx or 3xx
-	status := models.LinkStatusNotAvailable
-	if resp.StatusCode < 400 {
-		status = models.LinkStatusAvailable
+// WithDefaultScheme sets the scheme ("http" or "https") assumed for URLs given without one.
+// With fallback enabled, such URLs are retried over http when the https request fails.
+func WithDefaultScheme(scheme string, fallback bool) Option {
+	return func(c *Checker) {
+		if scheme == "http" || scheme == "https" {
+			c.defaultScheme = scheme
+		}
+		c.schemeFallback = fallback
 	}
+}
 
-	slog.Debug("checked URL",
-		slog.String("url", rawURL),
-		slog.Int("status_code", resp.StatusCode),
-		slog.String("status", string(status)),
-		slog.Duration("duration", duration),
-	)
+// WithMaxRedirects limits how many redirects a check follows. Links with longer
+// chains are reported as not available. Negative values keep the default.
+func WithMaxRedirects(maxRedirects int) Option {
+	return func(c *Checker) {
+		if maxRedirects >= 0 {
+			c.maxRedirects = maxRedirects
+		}
+	}
+}
 
-	return models.Link{
-		URL:       rawURL,
-		Status:    status,
-		CheckedAt: start,
-		Duration:  duration,
+// WithTimeouts sets how long establishing a connection may take and how long to wait for
+// response headers once the request is sent, so unreachable hosts fail fast while slow but
+// responding ones are still waited for. Checks failing on either are reported with a timeout
+// reason. Non-positive values keep the defaults: 30 seconds to connect and no header timeout,
+// checks are then bounded by the per-check timeout only.
+func WithTimeouts(dial, responseHeader time.Duration) Option {
+	return func(c *Checker) {
+		if dial > 0 {
+			c.dialTimeout = dial
+		}
+		if responseHeader > 0 {
+			c.transport.ResponseHeaderTimeout = responseHeader
+		}
+	}
+}
+
+// NewChecker creates a new Checker with a pooled HTTP/2-capable client and the given options.
+func NewChecker(opts ...Option) *Checker {
+	c := &Checker{
+		transport:     newTransport(),
+		defaultScheme: defaultScheme,
+		maxRedirects:  defaultMaxRedirects,
+		userAgent:     defaultUserAgent,
+		accept:        defaultAccept,
+		maxBodySize:   defaultMaxBodySize,
+		dialTimeout:   defaultDialTimeout,
+		maxRetryAfter: defaultMaxRetryAfter,
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	// the dialer depends on several options, so it is built once all of them are applied
+	c.transport.DialContext = c.newDialer().DialContext
+	c.client = &http.Client{
+		Transport:     c.roundTripper(),
+		CheckRedirect: c.checkRedirect,
+	}
+	return c
+}
+
+// Transport returns the round tripper used for checks, to fetch link sources under the same
+// TLS and address restrictions.
+func (c *Checker) Transport() http.RoundTripper {
+	return c.client.Transport
+}
+
+// CheckURL checks the given URL without external context control.
+// It is CheckURLWithContext with context.Background().
+func (c *Checker) CheckURL(rawURL string) models.Link {
+	return c.CheckURLWithContext(context.Background(), rawURL)
 }
 
-// CheckURLWithContext checks URL with context
+// CheckURLWithContext checks URL with context: the request is canceled with ctx and per-check
+// settings (HTTP method, previous ETag and modification time, expected status, content match,
+// CORS preflight) are read from it.
 func (c *Checker) CheckURLWithContext(ctx context.Context, rawURL string) models.Link {
 	start := time.Now()
+	method := methodFromContext(ctx)
+	preflight, isPreflight := corsPreflightFromContext(ctx)
+	if isPreflight {
+		method = http.MethodOptions
+	}
 
 	normalizedURL, err := c.normalizeURL(rawURL)
 	if err != nil {
-		slog.Warn("failed to normalize URL",
+		slog.WarnContext(ctx, "failed to normalize URL",
 			slog.String("raw_url", rawURL),
 			slog.Any("error", err),
 		)
 		return models.Link{
 			URL:       rawURL,
-			Status:    models.LinkStatusNotAvailable,
+			Status:    normalizeErrorStatus(err),
 			CheckedAt: start,
 			Duration:  time.Since(start),
 		}
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "HEAD", normalizedURL, http.NoBody)
-	if err != nil {
-		slog.Error("failed to create HTTP request with context",
-			slog.String("url", normalizedURL),
-			slog.Any("error", err),
-		)
-		return models.Link{
-			URL:       rawURL,
-			Status:    models.LinkStatusNotAvailable,
-			CheckedAt: start,
-			Duration:  time.Since(start),
-		}
+	// Only the check request itself is traced, not content and meta refresh fetches.
+	sendCtx := ctx
+	var trace *timingTrace
+	if c.timing {
+		trace = newTimingTrace(start)
+		sendCtx = trace.withTrace(ctx)
 	}
 
-	req.Header.Set("User-Agent", "WebStatusChecker/1.0")
-	req.Header.Set("Accept", "*/*")
-
-	resp, err := c.client.Do(req)
+	resp, scheme, err := c.send(sendCtx, method, rawURL, normalizedURL)
+	var retries int
+	if err == nil && c.rateLimitRetries > 0 {
+		resp, retries, err = c.retryRateLimited(sendCtx, resp, func() (*http.Response, error) {
+			next, nextScheme, err := c.send(sendCtx, method, rawURL, normalizedURL)
+			scheme = nextScheme
+			return next, err
+		})
+	}
 	if err != nil {
-		slog.Debug("HTTP request with context failed",
+		slog.DebugContext(ctx, "HTTP request failed",
 			slog.String("url", normalizedURL),
+			slog.String("method", method),
 			slog.Any("error", err),
 		)
-		return models.Link{
-			URL:       rawURL,
-			Status:    models.LinkStatusNotAvailable,
-			CheckedAt: start,
-			Duration:  time.Since(start),
+		link := c.failedLink(rawURL, start, err)
+		link.Method = method
+		link.RateLimitRetries = retries
+		if trace != nil {
+			link.Timing = trace.result()
 		}
+		return link
 	}
 	defer resp.Body.Close()
 
 	duration := time.Since(start)
 
+	if c.logHeaders {
+		slog.DebugContext(ctx, "response headers",
+			slog.String("url", rawURL),
+			slog.Int("status_code", resp.StatusCode),
+			slog.Any("headers", redactHeaders(resp.Header)),
+		)
+	}
+
 	status := models.LinkStatusNotAvailable
-	if resp.StatusCode < 400 {
+	if c.isAvailableStatus(resp.StatusCode) {
 		status = models.LinkStatusAvailable
 	}
 
-	slog.Debug("checked URL with context",
+	var linkErr string
+	expected, matched := checkExpectedStatus(ctx, resp)
+	if expected != "" {
+		status = models.LinkStatusNotAvailable
+		if matched {
+			status = models.LinkStatusAvailable
+		} else {
+			linkErr = models.LinkErrorUnexpectedStatus
+		}
+	}
+
+	// a link the server kept throttling says nothing about its availability
+	if c.rateLimitRetries > 0 && resp.StatusCode == http.StatusTooManyRequests && status != models.LinkStatusAvailable {
+		status = models.LinkStatusRateLimited
+	}
+
+	var cors *models.CORSResult
+	if isPreflight {
+		cors = checkCORS(preflight, resp.Header)
+		if status == models.LinkStatusAvailable && !cors.Allowed() {
+			status = models.LinkStatusNotAvailable
+			linkErr = models.LinkErrorCORSRejected
+		}
+	}
+
+	var contentMatched *bool
+	if m, ok := contentMatchFromContext(ctx); ok && status == models.LinkStatusAvailable {
+		matched, truncated, err := c.matchContent(ctx, resp, m)
+		if err != nil {
+			slog.DebugContext(ctx, "failed to match page content",
+				slog.String("url", rawURL),
+				slog.Any("error", err),
+			)
+		}
+		contentMatched = &matched
+		if !matched {
+			status = models.LinkStatusNotAvailable
+			linkErr = models.LinkErrorContentMismatch
+			if truncated {
+				linkErr = models.LinkErrorBodyTooLarge
+			}
+		}
+		duration = time.Since(start)
+	}
+
+	etag, unchanged := responseETag(ctx, resp)
+
+	var metaRefreshTarget string
+	if c.followMetaRefresh && status == models.LinkStatusAvailable && isHTMLResponse(resp) {
+		if target, available, ok := c.checkMetaRefresh(ctx, resp.Request.URL); ok {
+			metaRefreshTarget = target
+			if !available {
+				status = models.LinkStatusNotAvailable
+			}
+			duration = time.Since(start)
+		}
+	}
+
+	slog.DebugContext(ctx, "checked URL",
 		slog.String("url", rawURL),
 		slog.Int("status_code", resp.StatusCode),
 		slog.String("status", string(status)),
 		slog.Duration("duration", duration),
 	)
 
-	return models.Link{
+	link := models.Link{
+		URL:               rawURL,
+		Status:            status,
+		CheckedAt:         start,
+		Duration:          duration,
+		MetaRefreshTarget: metaRefreshTarget,
+		Scheme:            scheme,
+		Method:            method,
+		LastModified:      lastModified(resp),
+		StatusCode:        resp.StatusCode,
+		ExpectedStatus:    expected,
+		ContentMatched:    contentMatched,
+		RedirectCount:     redirectCount(resp),
+		ContentLength:     c.contentLength(ctx, resp),
+		ETag:              etag,
+		Unchanged:         unchanged,
+		Error:             linkErr,
+		CORS:              cors,
+		RateLimitRetries:  retries,
+	}
+	if trace != nil {
+		link.Timing = trace.result()
+	}
+	return link
+}
+
+// send sends a method request to normalizedURL and returns the response with the scheme used.
+// With scheme fallback enabled, a failed https request for a URL given without a scheme
+// is retried over plain http.
+func (c *Checker) send(ctx context.Context, method, rawURL, normalizedURL string) (*http.Response, string, error) {
+	u, err := url.Parse(normalizedURL)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid URL: %w", err)
+	}
+
+	resp, err := c.sendRequest(ctx, method, u.String())
+	if err == nil {
+		return resp, u.Scheme, nil
+	}
+
+	if !c.schemeFallback || u.Scheme != "https" || ctx.Err() != nil || !isFallbackError(err) || errors.Is(err, errBlockedAddress) {
+		return nil, "", err
+	}
+	if _, explicit := explicitScheme(strings.TrimSpace(rawURL)); explicit {
+		return nil, "", err
+	}
+
+	slog.DebugContext(ctx, "https request failed, retrying over http",
+		slog.String("url", normalizedURL),
+		slog.Any("error", err),
+	)
+
+	u.Scheme = "http"
+	resp, err = c.sendRequest(ctx, method, u.String())
+	if err != nil {
+		return nil, "", err
+	}
+	return resp, u.Scheme, nil
+}
+
+// sendRequest performs a single method request with the checker headers.
+func (c *Checker) sendRequest(ctx context.Context, method, target string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("Accept", c.acceptFor(ctx))
+	c.setBasicAuth(req)
+	setCORSHeaders(ctx, req)
+	if etag := etagFromContext(ctx); etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if since := modifiedSinceFromContext(ctx); !since.IsZero() {
+		req.Header.Set("If-Modified-Since", since.UTC().Format(http.TimeFormat))
+	}
+
+	return c.client.Do(req)
+}
+
+// isTimeout reports whether err is a timeout of the request itself, such as the dial or
+// response header timeout, rather than a cancellation.
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// plainHTTPResponseError is the net/http transport message for an https request answered
+// over plain http; the transport does not expose a typed error for it.
+const plainHTTPResponseError = "server gave HTTP response to HTTPS client"
+
+// isFallbackError reports whether a failed https request may succeed over plain http:
+// the TLS handshake failed, the port speaks plain http or no connection could be established.
+func isFallbackError(err error) bool {
+	var (
+		recordErr tls.RecordHeaderError
+		certErr   *tls.CertificateVerificationError
+		alertErr  tls.AlertError
+		netErr    *net.OpError
+	)
+	return strings.Contains(err.Error(), plainHTTPResponseError) ||
+		errors.As(err, &recordErr) ||
+		errors.As(err, &certErr) ||
+		errors.As(err, &alertErr) ||
+		errors.As(err, &netErr)
+}
+
+// errTooManyRedirects is returned by the client when a redirect chain exceeds the configured limit.
+var errTooManyRedirects = errors.New("too many redirects")
+
+// checkRedirect stops following redirects after maxRedirects hops.
+// The redirect request gets the credentials of its own host, if any.
+func (c *Checker) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) > c.maxRedirects {
+		return errTooManyRedirects
+	}
+	c.setBasicAuth(req)
+	return nil
+}
+
+// redirectCount returns the number of redirects followed to get resp.
+func redirectCount(resp *http.Response) int {
+	count := 0
+	for req := resp.Request; req != nil && req.Response != nil; req = req.Response.Request {
+		count++
+	}
+	return count
+}
+
+// failedLink builds the result for a URL whose request failed.
+func (c *Checker) failedLink(rawURL string, start time.Time, err error) models.Link {
+	link := models.Link{
 		URL:       rawURL,
-		Status:    status,
+		Status:    models.LinkStatusNotAvailable,
 		CheckedAt: start,
-		Duration:  duration,
+		Duration:  time.Since(start),
+	}
+	switch {
+	case errors.Is(err, errTooManyRedirects):
+		link.Error = models.LinkErrorTooManyRedirects
+		link.RedirectCount = c.maxRedirects
+	case errors.Is(err, errBlockedAddress):
+		link.Status = models.LinkStatusBlockedPrivateAddress
+	case isTimeout(err):
+		link.Error = models.LinkErrorTimeout
+	}
+	return link
+}
+
+// methodContextKey is the context key for the HTTP method a check is made with.
+type methodContextKey struct{}
+
+// ContextWithMethod returns a copy of ctx checking the URL with method instead of HEAD,
+// for servers that answer HEAD incorrectly. The method should be one of models.CheckMethods.
+func ContextWithMethod(ctx context.Context, method string) context.Context {
+	return context.WithValue(ctx, methodContextKey{}, method)
+}
+
+// methodFromContext returns the method stored by ContextWithMethod, HEAD if none is set.
+func methodFromContext(ctx context.Context) string {
+	if method, _ := ctx.Value(methodContextKey{}).(string); method != "" {
+		return method
 	}
+	return http.MethodHead
 }
 
+// acceptContextKey is the context key for the Accept header a check is made with.
+type acceptContextKey struct{}
+
+// ContextWithAccept returns a copy of ctx checking the URL with accept as the Accept header
+// instead of the one configured with WithAccept.
+func ContextWithAccept(ctx context.Context, accept string) context.Context {
+	return context.WithValue(ctx, acceptContextKey{}, accept)
+}
+
+// acceptFor returns the Accept header stored by ContextWithAccept, the configured one if none is set.
+func (c *Checker) acceptFor(ctx context.Context) string {
+	if accept, _ := ctx.Value(acceptContextKey{}).(string); accept != "" {
+		return accept
+	}
+	return c.accept
+}
+
+// etagContextKey is the context key for the ETag of a previous check.
+type etagContextKey struct{}
+
+// ContextWithETag returns a copy of ctx carrying the ETag from a previous check of the URL.
+// CheckURLWithContext sends it in If-None-Match and treats 304 as available and unchanged.
+func ContextWithETag(ctx context.Context, etag string) context.Context {
+	return context.WithValue(ctx, etagContextKey{}, etag)
+}
+
+// etagFromContext returns the ETag stored by ContextWithETag, or an empty string.
+func etagFromContext(ctx context.Context) string {
+	etag, _ := ctx.Value(etagContextKey{}).(string)
+	return etag
+}
+
+// modifiedSinceContextKey is the context key for the time of a previous version of the URL.
+type modifiedSinceContextKey struct{}
+
+// ContextWithModifiedSince returns a copy of ctx carrying the Last-Modified time, or the check time,
+// of a previous check of the URL. CheckURLWithContext sends it in If-Modified-Since and treats 304
+// as available and unchanged.
+func ContextWithModifiedSince(ctx context.Context, t time.Time) context.Context {
+	return context.WithValue(ctx, modifiedSinceContextKey{}, t)
+}
+
+// modifiedSinceFromContext returns the time stored by ContextWithModifiedSince, or the zero time.
+func modifiedSinceFromContext(ctx context.Context) time.Time {
+	t, _ := ctx.Value(modifiedSinceContextKey{}).(time.Time)
+	return t
+}
+
+// expectedStatusContextKey is the context key for the status range a check must answer with.
+type expectedStatusContextKey struct{}
+
+// ContextWithExpectedStatus returns a copy of ctx requiring the checked URL to answer with
+// a status in r instead of any status below 400. The status of the first response is
+// compared, before redirects, so that a redirect to a login page does not pass for a 200.
+func ContextWithExpectedStatus(ctx context.Context, r models.StatusRange) context.Context {
+	return context.WithValue(ctx, expectedStatusContextKey{}, r)
+}
+
+// checkExpectedStatus compares the first response of the redirect chain with the range from
+// ContextWithExpectedStatus. It returns the formatted range, empty if none is set, and whether it matched.
+func checkExpectedStatus(ctx context.Context, resp *http.Response) (string, bool) {
+	r, ok := ctx.Value(expectedStatusContextKey{}).(models.StatusRange)
+	if !ok || r.IsZero() {
+		return "", true
+	}
+
+	code := resp.StatusCode
+	for req := resp.Request; req != nil && req.Response != nil; req = req.Response.Request {
+		code = req.Response.StatusCode
+	}
+
+	return r.String(), r.Contains(code)
+}
+
+// responseETag returns the ETag to record for resp and whether the page is unchanged (304).
+// A 304 without an ETag header keeps the ETag that was sent.
+func responseETag(ctx context.Context, resp *http.Response) (string, bool) {
+	etag := resp.Header.Get("ETag")
+	if resp.StatusCode != http.StatusNotModified {
+		return etag, false
+	}
+	if etag == "" {
+		etag = etagFromContext(ctx)
+	}
+	return etag, true
+}
+
+// lastModified parses the Last-Modified response header, returning nil if it is missing or invalid.
+func lastModified(resp *http.Response) *time.Time {
+	value := resp.Header.Get("Last-Modified")
+	if value == "" {
+		return nil
+	}
+
+	t, err := http.ParseTime(value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// redactedHeaders are response headers whose values are not logged.
+var redactedHeaders = []string{"Set-Cookie"}
+
+// redactHeaders returns a copy of header with the values of redactedHeaders replaced.
+func redactHeaders(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, name := range redactedHeaders {
+		if _, ok := redacted[name]; ok {
+			redacted[name] = []string{"[redacted]"}
+		}
+	}
+	return redacted
+}
+
+// errUnsupportedScheme is returned by normalizeURL for URLs with an explicit non-HTTP scheme.
+var errUnsupportedScheme = errors.New("unsupported URL scheme")
+
+// normalizeErrorStatus maps a normalizeURL error to the status reported for the link.
+func normalizeErrorStatus(err error) models.LinkStatus {
+	if errors.Is(err, errUnsupportedScheme) {
+		return models.LinkStatusUnsupportedScheme
+	}
+	return models.LinkStatusNotAvailable
+}
+
+// normalizeURL adds the default scheme (https unless configured) when missing and validates the host and port.
+// Bare IPv6 literals are wrapped in brackets, "host:port" inputs keep their port.
+// URLs with an explicit scheme other than http/https (ftp://, mailto:, ...) are rejected
+// with errUnsupportedScheme instead of being rewritten to https.
 func (c *Checker) normalizeURL(rawURL string) (string, error) {
-	if !strings.HasPrefix(rawURL, "http://") && !strings.HasPrefix(rawURL, "https://") {
-		rawURL = "https://" + rawURL
+	rawURL = strings.TrimSpace(rawURL)
+
+	if scheme, ok := explicitScheme(rawURL); ok {
+		if scheme != "http" && scheme != "https" {
+			return "", fmt.Errorf("%w: %s", errUnsupportedScheme, scheme)
+		}
+	} else {
+		rawURL = c.defaultScheme + "://" + bracketIPv6(rawURL)
 	}
 
 	u, err := url.Parse(rawURL)
@@ -181,9 +661,93 @@ func (c *Checker) normalizeURL(rawURL string) (string, error) {
 		return "", fmt.Errorf("invalid URL: %w", err)
 	}
 
-	if u.Host == "" {
+	if u.Hostname() == "" {
 		return "", fmt.Errorf("missing host in URL")
 	}
 
+	if port := u.Port(); port != "" {
+		p, err := strconv.Atoi(port)
+		if err != nil || p < 1 || p > 65535 {
+			return "", fmt.Errorf("invalid port in URL: %s", port)
+		}
+	}
+
 	return u.String(), nil
 }
+
+// Host returns the lowercased host name rawURL is checked at, resolving links without
+// a scheme the way normalizeURL does. It returns "" if rawURL has no host (e.g. mailto:).
+func Host(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	if _, ok := explicitScheme(rawURL); !ok {
+		rawURL = "https://" + bracketIPv6(rawURL)
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
+
+// CollapseWWW strips a leading "www." from host so that www and non-www hosts compare equal.
+// Hosts where nothing but a top-level domain would remain (e.g. "www.com") are kept as is.
+func CollapseWWW(host string) string {
+	rest, ok := strings.CutPrefix(host, "www.")
+	if !ok || !strings.Contains(rest, ".") {
+		return host
+	}
+	return rest
+}
+
+// explicitScheme returns the lowercased scheme if rawURL starts with one.
+// "host:port" and bare IPv6 literals are not treated as schemes.
+func explicitScheme(rawURL string) (string, bool) {
+	scheme, rest, found := strings.Cut(rawURL, ":")
+	if !found || scheme == "" || !isSchemeName(scheme) {
+		return "", false
+	}
+
+	if strings.HasPrefix(rest, "//") {
+		return strings.ToLower(scheme), true
+	}
+
+	// "localhost:8080" is a host with a port, "2001:db8::1" is an IPv6 address
+	if strings.Contains(scheme, ".") || rest == "" || rest[0] == ':' || (rest[0] >= '0' && rest[0] <= '9') {
+		return "", false
+	}
+
+	return strings.ToLower(scheme), true
+}
+
+// isSchemeName reports whether s is a valid URL scheme name (RFC 3986).
+func isSchemeName(s string) bool {
+	for i, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case i > 0 && (r >= '0' && r <= '9' || r == '+' || r == '-' || r == '.'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// bracketIPv6 wraps a bare IPv6 literal host (without scheme) in square brackets,
+// so that its colons are not mistaken for a port separator.
+func bracketIPv6(rawURL string) string {
+	host, rest := rawURL, ""
+	if i := strings.IndexAny(rawURL, "/?#"); i >= 0 {
+		host, rest = rawURL[:i], rawURL[i:]
+	}
+
+	if strings.HasPrefix(host, "[") || strings.Count(host, ":") < 2 {
+		return rawURL
+	}
+
+	if ip := net.ParseIP(host); ip == nil || ip.To4() != nil {
+		return rawURL
+	}
+
+	return "[" + host + "]" + rest
+}
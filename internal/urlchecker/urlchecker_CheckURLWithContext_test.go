@@ -0,0 +1,83 @@
+package urlchecker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestChecker_CheckURLWithContext_MetaRefresh(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/legacy", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(`<html><head><meta http-equiv="refresh" content="0; url=/missing"></head><body></body></html>`))
+	})
+	mux.HandleFunc("/legacy-ok", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><head><META HTTP-EQUIV="Refresh" CONTENT="5;URL='/ok'"></head></html>`))
+	})
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	t.Run("disabled by default", func(t *testing.T) {
+		c := NewChecker()
+
+		link := c.CheckURLWithContext(context.Background(), srv.URL+"/legacy")
+
+		if link.Status != models.LinkStatusAvailable {
+			t.Errorf("CheckURLWithContext() status = %s, want %s", link.Status, models.LinkStatusAvailable)
+		}
+		if link.MetaRefreshTarget != "" {
+			t.Errorf("CheckURLWithContext() MetaRefreshTarget = %q, want empty", link.MetaRefreshTarget)
+		}
+	})
+
+	t.Run("reports broken meta-refresh target", func(t *testing.T) {
+		c := NewChecker(WithMetaRefresh(true))
+
+		link := c.CheckURLWithContext(context.Background(), srv.URL+"/legacy")
+
+		if link.Status != models.LinkStatusNotAvailable {
+			t.Errorf("CheckURLWithContext() status = %s, want %s", link.Status, models.LinkStatusNotAvailable)
+		}
+		if link.MetaRefreshTarget != srv.URL+"/missing" {
+			t.Errorf("CheckURLWithContext() MetaRefreshTarget = %q, want %q", link.MetaRefreshTarget, srv.URL+"/missing")
+		}
+	})
+
+	t.Run("follows working meta-refresh target", func(t *testing.T) {
+		c := NewChecker(WithMetaRefresh(true))
+
+		link := c.CheckURLWithContext(context.Background(), srv.URL+"/legacy-ok")
+
+		if link.Status != models.LinkStatusAvailable {
+			t.Errorf("CheckURLWithContext() status = %s, want %s", link.Status, models.LinkStatusAvailable)
+		}
+		if link.MetaRefreshTarget != srv.URL+"/ok" {
+			t.Errorf("CheckURLWithContext() MetaRefreshTarget = %q, want %q", link.MetaRefreshTarget, srv.URL+"/ok")
+		}
+	})
+
+	t.Run("ignores non-HTML responses", func(t *testing.T) {
+		c := NewChecker(WithMetaRefresh(true))
+
+		link := c.CheckURLWithContext(context.Background(), srv.URL+"/ok")
+
+		if link.Status != models.LinkStatusAvailable {
+			t.Errorf("CheckURLWithContext() status = %s, want %s", link.Status, models.LinkStatusAvailable)
+		}
+		if link.MetaRefreshTarget != "" {
+			t.Errorf("CheckURLWithContext() MetaRefreshTarget = %q, want empty", link.MetaRefreshTarget)
+		}
+	})
+}
@@ -0,0 +1,34 @@
+package urlchecker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestChecker_CheckURL(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/new", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		w.WriteHeader(http.StatusOK)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := NewChecker()
+
+	got := c.CheckURL(srv.URL + "/old")
+	want := c.CheckURLWithContext(context.Background(), srv.URL+"/old")
+
+	if got.URL != want.URL || got.Status != want.Status || got.StatusCode != want.StatusCode ||
+		got.Scheme != want.Scheme || got.RedirectCount != want.RedirectCount || got.ETag != want.ETag {
+		t.Errorf("CheckURL() = %+v, want same result as CheckURLWithContext() %+v", got, want)
+	}
+	if got.RedirectCount != 1 || got.ETag != `"v1"` {
+		t.Errorf("CheckURL() redirect_count = %d, etag = %s, want 1, \"v1\"", got.RedirectCount, got.ETag)
+	}
+}
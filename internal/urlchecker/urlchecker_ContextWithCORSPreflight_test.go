@@ -0,0 +1,143 @@
+package urlchecker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestChecker_ContextWithCORSPreflight(t *testing.T) {
+	// every path answers preflights with a different CORS configuration
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodOptions {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if r.Header.Get("Access-Control-Request-Method") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		h := w.Header()
+		switch r.URL.Path {
+		case "/echo":
+			h.Set("Access-Control-Allow-Origin", r.Header.Get("Origin"))
+			h.Set("Access-Control-Allow-Methods", "GET, PUT")
+			h.Set("Access-Control-Allow-Headers", r.Header.Get("Access-Control-Request-Headers"))
+			h.Set("Access-Control-Max-Age", "600")
+		case "/other-origin":
+			h.Set("Access-Control-Allow-Origin", "https://other.example.com")
+			h.Set("Access-Control-Allow-Methods", "PUT")
+		case "/wildcard":
+			h.Set("Access-Control-Allow-Origin", "*")
+			h.Set("Access-Control-Allow-Methods", "*")
+			h.Set("Access-Control-Allow-Headers", "*")
+		case "/wildcard-credentials":
+			h.Set("Access-Control-Allow-Origin", "*")
+			h.Set("Access-Control-Allow-Methods", "*")
+			h.Set("Access-Control-Allow-Credentials", "true")
+		case "/no-headers":
+			h.Set("Access-Control-Allow-Origin", "https://app.example.com")
+			h.Set("Access-Control-Allow-Methods", "PUT")
+		case "/disallowed":
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	put := models.CORSPreflight{Origin: "https://app.example.com", Method: http.MethodPut, Headers: []string{"X-Token"}}
+	get := models.CORSPreflight{Origin: "https://app.example.com", Method: http.MethodGet}
+
+	tests := []struct {
+		name       string
+		path       string
+		preflight  models.CORSPreflight
+		wantStatus models.LinkStatus
+		wantError  string
+		wantCORS   models.CORSResult
+	}{
+		{
+			name: "allowed", path: "/echo", preflight: put, wantStatus: models.LinkStatusAvailable,
+			wantCORS: models.CORSResult{
+				AllowOrigin: "https://app.example.com", AllowMethods: "GET, PUT", AllowHeaders: "x-token", MaxAge: "600",
+				OriginAllowed: true, MethodAllowed: true, HeadersAllowed: true,
+			},
+		},
+		{
+			name: "other origin", path: "/other-origin", preflight: get,
+			wantStatus: models.LinkStatusNotAvailable, wantError: models.LinkErrorCORSRejected,
+			wantCORS: models.CORSResult{AllowOrigin: "https://other.example.com", AllowMethods: "PUT", MethodAllowed: true, HeadersAllowed: true},
+		},
+		{
+			name: "wildcards", path: "/wildcard", preflight: put, wantStatus: models.LinkStatusAvailable,
+			wantCORS: models.CORSResult{AllowOrigin: "*", AllowMethods: "*", AllowHeaders: "*", OriginAllowed: true, MethodAllowed: true, HeadersAllowed: true},
+		},
+		{
+			name: "wildcards ignored with credentials", path: "/wildcard-credentials", preflight: put,
+			wantStatus: models.LinkStatusNotAvailable, wantError: models.LinkErrorCORSRejected,
+			wantCORS: models.CORSResult{AllowOrigin: "*", AllowMethods: "*", AllowCredentials: true},
+		},
+		{
+			name: "safelisted method needs no allow methods", path: "/other-origin",
+			preflight: models.CORSPreflight{Origin: "https://other.example.com", Method: http.MethodPost}, wantStatus: models.LinkStatusAvailable,
+			wantCORS: models.CORSResult{AllowOrigin: "https://other.example.com", AllowMethods: "PUT", OriginAllowed: true, MethodAllowed: true, HeadersAllowed: true},
+		},
+		{
+			name: "header not allowed", path: "/no-headers", preflight: put,
+			wantStatus: models.LinkStatusNotAvailable, wantError: models.LinkErrorCORSRejected,
+			wantCORS: models.CORSResult{AllowOrigin: "https://app.example.com", AllowMethods: "PUT", OriginAllowed: true, MethodAllowed: true},
+		},
+		{
+			name: "preflight rejected by status", path: "/disallowed", preflight: get,
+			wantStatus: models.LinkStatusNotAvailable,
+			wantCORS:   models.CORSResult{MethodAllowed: true, HeadersAllowed: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := ContextWithCORSPreflight(context.Background(), tt.preflight)
+
+			link := NewChecker().CheckURLWithContext(ctx, srv.URL+tt.path)
+
+			if link.Method != http.MethodOptions {
+				t.Errorf("Method = %q, want OPTIONS", link.Method)
+			}
+			if link.Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s (status code %d)", link.Status, tt.wantStatus, link.StatusCode)
+			}
+			if link.Error != tt.wantError {
+				t.Errorf("Error = %q, want %q", link.Error, tt.wantError)
+			}
+			if link.CORS == nil {
+				t.Fatal("CORS = nil, want preflight result")
+			}
+			if *link.CORS != tt.wantCORS {
+				t.Errorf("CORS = %+v, want %+v", *link.CORS, tt.wantCORS)
+			}
+		})
+	}
+
+	t.Run("method from context is replaced", func(t *testing.T) {
+		ctx := ContextWithMethod(context.Background(), http.MethodGet)
+		ctx = ContextWithCORSPreflight(ctx, get)
+
+		link := NewChecker().CheckURLWithContext(ctx, srv.URL+"/echo")
+
+		if link.Method != http.MethodOptions || link.Status != models.LinkStatusAvailable {
+			t.Errorf("Method = %q, Status = %s, want OPTIONS and available", link.Method, link.Status)
+		}
+	})
+
+	t.Run("plain checks have no CORS result", func(t *testing.T) {
+		link := NewChecker().CheckURLWithContext(ContextWithMethod(context.Background(), http.MethodOptions), srv.URL+"/echo")
+
+		if link.CORS != nil {
+			t.Errorf("CORS = %+v, want nil", *link.CORS)
+		}
+	})
+}
@@ -0,0 +1,118 @@
+package urlchecker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestChecker_ContextWithContentMatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte("<html><body><h1>Page Not Found</h1><p>Order #4521</p></body></html>"))
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		match      models.ContentMatch
+		wantStatus models.LinkStatus
+		wantError  string
+	}{
+		{
+			name:       "substring found",
+			match:      models.ContentMatch{Contains: "Order #"},
+			wantStatus: models.LinkStatusAvailable,
+		},
+		{
+			name:       "soft 404 fails substring",
+			match:      models.ContentMatch{Contains: "Welcome"},
+			wantStatus: models.LinkStatusNotAvailable,
+			wantError:  models.LinkErrorContentMismatch,
+		},
+		{
+			name:       "regex matches",
+			match:      models.ContentMatch{Pattern: regexp.MustCompile(`Order #\d+`)},
+			wantStatus: models.LinkStatusAvailable,
+		},
+		{
+			name:       "both must match",
+			match:      models.ContentMatch{Contains: "Order", Pattern: regexp.MustCompile(`(?i)welcome`)},
+			wantStatus: models.LinkStatusNotAvailable,
+			wantError:  models.LinkErrorContentMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := ContextWithContentMatch(context.Background(), tt.match)
+
+			link := NewChecker().CheckURLWithContext(ctx, srv.URL)
+
+			if link.Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s", link.Status, tt.wantStatus)
+			}
+			if link.Error != tt.wantError {
+				t.Errorf("Error = %q, want %q", link.Error, tt.wantError)
+			}
+			wantMatched := tt.wantStatus == models.LinkStatusAvailable
+			if link.ContentMatched == nil || *link.ContentMatched != wantMatched {
+				t.Errorf("ContentMatched = %v, want %v", link.ContentMatched, wantMatched)
+			}
+		})
+	}
+
+	t.Run("GET check reuses its body", func(t *testing.T) {
+		var requests []string
+		counting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r.Method)
+			_, _ = w.Write([]byte("Order #4521"))
+		}))
+		defer counting.Close()
+
+		ctx := ContextWithMethod(context.Background(), http.MethodGet)
+		ctx = ContextWithContentMatch(ctx, models.ContentMatch{Contains: "Order #"})
+		link := NewChecker().CheckURLWithContext(ctx, counting.URL)
+
+		if link.ContentMatched == nil || !*link.ContentMatched {
+			t.Errorf("ContentMatched = %v, want true", link.ContentMatched)
+		}
+		if len(requests) != 1 || requests[0] != http.MethodGet {
+			t.Errorf("requests = %v, want [GET]", requests)
+		}
+	})
+
+	t.Run("HEAD check fetches body with GET", func(t *testing.T) {
+		var requests []string
+		counting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r.Method)
+			_, _ = w.Write([]byte("Order #4521"))
+		}))
+		defer counting.Close()
+
+		ctx := ContextWithContentMatch(context.Background(), models.ContentMatch{Contains: "Order #"})
+		link := NewChecker().CheckURLWithContext(ctx, counting.URL)
+
+		if link.ContentMatched == nil || !*link.ContentMatched {
+			t.Errorf("ContentMatched = %v, want true", link.ContentMatched)
+		}
+		if len(requests) != 2 || requests[0] != http.MethodHead || requests[1] != http.MethodGet {
+			t.Errorf("requests = %v, want [HEAD GET]", requests)
+		}
+	})
+
+	t.Run("content not checked without match", func(t *testing.T) {
+		link := NewChecker().CheckURLWithContext(context.Background(), srv.URL)
+
+		if link.ContentMatched != nil {
+			t.Errorf("ContentMatched = %v, want nil", *link.ContentMatched)
+		}
+	})
+}
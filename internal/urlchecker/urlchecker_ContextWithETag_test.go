@@ -0,0 +1,55 @@
+package urlchecker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestChecker_ContextWithETag(t *testing.T) {
+	const current = `"abc123"`
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == current {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", current)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name          string
+		etag          string
+		wantUnchanged bool
+	}{
+		{name: "first check records etag", etag: ""},
+		{name: "matching etag is unchanged", etag: current, wantUnchanged: true},
+		{name: "stale etag is changed", etag: `"old"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.etag != "" {
+				ctx = ContextWithETag(ctx, tt.etag)
+			}
+
+			link := NewChecker().CheckURLWithContext(ctx, srv.URL)
+
+			if link.Status != models.LinkStatusAvailable {
+				t.Errorf("Status = %s, want %s", link.Status, models.LinkStatusAvailable)
+			}
+			if link.ETag != current {
+				t.Errorf("ETag = %s, want %s", link.ETag, current)
+			}
+			if link.Unchanged != tt.wantUnchanged {
+				t.Errorf("Unchanged = %v, want %v", link.Unchanged, tt.wantUnchanged)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package urlchecker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestChecker_ContextWithExpectedStatus(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusFound)
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		path       string
+		expected   string
+		wantStatus models.LinkStatus
+		wantError  string
+	}{
+		{name: "default rule accepts redirect", path: "/login", wantStatus: models.LinkStatusAvailable},
+		{name: "exact code matches", path: "/ok", expected: "200", wantStatus: models.LinkStatusAvailable},
+		{name: "redirect fails exact code", path: "/login", expected: "200", wantStatus: models.LinkStatusNotAvailable, wantError: models.LinkErrorUnexpectedStatus},
+		{name: "redirect matches class", path: "/login", expected: "3xx", wantStatus: models.LinkStatusAvailable},
+		{name: "expected 404 is available", path: "/missing", expected: "404", wantStatus: models.LinkStatusAvailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.expected != "" {
+				r, err := models.ParseStatusRange(tt.expected)
+				if err != nil {
+					t.Fatalf("ParseStatusRange(%q) error = %v", tt.expected, err)
+				}
+				ctx = ContextWithExpectedStatus(ctx, r)
+			}
+
+			link := NewChecker().CheckURLWithContext(ctx, srv.URL+tt.path)
+
+			if link.Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s", link.Status, tt.wantStatus)
+			}
+			if link.Error != tt.wantError {
+				t.Errorf("Error = %q, want %q", link.Error, tt.wantError)
+			}
+			if link.ExpectedStatus != tt.expected {
+				t.Errorf("ExpectedStatus = %q, want %q", link.ExpectedStatus, tt.expected)
+			}
+		})
+	}
+}
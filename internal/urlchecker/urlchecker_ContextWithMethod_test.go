@@ -0,0 +1,62 @@
+package urlchecker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestChecker_ContextWithMethod(t *testing.T) {
+	// the server only answers GET correctly, like some APIs do
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Method", r.Method)
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		method     string
+		wantMethod string
+		wantStatus models.LinkStatus
+	}{
+		{name: "default is HEAD", method: "", wantMethod: http.MethodHead, wantStatus: models.LinkStatusNotAvailable},
+		{name: "GET", method: http.MethodGet, wantMethod: http.MethodGet, wantStatus: models.LinkStatusAvailable},
+		{name: "OPTIONS", method: http.MethodOptions, wantMethod: http.MethodOptions, wantStatus: models.LinkStatusNotAvailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.method != "" {
+				ctx = ContextWithMethod(ctx, tt.method)
+			}
+
+			link := NewChecker().CheckURLWithContext(ctx, srv.URL)
+
+			if link.Method != tt.wantMethod {
+				t.Errorf("Method = %q, want %q", link.Method, tt.wantMethod)
+			}
+			if link.Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s", link.Status, tt.wantStatus)
+			}
+		})
+	}
+
+	t.Run("failed request records method", func(t *testing.T) {
+		ctx := ContextWithMethod(context.Background(), http.MethodGet)
+
+		link := NewChecker().CheckURLWithContext(ctx, "http://127.0.0.1:1")
+
+		if link.Method != http.MethodGet {
+			t.Errorf("Method = %q, want %q", link.Method, http.MethodGet)
+		}
+	})
+}
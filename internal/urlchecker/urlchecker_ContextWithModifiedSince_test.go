@@ -0,0 +1,54 @@
+package urlchecker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestChecker_ContextWithModifiedSince(t *testing.T) {
+	modified := time.Date(2024, time.January, 15, 10, 30, 0, 0, time.UTC)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if since, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil && !modified.After(since) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Last-Modified", modified.Format(http.TimeFormat))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name          string
+		since         time.Time
+		wantUnchanged bool
+	}{
+		{name: "first check is unconditional"},
+		{name: "not modified since last modification", since: modified, wantUnchanged: true},
+		{name: "not modified since later check", since: modified.Add(time.Hour).In(time.FixedZone("MSK", 3*60*60)), wantUnchanged: true},
+		{name: "modified since earlier version", since: modified.Add(-time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if !tt.since.IsZero() {
+				ctx = ContextWithModifiedSince(ctx, tt.since)
+			}
+
+			link := NewChecker().CheckURLWithContext(ctx, srv.URL)
+
+			if link.Status != models.LinkStatusAvailable {
+				t.Errorf("Status = %s, want %s", link.Status, models.LinkStatusAvailable)
+			}
+			if link.Unchanged != tt.wantUnchanged {
+				t.Errorf("Unchanged = %v, want %v", link.Unchanged, tt.wantUnchanged)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package urlchecker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestChecker_WithAccept(t *testing.T) {
+	// the server negotiates content and rejects requests not accepting HTML
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "text/html" {
+			w.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		opts       []Option
+		accept     string
+		wantStatus models.LinkStatus
+	}{
+		{name: "default accepts anything", wantStatus: models.LinkStatusNotAvailable},
+		{name: "empty keeps default", opts: []Option{WithAccept("")}, wantStatus: models.LinkStatusNotAvailable},
+		{name: "configured", opts: []Option{WithAccept("text/html")}, wantStatus: models.LinkStatusAvailable},
+		{name: "context overrides configured", opts: []Option{WithAccept("application/json")}, accept: "text/html", wantStatus: models.LinkStatusAvailable},
+		{name: "context overrides default", accept: "text/html", wantStatus: models.LinkStatusAvailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.accept != "" {
+				ctx = ContextWithAccept(ctx, tt.accept)
+			}
+
+			link := NewChecker(tt.opts...).CheckURLWithContext(ctx, srv.URL)
+
+			if link.Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s (status code %d)", link.Status, tt.wantStatus, link.StatusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package urlchecker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestChecker_WithBasicAuth(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			// move to the same server under another host name
+			http.Redirect(w, r, strings.Replace(srv.URL, "127.0.0.1", "localhost", 1)+"/private", http.StatusFound)
+			return
+		}
+		user, pass, ok := r.BasicAuth()
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if user != "user" || pass != "se:cret" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name  string
+		creds []HostCredentials
+		path  string
+		want  models.LinkStatus
+	}{
+		{name: "no credentials", path: "/private", want: models.LinkStatusNotAvailable},
+		{
+			name:  "credentials for the host",
+			creds: []HostCredentials{{Host: "127.0.0.*", Username: "user", Password: "se:cret"}},
+			path:  "/private",
+			want:  models.LinkStatusAvailable,
+		},
+		{
+			name:  "credentials for another host",
+			creds: []HostCredentials{{Host: "intranet.example.com", Username: "user", Password: "se:cret"}},
+			path:  "/private",
+			want:  models.LinkStatusNotAvailable,
+		},
+		{
+			name: "first matching entry wins",
+			creds: []HostCredentials{
+				{Host: "127.0.0.1", Username: "user", Password: "se:cret"},
+				{Host: "*", Username: "other", Password: "wrong"},
+			},
+			path: "/private",
+			want: models.LinkStatusAvailable,
+		},
+		{
+			name:  "redirect gets credentials of its host",
+			creds: []HostCredentials{{Host: "localhost", Username: "user", Password: "se:cret"}},
+			path:  "/redirect",
+			want:  models.LinkStatusAvailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewChecker(WithBasicAuth(tt.creds))
+
+			link := c.CheckURLWithContext(context.Background(), srv.URL+tt.path)
+
+			if link.Status != tt.want {
+				t.Errorf("CheckURLWithContext() status = %s (code %d), want %s", link.Status, link.StatusCode, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package urlchecker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestChecker_WithConnectionPool(t *testing.T) {
+	t.Run("uses tuned defaults", func(t *testing.T) {
+		c := NewChecker()
+
+		if !c.transport.ForceAttemptHTTP2 {
+			t.Error("NewChecker() transport does not attempt HTTP/2")
+		}
+		if c.transport.MaxIdleConnsPerHost != defaultMaxIdleConnsPerHost {
+			t.Errorf("NewChecker() MaxIdleConnsPerHost = %d, want %d", c.transport.MaxIdleConnsPerHost, defaultMaxIdleConnsPerHost)
+		}
+	})
+
+	t.Run("applies pool settings", func(t *testing.T) {
+		c := NewChecker(WithConnectionPool(50, 20, 30*time.Second))
+
+		if c.transport.MaxIdleConns != 50 {
+			t.Errorf("MaxIdleConns = %d, want 50", c.transport.MaxIdleConns)
+		}
+		if c.transport.MaxIdleConnsPerHost != 20 {
+			t.Errorf("MaxIdleConnsPerHost = %d, want 20", c.transport.MaxIdleConnsPerHost)
+		}
+		if c.transport.IdleConnTimeout != 30*time.Second {
+			t.Errorf("IdleConnTimeout = %v, want 30s", c.transport.IdleConnTimeout)
+		}
+	})
+
+	t.Run("keeps defaults for non-positive values", func(t *testing.T) {
+		c := NewChecker(WithConnectionPool(0, -1, 0))
+
+		if c.transport.MaxIdleConns != defaultMaxIdleConns {
+			t.Errorf("MaxIdleConns = %d, want %d", c.transport.MaxIdleConns, defaultMaxIdleConns)
+		}
+		if c.transport.IdleConnTimeout != defaultIdleConnTimeout {
+			t.Errorf("IdleConnTimeout = %v, want %v", c.transport.IdleConnTimeout, defaultIdleConnTimeout)
+		}
+	})
+
+	t.Run("combines with insecure TLS in any order", func(t *testing.T) {
+		srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer srv.Close()
+
+		c := NewChecker(WithInsecureSkipVerify(true, nil), WithConnectionPool(10, 5, time.Minute))
+
+		link := c.CheckURLWithContext(context.Background(), srv.URL)
+		if link.Status != models.LinkStatusAvailable {
+			t.Errorf("CheckURLWithContext() status = %s, want %s", link.Status, models.LinkStatusAvailable)
+		}
+	})
+}
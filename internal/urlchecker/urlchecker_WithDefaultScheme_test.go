@@ -0,0 +1,66 @@
+package urlchecker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestChecker_WithDefaultScheme(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	bareHost := strings.TrimPrefix(srv.URL, "http://")
+
+	t.Run("bare host fails over https without fallback", func(t *testing.T) {
+		c := NewChecker()
+
+		link := c.CheckURLWithContext(context.Background(), bareHost)
+
+		if link.Status != models.LinkStatusNotAvailable {
+			t.Errorf("CheckURLWithContext() status = %s, want %s", link.Status, models.LinkStatusNotAvailable)
+		}
+	})
+
+	t.Run("falls back to http for bare host", func(t *testing.T) {
+		c := NewChecker(WithDefaultScheme("https", true))
+
+		link := c.CheckURLWithContext(context.Background(), bareHost)
+
+		if link.Status != models.LinkStatusAvailable {
+			t.Errorf("CheckURLWithContext() status = %s, want %s", link.Status, models.LinkStatusAvailable)
+		}
+		if link.Scheme != "http" {
+			t.Errorf("CheckURLWithContext() scheme = %q, want http", link.Scheme)
+		}
+	})
+
+	t.Run("does not fall back for explicit https", func(t *testing.T) {
+		c := NewChecker(WithDefaultScheme("https", true))
+
+		link := c.CheckURLWithContext(context.Background(), "https://"+bareHost)
+
+		if link.Status != models.LinkStatusNotAvailable {
+			t.Errorf("CheckURLWithContext() status = %s, want %s", link.Status, models.LinkStatusNotAvailable)
+		}
+	})
+
+	t.Run("uses configured default scheme", func(t *testing.T) {
+		c := NewChecker(WithDefaultScheme("http", false))
+
+		link := c.CheckURLWithContext(context.Background(), bareHost)
+
+		if link.Status != models.LinkStatusAvailable {
+			t.Errorf("CheckURLWithContext() status = %s, want %s", link.Status, models.LinkStatusAvailable)
+		}
+		if link.Scheme != "http" {
+			t.Errorf("CheckURLWithContext() scheme = %q, want http", link.Scheme)
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package urlchecker
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestChecker_WithHeaderLogging(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Debug-Reason", "maintenance")
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		enabled bool
+	}{
+		{name: "disabled", enabled: false},
+		{name: "enabled", enabled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+			prev := slog.Default()
+			slog.SetDefault(logger)
+			defer slog.SetDefault(prev)
+
+			NewChecker(WithHeaderLogging(tt.enabled)).CheckURLWithContext(context.Background(), srv.URL)
+
+			out := buf.String()
+			if logged := strings.Contains(out, "maintenance"); logged != tt.enabled {
+				t.Errorf("headers logged = %v, want %v\n%s", logged, tt.enabled, out)
+			}
+			if strings.Contains(out, "secret") {
+				t.Errorf("Set-Cookie value must be redacted\n%s", out)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package urlchecker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestChecker_WithInsecureSkipVerify(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		enabled bool
+		hosts   []string
+		want    models.LinkStatus
+	}{
+		{name: "verification enabled by default", enabled: false, want: models.LinkStatusNotAvailable},
+		{name: "skip verification for all hosts", enabled: true, want: models.LinkStatusAvailable},
+		{name: "skip verification for matching host", enabled: true, hosts: []string{"127.0.0.*"}, want: models.LinkStatusAvailable},
+		{name: "keep verification for other hosts", enabled: true, hosts: []string{"*.staging.local"}, want: models.LinkStatusNotAvailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewChecker(WithInsecureSkipVerify(tt.enabled, tt.hosts))
+
+			link := c.CheckURLWithContext(context.Background(), srv.URL)
+
+			if link.Status != tt.want {
+				t.Errorf("CheckURLWithContext() status = %s, want %s", link.Status, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package urlchecker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestChecker_WithMaxBodySize(t *testing.T) {
+	// the marker sits behind 64 bytes of padding
+	body := strings.Repeat("x", 64) + "MARKER"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(body))
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		maxBody    int64
+		contains   string
+		wantStatus models.LinkStatus
+		wantError  string
+	}{
+		{name: "default limit reads whole body", contains: "MARKER", wantStatus: models.LinkStatusAvailable},
+		{name: "body of exactly the limit is not truncated", maxBody: int64(len(body)), contains: "MARKER", wantStatus: models.LinkStatusAvailable},
+		{name: "match in truncated part is body too large", maxBody: 32, contains: "MARKER", wantStatus: models.LinkStatusNotAvailable, wantError: models.LinkErrorBodyTooLarge},
+		{name: "match in read part passes", maxBody: 32, contains: "xxxx", wantStatus: models.LinkStatusAvailable},
+		{name: "mismatch on whole body is content mismatch", maxBody: 1024, contains: "Welcome", wantStatus: models.LinkStatusNotAvailable, wantError: models.LinkErrorContentMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := ContextWithContentMatch(context.Background(), models.ContentMatch{Contains: tt.contains})
+
+			link := NewChecker(WithMaxBodySize(tt.maxBody)).CheckURLWithContext(ctx, srv.URL)
+
+			if link.Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s", link.Status, tt.wantStatus)
+			}
+			if link.Error != tt.wantError {
+				t.Errorf("Error = %q, want %q", link.Error, tt.wantError)
+			}
+		})
+	}
+}
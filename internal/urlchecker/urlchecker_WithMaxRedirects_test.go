@@ -0,0 +1,57 @@
+package urlchecker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestChecker_WithMaxRedirects(t *testing.T) {
+	// /hops/N redirects N times before answering 200
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/hops/"))
+		if n > 0 {
+			http.Redirect(w, r, fmt.Sprintf("/hops/%d", n-1), http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name          string
+		maxRedirects  int
+		hops          int
+		wantStatus    models.LinkStatus
+		wantRedirects int
+		wantError     string
+	}{
+		{name: "no redirects", maxRedirects: 3, hops: 0, wantStatus: models.LinkStatusAvailable, wantRedirects: 0},
+		{name: "counts followed redirects", maxRedirects: 3, hops: 3, wantStatus: models.LinkStatusAvailable, wantRedirects: 3},
+		{name: "exceeds limit", maxRedirects: 3, hops: 4, wantStatus: models.LinkStatusNotAvailable, wantRedirects: 3, wantError: models.LinkErrorTooManyRedirects},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewChecker(WithMaxRedirects(tt.maxRedirects))
+
+			link := c.CheckURLWithContext(context.Background(), fmt.Sprintf("%s/hops/%d", srv.URL, tt.hops))
+
+			if link.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", link.Status, tt.wantStatus)
+			}
+			if link.RedirectCount != tt.wantRedirects {
+				t.Errorf("RedirectCount = %d, want %d", link.RedirectCount, tt.wantRedirects)
+			}
+			if link.Error != tt.wantError {
+				t.Errorf("Error = %q, want %q", link.Error, tt.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package urlchecker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestChecker_WithPrivateAddressBlocking(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		enabled bool
+		want    models.LinkStatus
+	}{
+		{name: "disabled allows loopback", enabled: false, want: models.LinkStatusAvailable},
+		{name: "enabled blocks loopback", enabled: true, want: models.LinkStatusBlockedPrivateAddress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = 0
+			link := NewChecker(WithPrivateAddressBlocking(tt.enabled)).CheckURLWithContext(context.Background(), srv.URL)
+
+			if link.Status != tt.want {
+				t.Errorf("Status = %s, want %s", link.Status, tt.want)
+			}
+			if tt.enabled && requests != 0 {
+				t.Errorf("server got %d requests, want none", requests)
+			}
+		})
+	}
+}
+
+func TestChecker_isPrivateAddress(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{addr: "127.0.0.1", want: true},
+		{addr: "10.1.2.3", want: true},
+		{addr: "172.16.0.1", want: true},
+		{addr: "192.168.1.1", want: true},
+		{addr: "169.254.169.254", want: true},
+		{addr: "0.0.0.0", want: true},
+		{addr: "::1", want: true},
+		{addr: "fe80::1", want: true},
+		{addr: "fd00::1", want: true},
+		{addr: "::ffff:127.0.0.1", want: true},
+		{addr: "93.184.216.34", want: false},
+		{addr: "2606:2800:220:1:248:1893:25c8:1946", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			if got := isPrivateAddress(netip.MustParseAddr(tt.addr)); got != tt.want {
+				t.Errorf("isPrivateAddress(%s) = %v, want %v", tt.addr, got, tt.want)
+			}
+		})
+	}
+}
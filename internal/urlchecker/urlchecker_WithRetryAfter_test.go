@@ -0,0 +1,160 @@
+package urlchecker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestChecker_WithRetryAfter(t *testing.T) {
+	// newServer answers the first limited requests with 429 and retryAfter, then with 200
+	newServer := func(limited int32, retryAfter string) (*httptest.Server, *atomic.Int32) {
+		var requests atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if requests.Add(1) <= limited {
+				if retryAfter != "" {
+					w.Header().Set("Retry-After", retryAfter)
+				}
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(srv.Close)
+		return srv, &requests
+	}
+
+	tests := []struct {
+		name         string
+		opts         []Option
+		limited      int32
+		retryAfter   string
+		timeout      time.Duration
+		wantStatus   models.LinkStatus
+		wantRetries  int
+		wantRequests int32
+	}{
+		{
+			name:         "disabled by default",
+			limited:      1,
+			retryAfter:   "0",
+			wantStatus:   models.LinkStatusNotAvailable,
+			wantRequests: 1,
+		},
+		{
+			name:         "retries after the delay in seconds",
+			opts:         []Option{WithRetryAfter(2, 0)},
+			limited:      2,
+			retryAfter:   "0",
+			wantStatus:   models.LinkStatusAvailable,
+			wantRetries:  2,
+			wantRequests: 3,
+		},
+		{
+			name:         "retries after a past HTTP date",
+			opts:         []Option{WithRetryAfter(1, 0)},
+			limited:      1,
+			retryAfter:   time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat),
+			wantStatus:   models.LinkStatusAvailable,
+			wantRetries:  1,
+			wantRequests: 2,
+		},
+		{
+			name:         "rate limited once retries run out",
+			opts:         []Option{WithRetryAfter(1, 0)},
+			limited:      5,
+			retryAfter:   "0",
+			wantStatus:   models.LinkStatusRateLimited,
+			wantRetries:  1,
+			wantRequests: 2,
+		},
+		{
+			name:         "no retry without Retry-After",
+			opts:         []Option{WithRetryAfter(3, 0)},
+			limited:      1,
+			wantStatus:   models.LinkStatusRateLimited,
+			wantRequests: 1,
+		},
+		{
+			name:         "no retry for a delay over the maximum",
+			opts:         []Option{WithRetryAfter(3, time.Second)},
+			limited:      1,
+			retryAfter:   "5",
+			wantStatus:   models.LinkStatusRateLimited,
+			wantRequests: 1,
+		},
+		{
+			name:         "no retry for a delay past the deadline",
+			opts:         []Option{WithRetryAfter(3, 0)},
+			limited:      1,
+			retryAfter:   "5",
+			timeout:      time.Second,
+			wantStatus:   models.LinkStatusRateLimited,
+			wantRequests: 1,
+		},
+		{
+			name:         "429 configured as available",
+			opts:         []Option{WithRetryAfter(1, 0), WithStatusCodes([]int{http.StatusTooManyRequests}, nil)},
+			limited:      5,
+			retryAfter:   "0",
+			wantStatus:   models.LinkStatusAvailable,
+			wantRetries:  1,
+			wantRequests: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, requests := newServer(tt.limited, tt.retryAfter)
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+			link := NewChecker(tt.opts...).CheckURLWithContext(ctx, srv.URL)
+
+			if link.Status != tt.wantStatus || link.RateLimitRetries != tt.wantRetries {
+				t.Errorf("Status = %q after %d retries, want %q after %d", link.Status, link.RateLimitRetries, tt.wantStatus, tt.wantRetries)
+			}
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("server got %d requests, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestChecker_parseRetryAfter(t *testing.T) {
+	now := time.Date(2024, time.March, 5, 8, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "seconds", value: "120", want: 2 * time.Minute, wantOK: true},
+		{name: "zero seconds", value: " 0 ", want: 0, wantOK: true},
+		{name: "HTTP date", value: now.Add(30 * time.Second).Format(http.TimeFormat), want: 30 * time.Second, wantOK: true},
+		{name: "past HTTP date", value: now.Add(-time.Minute).Format(http.TimeFormat), want: 0, wantOK: true},
+		{name: "missing", value: ""},
+		{name: "negative seconds", value: "-1"},
+		{name: "overflowing seconds", value: "99999999999999"},
+		{name: "garbage", value: "soon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value, now)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("parseRetryAfter(%q) = %v, %t, want %v, %t", tt.value, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package urlchecker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestChecker_WithSizeProbe(t *testing.T) {
+	const size = 2048
+
+	tests := []struct {
+		name          string
+		headLength    bool
+		rangeResponse bool
+		probe         bool
+		want          int64
+	}{
+		{name: "content length from HEAD", headLength: true, want: size},
+		{name: "missing length without probe", rangeResponse: true},
+		{name: "probe reads Content-Range", rangeResponse: true, probe: true, want: size},
+		{name: "probe falls back to full response length", probe: true, want: size},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var probed bool
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodHead {
+					if tt.headLength {
+						w.Header().Set("Content-Length", strconv.Itoa(size))
+					}
+					w.WriteHeader(http.StatusOK)
+					return
+				}
+
+				probed = true
+				if got := r.Header.Get("Range"); got != "bytes=0-0" {
+					t.Errorf("Range = %q, want bytes=0-0", got)
+				}
+				if tt.rangeResponse {
+					w.Header().Set("Content-Range", "bytes 0-0/"+strconv.Itoa(size))
+					w.WriteHeader(http.StatusPartialContent)
+					_, _ = w.Write([]byte("x"))
+					return
+				}
+				_, _ = w.Write(make([]byte, size))
+			}))
+			defer srv.Close()
+
+			link := NewChecker(WithSizeProbe(tt.probe)).CheckURLWithContext(context.Background(), srv.URL)
+
+			if link.ContentLength != tt.want {
+				t.Errorf("ContentLength = %d, want %d", link.ContentLength, tt.want)
+			}
+			if probed != (tt.probe && !tt.headLength) {
+				t.Errorf("probed = %v, want %v", probed, tt.probe && !tt.headLength)
+			}
+		})
+	}
+}
+
+func TestChecker_contentRangeSize(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{value: "bytes 0-0/1234", want: 1234},
+		{value: "bytes 0-0/*", wantErr: true},
+		{value: "items 0-0/10", wantErr: true},
+		{value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := contentRangeSize(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("contentRangeSize(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("contentRangeSize(%q) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
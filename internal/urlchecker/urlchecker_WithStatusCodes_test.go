@@ -0,0 +1,52 @@
+package urlchecker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestChecker_WithStatusCodes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		code, _ := strconv.Atoi(r.URL.Query().Get("code"))
+		w.WriteHeader(code)
+	}))
+	defer srv.Close()
+
+	c := NewChecker(WithStatusCodes([]int{401, 403}, []int{204}))
+
+	tests := []struct {
+		code int
+		want models.LinkStatus
+	}{
+		{code: http.StatusOK, want: models.LinkStatusAvailable},
+		{code: http.StatusNoContent, want: models.LinkStatusNotAvailable},
+		{code: http.StatusUnauthorized, want: models.LinkStatusAvailable},
+		{code: http.StatusForbidden, want: models.LinkStatusAvailable},
+		{code: http.StatusNotFound, want: models.LinkStatusNotAvailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.code), func(t *testing.T) {
+			link := c.CheckURLWithContext(context.Background(), srv.URL+"?code="+strconv.Itoa(tt.code))
+
+			if link.Status != tt.want {
+				t.Errorf("CheckURLWithContext() status = %s, want %s", link.Status, tt.want)
+			}
+		})
+	}
+
+	t.Run("expected status takes precedence", func(t *testing.T) {
+		ctx := ContextWithExpectedStatus(context.Background(), models.StatusRange{Min: 200, Max: 200})
+
+		link := c.CheckURLWithContext(ctx, srv.URL+"?code=401")
+
+		if link.Status != models.LinkStatusNotAvailable {
+			t.Errorf("CheckURLWithContext() status = %s, want %s", link.Status, models.LinkStatusNotAvailable)
+		}
+	})
+}
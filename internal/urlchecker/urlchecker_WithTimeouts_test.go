@@ -0,0 +1,75 @@
+package urlchecker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestChecker_WithTimeouts(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		c := NewChecker()
+
+		if c.newDialer().Timeout != defaultDialTimeout {
+			t.Errorf("dial timeout = %v, want %v", c.newDialer().Timeout, defaultDialTimeout)
+		}
+		if c.transport.ResponseHeaderTimeout != 0 {
+			t.Errorf("ResponseHeaderTimeout = %v, want none", c.transport.ResponseHeaderTimeout)
+		}
+	})
+
+	t.Run("keeps defaults for non-positive values", func(t *testing.T) {
+		c := NewChecker(WithTimeouts(0, -time.Second))
+
+		if c.newDialer().Timeout != defaultDialTimeout || c.transport.ResponseHeaderTimeout != 0 {
+			t.Errorf("timeouts = %v, %v, want defaults", c.newDialer().Timeout, c.transport.ResponseHeaderTimeout)
+		}
+	})
+
+	t.Run("combines with private address blocking in any order", func(t *testing.T) {
+		for _, c := range []*Checker{
+			NewChecker(WithTimeouts(time.Second, 0), WithPrivateAddressBlocking(true)),
+			NewChecker(WithPrivateAddressBlocking(true), WithTimeouts(time.Second, 0)),
+		} {
+			dialer := c.newDialer()
+			if dialer.Timeout != time.Second || dialer.Control == nil {
+				t.Errorf("dialer timeout = %v, blocks private addresses = %t, want 1s and true", dialer.Timeout, dialer.Control != nil)
+			}
+		}
+	})
+
+	// the server takes a while before sending response headers
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(200 * time.Millisecond):
+		case <-release:
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	t.Run("response header timeout fails slow responses", func(t *testing.T) {
+		link := NewChecker(WithTimeouts(0, 20*time.Millisecond)).CheckURLWithContext(context.Background(), srv.URL)
+
+		if link.Status != models.LinkStatusNotAvailable || link.Error != models.LinkErrorTimeout {
+			t.Errorf("Status = %s, Error = %q, want not available with timeout", link.Status, link.Error)
+		}
+		if link.Duration >= 200*time.Millisecond {
+			t.Errorf("Duration = %v, want the check to give up before the response", link.Duration)
+		}
+	})
+
+	t.Run("slow responses are waited for by default", func(t *testing.T) {
+		link := NewChecker(WithTimeouts(time.Second, 0)).CheckURLWithContext(context.Background(), srv.URL)
+
+		if link.Status != models.LinkStatusAvailable {
+			t.Errorf("Status = %s, want %s", link.Status, models.LinkStatusAvailable)
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package urlchecker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestChecker_WithTiming(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	t.Run("disabled by default", func(t *testing.T) {
+		c := NewChecker(WithInsecureSkipVerify(true, nil))
+
+		link := c.CheckURLWithContext(context.Background(), srv.URL)
+
+		if link.Timing != nil {
+			t.Errorf("Timing = %+v, want nil", link.Timing)
+		}
+	})
+
+	t.Run("breakdown of a new connection", func(t *testing.T) {
+		c := NewChecker(WithInsecureSkipVerify(true, nil), WithTiming(true))
+
+		link := c.CheckURLWithContext(context.Background(), srv.URL)
+
+		if link.Timing == nil {
+			t.Fatal("Timing = nil, want breakdown")
+		}
+		if link.Timing.Connect <= 0 {
+			t.Errorf("Connect = %s, want positive", link.Timing.Connect)
+		}
+		if link.Timing.TLS <= 0 {
+			t.Errorf("TLS = %s, want positive", link.Timing.TLS)
+		}
+		if link.Timing.TTFB < 20*time.Millisecond || link.Timing.TTFB > link.Duration {
+			t.Errorf("TTFB = %s, want between 20ms and duration %s", link.Timing.TTFB, link.Duration)
+		}
+
+		// the second check reuses the pooled connection
+		link = c.CheckURLWithContext(context.Background(), srv.URL)
+
+		if link.Timing == nil {
+			t.Fatal("Timing = nil, want breakdown")
+		}
+		if link.Timing.Connect != 0 || link.Timing.TLS != 0 {
+			t.Errorf("reused connection Connect = %s, TLS = %s, want 0", link.Timing.Connect, link.Timing.TLS)
+		}
+	})
+
+	t.Run("failed request", func(t *testing.T) {
+		c := NewChecker(WithTiming(true))
+
+		link := c.CheckURLWithContext(context.Background(), "http://127.0.0.1:1")
+
+		if link.Timing == nil {
+			t.Fatal("Timing = nil, want breakdown")
+		}
+		if link.Timing.TTFB != 0 {
+			t.Errorf("TTFB = %s, want 0 without a response", link.Timing.TTFB)
+		}
+	})
+}
@@ -0,0 +1,43 @@
+package urlchecker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestChecker_WithUserAgent(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("User-Agent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name      string
+		userAgent string
+		want      string
+	}{
+		{name: "default", want: defaultUserAgent},
+		{name: "custom", userAgent: "LinkAudit/2.0 (+https://example.com/bot)", want: "LinkAudit/2.0 (+https://example.com/bot)"},
+		{name: "empty keeps default", userAgent: "", want: defaultUserAgent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewChecker(WithUserAgent(tt.userAgent))
+
+			c.CheckURLWithContext(context.Background(), srv.URL)
+			if got != tt.want {
+				t.Errorf("CheckURLWithContext() User-Agent = %q, want %q", got, tt.want)
+			}
+
+			c.CheckURL(srv.URL)
+			if got != tt.want {
+				t.Errorf("CheckURL() User-Agent = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package urlchecker
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/url"
+	"syscall"
+	"testing"
+)
+
+func TestChecker_isFallbackError(t *testing.T) {
+	wrap := func(err error) error {
+		return &url.Error{Op: "Head", URL: "https://example.com", Err: err}
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "connection refused", err: wrap(&net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}), want: true},
+		{name: "plain http on https port", err: wrap(errors.New("http: " + plainHTTPResponseError)), want: true},
+		{name: "tls record header", err: wrap(tls.RecordHeaderError{Msg: "first record does not look like a TLS handshake"}), want: true},
+		{name: "certificate verification", err: wrap(&tls.CertificateVerificationError{Err: errors.New("unknown authority")}), want: true},
+		{name: "too many redirects", err: wrap(errTooManyRedirects), want: false},
+		{name: "context canceled", err: wrap(context.Canceled), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isFallbackError(tt.err); got != tt.want {
+				t.Errorf("isFallbackError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
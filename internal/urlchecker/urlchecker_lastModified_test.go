@@ -0,0 +1,47 @@
+package urlchecker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestChecker_lastModified(t *testing.T) {
+	modified := time.Date(2024, time.March, 5, 8, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		header string
+		want   *time.Time
+	}{
+		{name: "valid header", header: modified.Format(http.TimeFormat), want: &modified},
+		{name: "missing header", header: ""},
+		{name: "unparseable header", header: "last tuesday"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.header != "" {
+					w.Header().Set("Last-Modified", tt.header)
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer srv.Close()
+
+			link := NewChecker().CheckURLWithContext(context.Background(), srv.URL)
+
+			if tt.want == nil {
+				if link.LastModified != nil {
+					t.Errorf("LastModified = %v, want nil", *link.LastModified)
+				}
+				return
+			}
+			if link.LastModified == nil || !link.LastModified.Equal(*tt.want) {
+				t.Errorf("LastModified = %v, want %v", link.LastModified, *tt.want)
+			}
+		})
+	}
+}
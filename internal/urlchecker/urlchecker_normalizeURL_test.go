@@ -0,0 +1,93 @@
+package urlchecker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/polonkoevv/linkchecker/internal/models"
+)
+
+func TestChecker_normalizeURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "adds https scheme", input: "example.com", want: "https://example.com"},
+		{name: "keeps http scheme", input: "http://example.com/path", want: "http://example.com/path"},
+		{name: "lowercases scheme", input: "HTTPS://example.com", want: "https://example.com"},
+		{name: "bracketed IPv6", input: "[2001:db8::1]", want: "https://[2001:db8::1]"},
+		{name: "bracketed IPv6 with port", input: "[::1]:8080", want: "https://[::1]:8080"},
+		{name: "bare IPv6", input: "2001:db8::1", want: "https://[2001:db8::1]"},
+		{name: "bare IPv6 with path", input: "2001:db8::1/health", want: "https://[2001:db8::1]/health"},
+		{name: "IPv6 with scheme", input: "http://[2001:db8::1]:8080/", want: "http://[2001:db8::1]:8080/"},
+		{name: "hostname with port", input: "localhost:3000", want: "https://localhost:3000"},
+		{name: "IPv4 with port", input: "192.168.1.1:80", want: "https://192.168.1.1:80"},
+		{name: "IPv4 with port and path", input: "192.168.1.1:80/status", want: "https://192.168.1.1:80/status"},
+		{name: "trims whitespace", input: "  example.com  ", want: "https://example.com"},
+		{name: "empty input", input: "", wantErr: true},
+		{name: "port out of range", input: "localhost:99999", wantErr: true},
+		{name: "non-numeric port", input: "localhost:abc", wantErr: true},
+		{name: "missing host", input: "https://", wantErr: true},
+		{name: "unsupported scheme", input: "ftp://example.com", wantErr: true},
+		{name: "opaque unsupported scheme", input: "mailto:admin@example.com", wantErr: true},
+		{name: "link-local IPv6", input: "fe80::1", want: "https://[fe80::1]"},
+		{name: "URL in query without scheme", input: "example.com/?next=http://other.com", want: "https://example.com/?next=http://other.com"},
+	}
+
+	c := NewChecker()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.normalizeURL(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("normalizeURL(%q) = %q, want error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("normalizeURL(%q) error = %v, want nil", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("normalizeURL(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChecker_CheckURLWithContext_UnsupportedScheme(t *testing.T) {
+	c := NewChecker()
+
+	for _, raw := range []string{"ftp://files.example.com/pub", "mailto:admin@example.com", "javascript:void(0)"} {
+		link := c.CheckURLWithContext(context.Background(), raw)
+		if link.Status != models.LinkStatusUnsupportedScheme {
+			t.Errorf("CheckURLWithContext(%q) status = %s, want %s", raw, link.Status, models.LinkStatusUnsupportedScheme)
+		}
+	}
+
+	link := c.CheckURLWithContext(context.Background(), "localhost:99999")
+	if link.Status != models.LinkStatusNotAvailable {
+		t.Errorf("CheckURLWithContext(invalid port) status = %s, want %s", link.Status, models.LinkStatusNotAvailable)
+	}
+}
+
+func TestChecker_normalizeURL_DefaultScheme(t *testing.T) {
+	c := NewChecker(WithDefaultScheme("http", false))
+
+	got, err := c.normalizeURL("intranet.local/status")
+	if err != nil {
+		t.Fatalf("normalizeURL() error = %v, want nil", err)
+	}
+	if got != "http://intranet.local/status" {
+		t.Errorf("normalizeURL() = %q, want %q", got, "http://intranet.local/status")
+	}
+
+	got, err = c.normalizeURL("https://example.com")
+	if err != nil {
+		t.Fatalf("normalizeURL() error = %v, want nil", err)
+	}
+	if got != "https://example.com" {
+		t.Errorf("normalizeURL() = %q, want explicit scheme kept", got)
+	}
+}